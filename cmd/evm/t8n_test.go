@@ -113,6 +113,7 @@ func TestT8n(t *testing.T) {
 	for i, tc := range []struct {
 		base        string
 		input       t8nInput
+		extraArgs   []string
 		output      t8nOutput
 		expExitCode int
 		expOut      string
@@ -286,10 +287,20 @@ func TestT8n(t *testing.T) {
 			output: t8nOutput{alloc: true, result: true},
 			expOut: "exp.json",
 		},
+		{ // Olivetum rules: transfer burn, minimum amount from the env, rejected transfers
+			base: "./testdata/31",
+			input: t8nInput{
+				"alloc.json", "txs.json", "env.json", "Berlin", "-1",
+			},
+			extraArgs: []string{"--state.chainid", "30216931"},
+			output:    t8nOutput{alloc: true, result: true},
+			expOut:    "exp.json",
+		},
 	} {
 		args := []string{"t8n"}
 		args = append(args, tc.output.get()...)
 		args = append(args, tc.input.get(tc.base)...)
+		args = append(args, tc.extraArgs...)
 		var qArgs []string // quoted args for debugging purposes
 		for _, arg := range args {
 			if len(arg) == 0 {
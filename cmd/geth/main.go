@@ -101,6 +101,10 @@ var (
 		utils.TxLookupLimitFlag, // deprecated
 		utils.TransactionHistoryFlag,
 		utils.StateHistoryFlag,
+		utils.OlivetumSupplyExcludeFlag,
+		utils.OlivetumAccountIndexFlag,
+		utils.OlivetumHolderIndexFlag,
+		utils.OlivetumRichIndexFlag,
 		utils.LightServeFlag,    // deprecated
 		utils.LightIngressFlag,  // deprecated
 		utils.LightEgressFlag,   // deprecated
@@ -157,6 +161,7 @@ var (
 		utils.VMEnableDebugFlag,
 		utils.NetworkIdFlag,
 		utils.EthStatsURLFlag,
+		utils.EthStatsOlivetumFlag,
 		utils.FakePoWFlag,
 		utils.FakePoWPoissonFlag,
 		utils.NoCompactionFlag,
@@ -264,6 +269,8 @@ func init() {
 		snapshotCommand,
 		// See verkle.go
 		verkleCommand,
+		// See olivetumcmd.go
+		olivetumCommand,
 	}
 	if logTestCommand != nil {
 		app.Commands = append(app.Commands, logTestCommand)
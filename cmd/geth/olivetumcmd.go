@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/cmd/utils"
+	"github.com/ethereum/go-ethereum/consensus/olivetumhash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/internal/flags"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	olivetumBlockFlag = &cli.Uint64Flag{
+		Name:  "block",
+		Usage: "Block number to inspect (defaults to the current head)",
+	}
+	olivetumJSONFlag = &cli.BoolFlag{
+		Name:  "json",
+		Usage: "Print the result as JSON",
+	}
+	olivetumAllFlag = &cli.BoolFlag{
+		Name:  "all",
+		Usage: "Include holders that are not eligible",
+	}
+	olivetumOutputFlag = &cli.StringFlag{
+		Name:  "output",
+		Usage: "File to write the result to (defaults to stdout)",
+	}
+
+	olivetumCommand = &cli.Command{
+		Name:  "olivetum",
+		Usage: "Olivetum chain maintenance commands",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "audit-supply",
+				Usage:  "Check the supply counters against a replay of the chain",
+				Action: auditSupply,
+				Flags: flags.Merge([]cli.Flag{
+					olivetumBlockFlag,
+					olivetumJSONFlag,
+				}, utils.NetworkFlags, utils.DatabaseFlags),
+				Description: `
+geth olivetum audit-supply [--block N] [--json]
+replays block rewards, transfer and gas burns, dividend claims and claim tips
+from genesis up to the given block and compares them, together with the
+economy baseline, against the RewardVault counters and the sum of all account
+balances in the state of that block. Every discrepancy is reported and the
+command fails if there is any. Only the state of the audited block is needed.
+`,
+			},
+			{
+				Name:   "dividend-snapshot",
+				Usage:  "Export the dividend holders of a block",
+				Action: dividendSnapshot,
+				Flags: flags.Merge([]cli.Flag{
+					olivetumBlockFlag,
+					olivetumJSONFlag,
+					olivetumAllFlag,
+					olivetumOutputFlag,
+				}, utils.NetworkFlags, utils.DatabaseFlags),
+				Description: `
+geth olivetum dividend-snapshot [--block N] [--json] [--all] [--output FILE]
+lists the accounts eligible for the current dividend round at the time of the
+given block, with their eligible amounts, projected rewards and claim status,
+as CSV or JSON. Recent entries old enough to qualify are counted as a claim
+would count them. Account addresses are recovered from the preimage store, so
+the node must have been run with preimage recording enabled.
+`,
+			},
+		},
+	}
+)
+
+func auditSupply(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, db := utils.MakeChain(ctx, stack, true)
+	defer db.Close()
+
+	if !params.IsOlivetumConfig(chain.Config()) {
+		return errors.New("not an Olivetum chain")
+	}
+	genesis, err := core.ReadGenesis(db)
+	if err != nil {
+		return fmt.Errorf("failed to read genesis: %v", err)
+	}
+	number := chain.CurrentBlock().Number.Uint64()
+	if ctx.IsSet(olivetumBlockFlag.Name) {
+		number = ctx.Uint64(olivetumBlockFlag.Name)
+	}
+	audit, err := olivetumhash.AuditSupply(chain, genesis, number)
+	if err != nil {
+		return err
+	}
+	if ctx.Bool(olivetumJSONFlag.Name) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(audit); err != nil {
+			return err
+		}
+	} else {
+		printSupplyAudit(audit)
+	}
+	if n := len(audit.Discrepancies); n > 0 {
+		return fmt.Errorf("%d supply discrepancies found", n)
+	}
+	return nil
+}
+
+func dividendSnapshot(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chain, db := utils.MakeChain(ctx, stack, true)
+	defer db.Close()
+
+	if !params.IsOlivetumConfig(chain.Config()) {
+		return errors.New("not an Olivetum chain")
+	}
+	header := chain.CurrentBlock()
+	if ctx.IsSet(olivetumBlockFlag.Name) {
+		number := ctx.Uint64(olivetumBlockFlag.Name)
+		if header = chain.GetHeaderByNumber(number); header == nil {
+			return fmt.Errorf("block #%d not found", number)
+		}
+	}
+	statedb, err := chain.StateAt(header.Root)
+	if err != nil {
+		return fmt.Errorf("state of block #%d unavailable: %v", header.Number, err)
+	}
+	snap := core.TakeDividendSnapshot(statedb, header, ctx.Bool(olivetumAllFlag.Name))
+	if snap.MissingPreimages > 0 {
+		log.Warn("Skipped accounts without address preimage", "count", snap.MissingPreimages)
+	}
+	out := os.Stdout
+	if path := ctx.String(olivetumOutputFlag.Name); path != "" {
+		if out, err = os.Create(path); err != nil {
+			return err
+		}
+		defer out.Close()
+	}
+	if ctx.Bool(olivetumJSONFlag.Name) {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(snap)
+	}
+	return snap.WriteCSV(out)
+}
+
+func printSupplyAudit(audit *olivetumhash.SupplyAudit) {
+	fmt.Printf("Block:              #%d [%x]\n", audit.Block, audit.Hash)
+	fmt.Printf("Accounts:           %d\n", audit.Accounts)
+	fmt.Printf("Genesis allocation: %v\n", audit.GenesisAlloc)
+	fmt.Printf("Sum of balances:    %v\n\n", audit.Balances)
+
+	fmt.Printf("%-24s %32s %32s\n", "Counter", "Recorded", "Expected")
+	rows := []struct {
+		name               string
+		recorded, expected fmt.Stringer
+	}{
+		{"totalMinted", audit.Counters.Minted, audit.Expected.Minted},
+		{"totalBurned", audit.Counters.Burned, audit.Expected.Burned},
+		{"totalBurnedTransfers", audit.Counters.BurnedTransfers, audit.Expected.BurnedTransfers},
+		{"totalBurnedGas", audit.Counters.BurnedGas, audit.Expected.BurnedGas},
+		{"totalMinerBurnShare", audit.Counters.MinerBurnShare, audit.Expected.MinerBurnShare},
+		{"totalDividendsMinted", audit.Counters.DividendsMinted, audit.Expected.DividendsMinted},
+		{"totalBurnedRewards", audit.Counters.BurnedRewards, audit.Expected.BurnedRewards},
+	}
+	for _, row := range rows {
+		fmt.Printf("%-24s %32v %32v\n", row.name, row.recorded, row.expected)
+	}
+	fmt.Println()
+	fmt.Printf("Block rewards:      %v\n", audit.Flows.Rewards)
+	fmt.Printf("Reward burns:       %v\n", audit.Flows.RewardBurned)
+	fmt.Printf("  untracked:        %v\n", audit.Flows.UntrackedBurned)
+	fmt.Printf("Claim tips:         %v\n", audit.Flows.ClaimTips)
+	fmt.Printf("Dividends:          %v\n", audit.Flows.Dividends)
+	fmt.Printf("Transfer burns:     %v\n", audit.Flows.TransferBurned)
+	fmt.Printf("Gas burns:          %v\n", audit.Flows.GasBurned)
+	fmt.Printf("Base fee burns:     %v\n\n", audit.Flows.BaseFeeBurned)
+
+	if len(audit.Discrepancies) == 0 {
+		fmt.Println("No discrepancies found")
+		return
+	}
+	fmt.Println("Discrepancies:")
+	for _, d := range audit.Discrepancies {
+		fmt.Printf("  %s: recorded %v, expected %v (diff %v)\n", d.Item, d.Recorded, d.Expected, d.Diff)
+	}
+}
@@ -330,6 +330,26 @@ var (
 		Value:    ethconfig.Defaults.TransactionHistory,
 		Category: flags.StateCategory,
 	}
+	OlivetumSupplyExcludeFlag = &cli.StringFlag{
+		Name:     "olivetum.supply.exclude",
+		Usage:    "Comma separated accounts excluded from the Olivetum circulating supply (default = reward vault, burn admin and management contracts)",
+		Category: flags.EthCategory,
+	}
+	OlivetumAccountIndexFlag = &cli.BoolFlag{
+		Name:     "olivetum.accountindex",
+		Usage:    "Index per-account Olivetum burns, gas fees, miner burn shares and dividend claims (olivetum_getAccountEconomy)",
+		Category: flags.EthCategory,
+	}
+	OlivetumHolderIndexFlag = &cli.BoolFlag{
+		Name:     "olivetum.holderindex",
+		Usage:    "Index the Olivetum dividend holders, seeded from the state snapshot (olivetum_getDividendHolders)",
+		Category: flags.EthCategory,
+	}
+	OlivetumRichIndexFlag = &cli.BoolFlag{
+		Name:     "olivetum.richindex",
+		Usage:    "Index the Olivetum accounts by balance, seeded from the state snapshot (olivetum_getRichList)",
+		Category: flags.EthCategory,
+	}
 	// Light server and client settings
 	LightServeFlag = &cli.IntFlag{
 		Name:     "light.serve",
@@ -723,6 +743,11 @@ var (
 		Usage:    "Reporting URL of a ethstats service (nodename:secret@host:port)",
 		Category: flags.MetricsCategory,
 	}
+	EthStatsOlivetumFlag = &cli.BoolFlag{
+		Name:     "ethstats.olivetum",
+		Usage:    "Report the Olivetum supply, runtime configuration, finalized height and gateway stats to the ethstats service",
+		Category: flags.MetricsCategory,
+	}
 	FakePoWFlag = &cli.BoolFlag{
 		Name:     "fakepow",
 		Usage:    "Disables proof-of-work verification",
@@ -2026,6 +2051,25 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 		log.Warn("The flag --txlookuplimit is deprecated and will be removed, please use --history.transactions")
 		cfg.TransactionHistory = ctx.Uint64(TxLookupLimitFlag.Name)
 	}
+	if ctx.IsSet(OlivetumSupplyExcludeFlag.Name) {
+		cfg.OlivetumSupplyExclude = nil
+		for _, account := range strings.Split(ctx.String(OlivetumSupplyExcludeFlag.Name), ",") {
+			trimmed := strings.TrimSpace(account)
+			if !common.IsHexAddress(trimmed) {
+				Fatalf("Invalid account in --%s: %s", OlivetumSupplyExcludeFlag.Name, trimmed)
+			}
+			cfg.OlivetumSupplyExclude = append(cfg.OlivetumSupplyExclude, common.HexToAddress(trimmed))
+		}
+	}
+	if ctx.IsSet(OlivetumAccountIndexFlag.Name) {
+		cfg.OlivetumAccountIndex = ctx.Bool(OlivetumAccountIndexFlag.Name)
+	}
+	if ctx.IsSet(OlivetumHolderIndexFlag.Name) {
+		cfg.OlivetumHolderIndex = ctx.Bool(OlivetumHolderIndexFlag.Name)
+	}
+	if ctx.IsSet(OlivetumRichIndexFlag.Name) {
+		cfg.OlivetumRichIndex = ctx.Bool(OlivetumRichIndexFlag.Name)
+	}
 	if ctx.String(GCModeFlag.Name) == gcModeArchive && cfg.TransactionHistory != 0 {
 		cfg.TransactionHistory = 0
 		log.Warn("Disabled transaction unindexing for archive node")
@@ -2312,8 +2356,8 @@ func RegisterEthService(stack *node.Node, cfg *ethconfig.Config) (ethapi.Backend
 }
 
 // RegisterEthStatsService configures the Ethereum Stats daemon and adds it to the node.
-func RegisterEthStatsService(stack *node.Node, backend ethapi.Backend, url string) {
-	if err := ethstats.New(stack, backend, backend.Engine(), url); err != nil {
+func RegisterEthStatsService(stack *node.Node, backend ethapi.Backend, url string, olivetum bool) {
+	if err := ethstats.New(stack, backend, backend.Engine(), url, olivetum); err != nil {
 		Fatalf("Failed to register the Ethereum Stats service: %v", err)
 	}
 }
@@ -129,10 +129,15 @@ func (api *API) SubmitHashrateFor(address common.Address, rate hexutil.Uint64, i
 
 // GatewayStats returns aggregated stats for miners using getWorkFor/submitWorkFor.
 func (api *API) GatewayStats() GatewayStats {
-	if api.olivetumhash.remote == nil {
+	return api.olivetumhash.GatewayStats()
+}
+
+// GatewayStats returns aggregated stats for miners using getWorkFor/submitWorkFor.
+func (o *Olivetumhash) GatewayStats() GatewayStats {
+	if o.remote == nil {
 		return GatewayStats{}
 	}
-	return api.olivetumhash.remote.snapshotStats(gatewayActiveWindow)
+	return o.remote.snapshotStats(gatewayActiveWindow)
 }
 
 // PublicAPIs returns the RPC descriptors for the Olivetumhash API.
@@ -0,0 +1,393 @@
+package olivetumhash
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+)
+
+// SupplyAuditChain is the chain access needed to audit the supply.
+type SupplyAuditChain interface {
+	Config() ctypes.ChainConfigurator
+	GetBlockByNumber(number uint64) *types.Block
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+	StateAt(root common.Hash) (*state.StateDB, error)
+	OlivetumPayoutLogs(hash common.Hash, number uint64) []*types.Log
+}
+
+// SupplyCounters are the RewardVault supply counters.
+type SupplyCounters struct {
+	Minted          *big.Int `json:"totalMinted"`
+	Burned          *big.Int `json:"totalBurned"`
+	BurnedTransfers *big.Int `json:"totalBurnedTransfers"`
+	BurnedGas       *big.Int `json:"totalBurnedGas"`
+	MinerBurnShare  *big.Int `json:"totalMinerBurnShare"`
+	DividendsMinted *big.Int `json:"totalDividendsMinted"`
+	BurnedRewards   *big.Int `json:"totalBurnedRewards"`
+}
+
+func newSupplyCounters() SupplyCounters {
+	return SupplyCounters{new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int)}
+}
+
+func readSupplyCounters(s *state.StateDB) SupplyCounters {
+	return SupplyCounters{
+		Minted:          core.GetTotalMinted(s),
+		Burned:          core.GetTotalBurned(s),
+		BurnedTransfers: core.GetTotalBurnedTransfers(s),
+		BurnedGas:       core.GetTotalBurnedGas(s),
+		MinerBurnShare:  core.GetTotalMinerBurnShare(s),
+		DividendsMinted: core.GetTotalDividendsMinted(s),
+		BurnedRewards:   core.GetTotalBurnedRewards(s),
+	}
+}
+
+// SupplyFlows are the supply movements replayed from genesis, regardless of
+// whether the counters track them.
+type SupplyFlows struct {
+	Rewards         *big.Int `json:"rewards"`
+	RewardBurned    *big.Int `json:"rewardBurned"`
+	UntrackedBurned *big.Int `json:"untrackedRewardBurned"`
+	ClaimTips       *big.Int `json:"claimTips"`
+	Dividends       *big.Int `json:"dividends"`
+	TransferBurned  *big.Int `json:"transferBurned"`
+	GasBurned       *big.Int `json:"gasBurned"`
+	MinerBurnShare  *big.Int `json:"minerBurnShare"`
+	BaseFeeBurned   *big.Int `json:"baseFeeBurned"`
+	PreForkBurned   *big.Int `json:"preForkBurned"`
+	PreForkShare    *big.Int `json:"preForkMinerBurnShare"`
+	PreForkDividend *big.Int `json:"preForkDividends"`
+}
+
+// SupplyDiscrepancy is a mismatch between a recorded and a replayed value.
+type SupplyDiscrepancy struct {
+	Item     string   `json:"item"`
+	Recorded *big.Int `json:"recorded"`
+	Expected *big.Int `json:"expected"`
+	Diff     *big.Int `json:"diff"`
+}
+
+// SupplyAudit is the result of AuditSupply.
+type SupplyAudit struct {
+	Block         uint64              `json:"block"`
+	Hash          common.Hash         `json:"hash"`
+	GenesisAlloc  *big.Int            `json:"genesisAlloc"`
+	Balances      *big.Int            `json:"balances"`
+	Accounts      uint64              `json:"accounts"`
+	Counters      SupplyCounters      `json:"counters"`
+	Expected      SupplyCounters      `json:"expectedCounters"`
+	Flows         SupplyFlows         `json:"flows"`
+	Discrepancies []SupplyDiscrepancy `json:"discrepancies"`
+}
+
+// supplyAuditor replays the supply movements of each block.
+type supplyAuditor struct {
+	chain    SupplyAuditChain
+	config   ctypes.ChainConfigurator
+	rate     uint64
+	expected SupplyCounters
+	flows    SupplyFlows
+}
+
+// AuditSupply replays block rewards, transfer and gas burns, dividend claims
+// and claim tips from genesis up to the given block, and compares the result
+// with the RewardVault counters and the sum of all balances in the state of
+// that block. The economy baseline is added to the expected counters at the
+// economy fork, and the replayed pre-fork amounts it stands for are reported
+// next to it. Only the state of the audited block is required.
+func AuditSupply(chain SupplyAuditChain, genesis *genesisT.Genesis, number uint64) (*SupplyAudit, error) {
+	target := chain.GetBlockByNumber(number)
+	if target == nil {
+		return nil, fmt.Errorf("block #%d not found", number)
+	}
+	statedb, err := chain.StateAt(target.Root())
+	if err != nil {
+		return nil, fmt.Errorf("state of block #%d unavailable: %v", number, err)
+	}
+	a := &supplyAuditor{
+		chain:    chain,
+		config:   chain.Config(),
+		rate:     core.GenesisBurnRate(genesis.Alloc),
+		expected: newSupplyCounters(),
+	}
+	a.flows = SupplyFlows{new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int)}
+
+	alloc := new(big.Int)
+	for _, account := range genesis.Alloc {
+		if account.Balance != nil {
+			alloc.Add(alloc, account.Balance)
+		}
+	}
+
+	logged := time.Now()
+	for n := uint64(1); n <= number; n++ {
+		block := chain.GetBlockByNumber(n)
+		if block == nil {
+			return nil, fmt.Errorf("block #%d not found", n)
+		}
+		receipts := chain.GetReceiptsByHash(block.Hash())
+		if len(receipts) != len(block.Transactions()) {
+			return nil, fmt.Errorf("receipts of block #%d unavailable", n)
+		}
+		if err := a.replayBlock(block, receipts); err != nil {
+			return nil, err
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Auditing Olivetum supply", "block", n, "target", number)
+			logged = time.Now()
+		}
+	}
+
+	audit := &SupplyAudit{
+		Block:        number,
+		Hash:         target.Hash(),
+		GenesisAlloc: alloc,
+		Balances:     new(big.Int),
+		Counters:     readSupplyCounters(statedb),
+		Expected:     a.expected,
+		Flows:        a.flows,
+	}
+	collector := &balanceCollector{sum: audit.Balances}
+	statedb.DumpToCollector(collector, &state.DumpConfig{SkipCode: true, SkipStorage: true})
+	if collector.err != nil {
+		return nil, collector.err
+	}
+	audit.Accounts = collector.accounts
+	audit.compare(number >= params.GetEconomyForkBlock().Uint64() && params.GetEconomyForkBlock().Sign() > 0)
+	return audit, nil
+}
+
+func (a *supplyAuditor) replayBlock(block *types.Block, receipts types.Receipts) error {
+	var (
+		header   = block.Header()
+		signer   = types.MakeSigner(a.config, header.Number, header.Time)
+		economy  = isEconomyForkAt(header.Number)
+		share    = isBurnShareAt(header.Number)
+		isBatch  = params.BatchTransferFork.Active(header.Number)
+		baseFees = new(big.Int)
+	)
+	for i, tx := range block.Transactions() {
+		receipt := receipts[i]
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			return fmt.Errorf("block #%d tx %d: %v", header.Number, i, err)
+		}
+		if tx.Value().Sign() > 0 {
+			a.transferBurn(tx.Value(), economy, share)
+		}
+		to := tx.To()
+		if to != nil && *to == params.BatchTransferContract && isBatch && receipt.Status == types.ReceiptStatusSuccessful {
+			entries, _ := params.DecodeBatchTransfer(tx.Data())
+			for _, e := range entries {
+				a.transferBurn(e.Amount, economy, share)
+			}
+		}
+		if to != nil && *to == core.BurnContract && from == core.BurnAdmin && receipt.Status == types.ReceiptStatusSuccessful {
+			if rate, ok := core.DecodeBurnRate(tx.Data()); ok {
+				a.rate = rate
+			}
+		}
+		for _, l := range receipt.Logs {
+			if l.Address != core.DividendContract || len(l.Topics) == 0 || l.Topics[0] != core.DividendClaimedTopic {
+				continue
+			}
+			a.dividendClaim(new(big.Int).SetBytes(l.Data), economy, true)
+		}
+		if economy {
+			tip := tx.GasPrice()
+			if header.BaseFee != nil {
+				tip = tx.EffectiveGasTipValue(header.BaseFee)
+			}
+			fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), tip)
+			a.gasBurn(fee, share)
+		}
+		if header.BaseFee != nil {
+			baseFees.Add(baseFees, new(big.Int).Mul(header.BaseFee, new(big.Int).SetUint64(receipt.GasUsed)))
+		}
+	}
+	a.flows.BaseFeeBurned.Add(a.flows.BaseFeeBurned, baseFees)
+
+	// Automatic payouts are made before the block reward and mint no tip.
+	for _, l := range a.chain.OlivetumPayoutLogs(block.Hash(), block.NumberU64()) {
+		if l.Address == core.DividendContract && len(l.Topics) > 0 && l.Topics[0] == core.DividendClaimedTopic {
+			a.dividendClaim(new(big.Int).SetBytes(l.Data), economy, false)
+		}
+	}
+
+	if fork := params.GetEconomyForkBlock(); fork.Sign() > 0 && header.Number.Cmp(fork) == 0 {
+		a.expected.Burned.Add(a.expected.Burned, params.EconomyBaselineBurnedWei())
+		a.expected.BurnedTransfers.Add(a.expected.BurnedTransfers, params.EconomyBaselineBurnedTransfersWei())
+		a.expected.BurnedGas.Add(a.expected.BurnedGas, params.EconomyBaselineBurnedGasWei())
+		a.expected.MinerBurnShare.Add(a.expected.MinerBurnShare, params.EconomyBaselineMinerBurnShareWei())
+		a.expected.DividendsMinted.Add(a.expected.DividendsMinted, params.EconomyBaselineDividendsMintedWei())
+	}
+	gross := rewardForBlock(header.Number)
+	remaining := new(big.Int).Sub(params.MaxSupply(), a.expected.Minted)
+	if remaining.Sign() <= 0 {
+		return nil
+	}
+	if gross.Cmp(remaining) > 0 {
+		gross = remaining
+	}
+	burn := core.RewardBurn(gross, a.rate)
+	a.expected.Minted.Add(a.expected.Minted, gross)
+	a.flows.Rewards.Add(a.flows.Rewards, gross)
+	a.flows.RewardBurned.Add(a.flows.RewardBurned, burn)
+	if params.RewardBurnFork.Active(header.Number) {
+		a.expected.Burned.Add(a.expected.Burned, burn)
+		a.expected.BurnedRewards.Add(a.expected.BurnedRewards, burn)
+	} else {
+		a.flows.UntrackedBurned.Add(a.flows.UntrackedBurned, burn)
+	}
+	return nil
+}
+
+// splitBurn returns the burn on amount at the current rate and the part of it
+// credited to the miner.
+func (a *supplyAuditor) splitBurn(amount *big.Int, share bool) (*big.Int, *big.Int) {
+	burn := new(big.Int).Mul(amount, new(big.Int).SetUint64(a.rate))
+	burn.Div(burn, burnDenominator)
+	minerShare := new(big.Int)
+	if share {
+		minerShare.Mul(burn, big.NewInt(core.MinerBurnShareBps))
+		minerShare.Div(minerShare, burnDenominator)
+	}
+	return burn, minerShare
+}
+
+func (a *supplyAuditor) transferBurn(amount *big.Int, economy, share bool) {
+	burn, minerShare := a.splitBurn(amount, share)
+	net := new(big.Int).Sub(burn, minerShare)
+	a.flows.TransferBurned.Add(a.flows.TransferBurned, net)
+	a.flows.MinerBurnShare.Add(a.flows.MinerBurnShare, minerShare)
+	if !economy {
+		a.flows.PreForkBurned.Add(a.flows.PreForkBurned, net)
+		a.flows.PreForkShare.Add(a.flows.PreForkShare, minerShare)
+		return
+	}
+	if net.Sign() > 0 {
+		a.expected.Burned.Add(a.expected.Burned, net)
+		a.expected.BurnedTransfers.Add(a.expected.BurnedTransfers, net)
+		a.expected.MinerBurnShare.Add(a.expected.MinerBurnShare, minerShare)
+	}
+}
+
+func (a *supplyAuditor) gasBurn(fee *big.Int, share bool) {
+	burn, minerShare := a.splitBurn(fee, share)
+	net := new(big.Int).Sub(burn, minerShare)
+	if net.Sign() <= 0 {
+		return
+	}
+	a.flows.GasBurned.Add(a.flows.GasBurned, net)
+	a.flows.MinerBurnShare.Add(a.flows.MinerBurnShare, minerShare)
+	a.expected.Burned.Add(a.expected.Burned, net)
+	a.expected.BurnedGas.Add(a.expected.BurnedGas, net)
+	a.expected.MinerBurnShare.Add(a.expected.MinerBurnShare, minerShare)
+}
+
+func (a *supplyAuditor) dividendClaim(reward *big.Int, economy, claimTip bool) {
+	a.flows.Dividends.Add(a.flows.Dividends, reward)
+	if !economy {
+		a.flows.PreForkDividend.Add(a.flows.PreForkDividend, reward)
+		return
+	}
+	a.expected.DividendsMinted.Add(a.expected.DividendsMinted, reward)
+	if !claimTip {
+		return
+	}
+
+	// Mirrors core.MintDividendClaimTip.
+	virtualBurn := new(big.Int).Mul(reward, new(big.Int).SetUint64(a.rate))
+	virtualBurn.Div(virtualBurn, burnDenominator)
+	tip := new(big.Int).Mul(virtualBurn, big.NewInt(core.MinerBurnShareBps))
+	tip.Div(tip, burnDenominator)
+	remaining := new(big.Int).Sub(params.MaxSupply(), a.expected.Minted)
+	if remaining.Sign() <= 0 {
+		return
+	}
+	if tip.Cmp(remaining) > 0 {
+		tip = remaining
+	}
+	a.expected.Minted.Add(a.expected.Minted, tip)
+	a.flows.ClaimTips.Add(a.flows.ClaimTips, tip)
+}
+
+// compare fills in the discrepancies of the audit.
+func (audit *SupplyAudit) compare(pastEconomyFork bool) {
+	check := func(item string, recorded, expected *big.Int) {
+		if recorded.Cmp(expected) != 0 {
+			audit.Discrepancies = append(audit.Discrepancies, SupplyDiscrepancy{
+				Item:     item,
+				Recorded: recorded,
+				Expected: expected,
+				Diff:     new(big.Int).Sub(recorded, expected),
+			})
+		}
+	}
+	check("totalMinted", audit.Counters.Minted, audit.Expected.Minted)
+	check("totalBurned", audit.Counters.Burned, audit.Expected.Burned)
+	check("totalBurnedTransfers", audit.Counters.BurnedTransfers, audit.Expected.BurnedTransfers)
+	check("totalBurnedGas", audit.Counters.BurnedGas, audit.Expected.BurnedGas)
+	check("totalMinerBurnShare", audit.Counters.MinerBurnShare, audit.Expected.MinerBurnShare)
+	check("totalDividendsMinted", audit.Counters.DividendsMinted, audit.Expected.DividendsMinted)
+	check("totalBurnedRewards", audit.Counters.BurnedRewards, audit.Expected.BurnedRewards)
+
+	if pastEconomyFork {
+		check("baseline burned vs pre-fork transfer burns", params.EconomyBaselineBurnedWei(), audit.Flows.PreForkBurned)
+		check("baseline miner burn share vs pre-fork share", params.EconomyBaselineMinerBurnShareWei(), audit.Flows.PreForkShare)
+		check("baseline dividends vs pre-fork dividends", params.EconomyBaselineDividendsMintedWei(), audit.Flows.PreForkDividend)
+	}
+	if audit.Flows.UntrackedBurned.Sign() > 0 {
+		check("block reward burns tracked in totalBurned", new(big.Int), audit.Flows.UntrackedBurned)
+	}
+
+	expected := new(big.Int).Set(audit.GenesisAlloc)
+	expected.Add(expected, audit.Flows.Rewards)
+	expected.Sub(expected, audit.Flows.RewardBurned)
+	expected.Add(expected, audit.Flows.ClaimTips)
+	expected.Add(expected, audit.Flows.Dividends)
+	expected.Sub(expected, audit.Flows.TransferBurned)
+	expected.Sub(expected, audit.Flows.GasBurned)
+	expected.Sub(expected, audit.Flows.BaseFeeBurned)
+	check("sum of balances", audit.Balances, expected)
+}
+
+// balanceCollector sums the balances of a state dump.
+type balanceCollector struct {
+	sum      *big.Int
+	accounts uint64
+	err      error
+}
+
+func (c *balanceCollector) OnRoot(common.Hash) {}
+
+func (c *balanceCollector) OnAccount(addr *common.Address, account state.DumpAccount) {
+	balance, ok := new(big.Int).SetString(account.Balance, 10)
+	if !ok {
+		if c.err == nil {
+			c.err = fmt.Errorf("invalid balance %q", account.Balance)
+		}
+		return
+	}
+	c.sum.Add(c.sum, balance)
+	c.accounts++
+}
+
+func isEconomyForkAt(num *big.Int) bool {
+	fork := params.GetEconomyForkBlock()
+	return fork.Sign() > 0 && num.Cmp(fork) >= 0
+}
+
+func isBurnShareAt(num *big.Int) bool {
+	fork := params.GetBurnShareForkBlock()
+	return fork.Sign() == 0 || num.Cmp(fork) >= 0
+}
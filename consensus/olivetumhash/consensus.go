@@ -24,7 +24,6 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/trie"
-	"github.com/holiman/uint256"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -37,8 +36,6 @@ const (
 var (
 	allowedFutureBlockTimeSeconds = int64(15)
 	maxUint256                    = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
-	burnContractAddress           = common.HexToAddress("0x0000000000000000000000000000000000000b00")
-	burnStorageSlot               = common.Hash{}
 	burnDenominator               = big.NewInt(10000)
 
 	errOlderBlockTime    = errors.New("timestamp older than parent")
@@ -55,8 +52,6 @@ type olivetumPeriodProvider interface {
 	OlivetumBlockPeriod(hash common.Hash, number uint64) (uint64, bool)
 }
 
-const defaultBurnRate = 50
-
 // Olivetumhash implements a memory-hard PoW engine tailored for Olivetum.
 type Olivetumhash struct {
 	config engineConfig
@@ -305,9 +300,11 @@ func (o *Olivetumhash) Prepare(chain consensus.ChainHeaderReader, header *types.
 	return nil
 }
 
-// Finalize applies block rewards.
+// Finalize applies automatic dividend payouts and block rewards.
 func (o *Olivetumhash) Finalize(chain consensus.ChainHeaderReader, header *types.Header, state *state.StateDB, txs []*types.Transaction, uncles []*types.Header, withdrawals []*types.Withdrawal) {
 	core.ApplyEconomyBaseline(state, header.Number)
+	core.EnableDividendBuckets(state, header.Number)
+	core.DistributeDividends(state, header, len(txs))
 	accumulateRewards(state, header)
 }
 
@@ -777,35 +774,7 @@ func accumulateRewards(state *state.StateDB, header *types.Header) {
 	if state == nil {
 		return
 	}
-
-	gross := rewardForBlock(header.Number)
-	if gross.Sign() == 0 {
-		return
-	}
-
-	minted := getTotalMinted(state)
-	remaining := new(big.Int).Sub(params.MaxSupply(), minted)
-	if remaining.Sign() <= 0 {
-		return
-	}
-	if gross.Cmp(remaining) > 0 {
-		gross = remaining
-	}
-	if gross.Sign() == 0 {
-		return
-	}
-
-	burn := computeRewardBurn(gross, state)
-	payout := new(big.Int).Sub(new(big.Int).Set(gross), burn)
-
-	newMinted := new(big.Int).Add(minted, gross)
-	setTotalMinted(state, newMinted)
-
-	if payout.Sign() > 0 {
-		payoutU256, _ := uint256.FromBig(payout)
-		state.AddBalance(header.Coinbase, payoutU256)
-		core.AddHolding(state, header.Coinbase, payout, header.Time)
-	}
+	core.MintBlockReward(state, header.Coinbase, rewardForBlock(header.Number), header.Number, header.Time)
 }
 
 func rewardForBlock(number *big.Int) *big.Int {
@@ -845,48 +814,3 @@ func rewardForBlock(number *big.Int) *big.Int {
 	}
 	return reward
 }
-
-func computeRewardBurn(amount *big.Int, state *state.StateDB) *big.Int {
-	if amount.Sign() == 0 {
-		return new(big.Int)
-	}
-	rate := readBurnRate(state)
-	if rate == 0 {
-		return new(big.Int)
-	}
-	rateBig := new(big.Int).SetUint64(rate)
-	burn := new(big.Int).Mul(amount, rateBig)
-	burn.Div(burn, burnDenominator)
-	if burn.Cmp(amount) > 0 {
-		return new(big.Int).Set(amount)
-	}
-	return burn
-}
-
-func readBurnRate(state *state.StateDB) uint64 {
-	if state == nil {
-		return defaultBurnRate
-	}
-	stored := state.GetState(burnContractAddress, burnStorageSlot).Big().Uint64()
-	if stored == 0 {
-		return defaultBurnRate
-	}
-	return stored
-}
-
-func getTotalMinted(state *state.StateDB) *big.Int {
-	if state == nil {
-		return new(big.Int)
-	}
-	return new(big.Int).SetBytes(state.GetState(params.RewardVault, common.Hash{0: 0x0c}).Bytes())
-}
-
-func setTotalMinted(state *state.StateDB, amount *big.Int) {
-	if state == nil {
-		return
-	}
-	if state.GetNonce(params.RewardVault) == 0 {
-		state.SetNonce(params.RewardVault, 1)
-	}
-	state.SetState(params.RewardVault, common.Hash{0: 0x0c}, common.BigToHash(amount))
-}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -453,7 +454,7 @@ func TestAccumulateRewardsMinting(t *testing.T) {
 	statedb := newStateDB(t)
 
 	const burnRate = 300 // 3%
-	statedb.SetState(burnContractAddress, burnStorageSlot, common.BigToHash(new(big.Int).SetUint64(burnRate)))
+	core.SetBurnRate(statedb, burnRate)
 
 	header := &types.Header{
 		Coinbase: common.HexToAddress("0x9539c940a4adf4b26b539a56cc4c1372671acd97"),
@@ -478,6 +479,33 @@ func TestAccumulateRewardsMinting(t *testing.T) {
 	}
 }
 
+func TestAccumulateRewardsTracksBurnAfterFork(t *testing.T) {
+	defer params.RewardBurnFork.SetBlock(params.RewardBurnFork.Block())
+	params.RewardBurnFork.SetBlock(big.NewInt(2))
+
+	statedb := newStateDB(t)
+	core.SetBurnRate(statedb, 300)
+	header := &types.Header{
+		Coinbase: common.HexToAddress("0x9539c940a4adf4b26b539a56cc4c1372671acd97"),
+		Number:   big.NewInt(1),
+	}
+
+	accumulateRewards(statedb, header)
+	if burned := core.GetTotalBurned(statedb); burned.Sign() != 0 {
+		t.Fatalf("reward burn tracked before fork: %s", burned)
+	}
+
+	header.Number = big.NewInt(2)
+	accumulateRewards(statedb, header)
+	expectedBurn := core.RewardBurn(rewardForBlock(header.Number), 300)
+	if burned := core.GetTotalBurned(statedb); burned.Cmp(expectedBurn) != 0 {
+		t.Fatalf("total burned mismatch: have %s want %s", burned, expectedBurn)
+	}
+	if burned := core.GetTotalBurnedRewards(statedb); burned.Cmp(expectedBurn) != 0 {
+		t.Fatalf("reward burns mismatch: have %s want %s", burned, expectedBurn)
+	}
+}
+
 func TestAccumulateRewardsSupplyCap(t *testing.T) {
 	statedb := newStateDB(t)
 
@@ -0,0 +1,121 @@
+package olivetumhash
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// RewardEra is a run of blocks paying the same gross block reward.
+type RewardEra struct {
+	Index  int      // halving count, -1 for the blocks before the reward fork
+	Start  uint64   // first block of the era
+	End    *uint64  // last block of the era, nil for the open-ended tail emission
+	Reward *big.Int // gross block reward
+}
+
+// RewardEras returns the reward eras of the chain in block order, as paid by
+// rewardForBlock. The last era is the open-ended tail emission at the floor.
+func RewardEras() []RewardEra {
+	var (
+		eras     []RewardEra
+		start    = params.GetRewardForkBlock().Uint64()
+		base     = params.RewardBase()
+		floor    = params.RewardFloor()
+		interval = params.RewardHalvingInterval
+	)
+	if start > 0 {
+		end := start - 1
+		eras = append(eras, RewardEra{Index: -1, Start: 0, End: &end, Reward: new(big.Int).Set(base)})
+	}
+	reward := base
+	for k := 0; ; k++ {
+		era := RewardEra{Index: k, Start: start + uint64(k)*interval, Reward: new(big.Int).Set(reward)}
+		if era.Reward.Cmp(floor) < 0 {
+			era.Reward.Set(floor)
+		}
+		if interval == 0 || era.Reward.Cmp(floor) == 0 {
+			return append(eras, era)
+		}
+		end := era.Start + interval - 1
+		era.End = &end
+		eras = append(eras, era)
+		reward = new(big.Int).Rsh(reward, 1)
+	}
+}
+
+// RewardScheduleEra is a reward era with its projected issuance.
+type RewardScheduleEra struct {
+	RewardEra
+	Burn       *big.Int // burn per block at the current burn rate
+	Net        *big.Int // reward paid to the miner per block after the burn
+	MintedEnd  *big.Int // projected total minted at the end of the era, nil if the era has ended or is open-ended and never exhausts the supply
+	Exhausted  bool     // whether MaxSupply is projected to run out by the end of the era
+	Historical bool     // whether the era ended before the head block
+}
+
+// RewardSchedule is the projected reward schedule as seen from a head block.
+type RewardSchedule struct {
+	Head       uint64
+	Minted     *big.Int // total minted at the head block
+	MaxSupply  *big.Int
+	BurnRate   uint64
+	Eras       []RewardScheduleEra
+	Exhaustion *uint64 // projected last block minting a reward, nil if never
+}
+
+// ProjectRewardSchedule projects the reward schedule from the head block, given
+// the total minted and the burn rate at the head. It returns up to count eras,
+// starting with the era of block from. Rewards count against MaxSupply gross,
+// so the burn rate changes the net issuance but not the exhaustion block;
+// dividend claim tips, which also count as minted, are not projected.
+func ProjectRewardSchedule(head uint64, minted *big.Int, burnRate uint64, from uint64, count int) *RewardSchedule {
+	schedule := &RewardSchedule{
+		Head:      head,
+		Minted:    new(big.Int).Set(minted),
+		MaxSupply: params.MaxSupply(),
+		BurnRate:  burnRate,
+	}
+	total := new(big.Int).Set(minted)
+	for _, era := range RewardEras() {
+		entry := RewardScheduleEra{
+			RewardEra:  era,
+			Burn:       core.RewardBurn(era.Reward, burnRate),
+			Historical: era.End != nil && *era.End <= head,
+		}
+		entry.Net = new(big.Int).Sub(era.Reward, entry.Burn)
+
+		// Project the blocks of the era after the head.
+		if !entry.Historical && schedule.Exhaustion == nil {
+			first := era.Start
+			if first <= head {
+				first = head + 1
+			}
+			remaining := new(big.Int).Sub(schedule.MaxSupply, total)
+			if remaining.Sign() <= 0 {
+				schedule.Exhaustion = &head
+			} else if era.Reward.Sign() > 0 {
+				// Blocks needed to mint the remaining supply, the last one capped.
+				blocks := new(big.Int).Add(remaining, new(big.Int).Sub(era.Reward, big.NewInt(1)))
+				blocks.Div(blocks, era.Reward)
+				if era.End == nil || blocks.Cmp(new(big.Int).SetUint64(*era.End-first+1)) <= 0 {
+					last := first + blocks.Uint64() - 1
+					schedule.Exhaustion = &last
+				}
+			}
+			if era.End != nil && schedule.Exhaustion == nil {
+				total.Add(total, new(big.Int).Mul(era.Reward, new(big.Int).SetUint64(*era.End-first+1)))
+				entry.MintedEnd = new(big.Int).Set(total)
+			}
+		}
+		if !entry.Historical && schedule.Exhaustion != nil {
+			entry.Exhausted = true
+			entry.MintedEnd = new(big.Int).Set(schedule.MaxSupply)
+		}
+		if (era.End == nil || *era.End >= from) && len(schedule.Eras) < count {
+			schedule.Eras = append(schedule.Eras, entry)
+		}
+	}
+	return schedule
+}
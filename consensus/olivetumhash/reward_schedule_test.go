@@ -0,0 +1,90 @@
+package olivetumhash
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestRewardErasMatchRewardForBlock(t *testing.T) {
+	old := params.GetRewardForkBlock()
+	t.Cleanup(func() { params.SetRewardForkBlock(old) })
+	params.SetRewardForkBlock(big.NewInt(1000))
+
+	eras := RewardEras()
+	if eras[0].Index != -1 || *eras[0].End != 999 {
+		t.Fatalf("unexpected pre-fork era %+v", eras[0])
+	}
+	last := eras[len(eras)-1]
+	if last.End != nil || last.Reward.Cmp(params.RewardFloor()) != 0 {
+		t.Fatalf("last era is not the tail emission: %+v", last)
+	}
+	for i, era := range eras {
+		blocks := []uint64{era.Start, era.Start + 1}
+		if era.End != nil {
+			blocks = append(blocks, *era.End)
+		}
+		for _, n := range blocks {
+			if have := rewardForBlock(new(big.Int).SetUint64(n)); have.Cmp(era.Reward) != 0 {
+				t.Fatalf("era %d block %d: rewardForBlock %v, era reward %v", i, n, have, era.Reward)
+			}
+		}
+		if i > 0 && era.Start != *eras[i-1].End+1 {
+			t.Fatalf("era %d starts at %d after %d", i, era.Start, *eras[i-1].End)
+		}
+	}
+}
+
+func TestProjectRewardSchedule(t *testing.T) {
+	old := params.GetRewardForkBlock()
+	t.Cleanup(func() { params.SetRewardForkBlock(old) })
+	params.SetRewardForkBlock(big.NewInt(0))
+
+	// Two and a half rewards short of the cap: the third block mints the rest.
+	reward := rewardForBlock(big.NewInt(11))
+	short := new(big.Int).Add(new(big.Int).Mul(reward, big.NewInt(2)), new(big.Int).Rsh(reward, 1))
+	minted := new(big.Int).Sub(params.MaxSupply(), short)
+	schedule := ProjectRewardSchedule(10, minted, 150, 0, 100)
+	if schedule.Exhaustion == nil || *schedule.Exhaustion != 13 {
+		t.Fatalf("exhaustion: have %v want 13", schedule.Exhaustion)
+	}
+	first := schedule.Eras[0]
+	if !first.Exhausted || first.MintedEnd.Cmp(params.MaxSupply()) != 0 {
+		t.Fatalf("first era not exhausted: %+v", first)
+	}
+	if want := new(big.Int).Div(new(big.Int).Mul(reward, big.NewInt(150)), big.NewInt(10000)); first.Burn.Cmp(want) != 0 {
+		t.Fatalf("burn per block: have %v want %v", first.Burn, want)
+	}
+
+	// From genesis the halvings run out into the tail emission, which
+	// exhausts the cap eventually. The genesis block mints nothing.
+	schedule = ProjectRewardSchedule(0, new(big.Int), 0, 0, 100)
+	if schedule.Exhaustion == nil {
+		t.Fatalf("tail emission never exhausts the supply")
+	}
+	total := new(big.Int)
+	for _, era := range schedule.Eras[:len(schedule.Eras)-1] {
+		if era.Exhausted {
+			t.Fatalf("era %d exhausted before the tail", era.Index)
+		}
+		first := era.Start
+		if first == 0 {
+			first = 1
+		}
+		total.Add(total, new(big.Int).Mul(era.Reward, new(big.Int).SetUint64(*era.End-first+1)))
+		if era.MintedEnd.Cmp(total) != 0 {
+			t.Fatalf("era %d minted: have %v want %v", era.Index, era.MintedEnd, total)
+		}
+	}
+	tail := schedule.Eras[len(schedule.Eras)-1]
+	blocks := *schedule.Exhaustion - tail.Start + 1
+	if minted := new(big.Int).Add(total, new(big.Int).Mul(tail.Reward, new(big.Int).SetUint64(blocks))); minted.Cmp(params.MaxSupply()) < 0 {
+		t.Fatalf("supply not exhausted at block %d: minted %v", *schedule.Exhaustion, minted)
+	}
+
+	// Eras before from are skipped.
+	if eras := ProjectRewardSchedule(0, new(big.Int), 0, params.RewardHalvingInterval, 1).Eras; len(eras) != 1 || eras[0].Index != 1 {
+		t.Fatalf("unexpected eras from the second era: %+v", eras)
+	}
+}
@@ -149,6 +149,12 @@ type CacheConfig struct {
 	StateHistory        uint64        // Number of blocks from head whose state histories are reserved.
 	StateScheme         string        // Scheme used to store ethereum states and merkle tree nodes on top
 
+	OlivetumAccountIndex bool // Whether to index per-address Olivetum economy figures
+	OlivetumHolderIndex  bool // Whether to index the Olivetum dividend holders
+	OlivetumRichIndex    bool // Whether to index the Olivetum accounts by balance
+
+	OlivetumSupplyExclude []common.Address // Accounts recorded as locked in the Olivetum economy records, the defaults if empty
+
 	SnapshotNoBuild bool // Whether the background generation is allowed
 	SnapshotWait    bool // Wait for snapshot construction on startup. TODO(karalabe): This is a dirty hack for testing, nuke it
 }
@@ -270,6 +276,10 @@ type BlockChain struct {
 
 	// finalizedHeight is the monotonic reorg floor (only used for Olivetum).
 	finalizedHeight uint64
+
+	olivetumHolderSeed *olivetumIndexSeed // Seeding of the dividend holder index, nil if disabled
+	olivetumRichSeed   *olivetumIndexSeed // Seeding of the rich list index
+	olivetumReseed     chan struct{}      // Notification channel for indexes to be seeded again
 }
 
 // NewBlockChain returns a fully initialised block chain using information
@@ -299,21 +309,22 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *genesis
 	log.Info("")
 
 	bc := &BlockChain{
-		chainConfig:   chainConfig,
-		cacheConfig:   cacheConfig,
-		db:            db,
-		triedb:        triedb,
-		triegc:        prque.New[int64, common.Hash](nil),
-		quit:          make(chan struct{}),
-		chainmu:       syncx.NewClosableMutex(),
-		bodyCache:     lru.NewCache[common.Hash, *types.Body](bodyCacheLimit),
-		bodyRLPCache:  lru.NewCache[common.Hash, rlp.RawValue](bodyCacheLimit),
-		receiptsCache: lru.NewCache[common.Hash, []*types.Receipt](receiptsCacheLimit),
-		blockCache:    lru.NewCache[common.Hash, *types.Block](blockCacheLimit),
-		txLookupCache: lru.NewCache[common.Hash, txLookup](txLookupCacheLimit),
-		futureBlocks:  lru.NewCache[common.Hash, *types.Block](maxFutureBlocks),
-		engine:        engine,
-		vmConfig:      vmConfig,
+		chainConfig:    chainConfig,
+		cacheConfig:    cacheConfig,
+		db:             db,
+		triedb:         triedb,
+		triegc:         prque.New[int64, common.Hash](nil),
+		quit:           make(chan struct{}),
+		olivetumReseed: make(chan struct{}, 1),
+		chainmu:        syncx.NewClosableMutex(),
+		bodyCache:      lru.NewCache[common.Hash, *types.Body](bodyCacheLimit),
+		bodyRLPCache:   lru.NewCache[common.Hash, rlp.RawValue](bodyCacheLimit),
+		receiptsCache:  lru.NewCache[common.Hash, []*types.Receipt](receiptsCacheLimit),
+		blockCache:     lru.NewCache[common.Hash, *types.Block](blockCacheLimit),
+		txLookupCache:  lru.NewCache[common.Hash, txLookup](txLookupCacheLimit),
+		futureBlocks:   lru.NewCache[common.Hash, *types.Block](maxFutureBlocks),
+		engine:         engine,
+		vmConfig:       vmConfig,
 	}
 	bc.flushInterval.Store(int64(cacheConfig.TrieTimeLimit))
 	bc.forker = NewForkChoice(bc, shouldPreserve)
@@ -484,6 +495,14 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, genesis *genesis
 	bc.wg.Add(1)
 	go bc.updateFutureBlocks()
 
+	if params.IsOlivetumConfig(chainConfig) {
+		// A new chain starts at the genesis without passing writeHeadBlock.
+		if rawdb.ReadOlivetumEconomyRecord(bc.db, 0) == nil {
+			bc.writeOlivetumEconomyRecord(bc.db, bc.genesisBlock)
+		}
+		bc.startOlivetumIndexSeeds()
+	}
+
 	// Rewind the chain in case of an incompatible config upgrade.
 	if compat, ok := genesisErr.(*confp.ConfigCompatError); ok {
 		log.Warn("Rewinding chain to upgrade configuration", "err", compat)
@@ -968,6 +987,19 @@ func (bc *BlockChain) writeHeadBlock(block *types.Block) {
 	rawdb.WriteHeadFastBlockHash(batch, block.Hash())
 	rawdb.WriteCanonicalHash(batch, block.Hash(), block.NumberU64())
 	rawdb.WriteTxLookupEntriesByBlock(batch, block)
+	if params.IsOlivetumConfig(bc.chainConfig) {
+		writeOlivetumMemoIndex(batch, block)
+		bc.writeOlivetumEconomyRecord(batch, block)
+		if bc.cacheConfig.OlivetumHolderIndex {
+			bc.writeOlivetumHolderIndex(batch, block)
+		}
+		if bc.cacheConfig.OlivetumRichIndex {
+			bc.writeOlivetumRichIndex(batch, block)
+		}
+		if bc.cacheConfig.OlivetumAccountIndex {
+			bc.writeOlivetumAccountIndex(batch, block)
+		}
+	}
 	rawdb.WriteHeadBlockHash(batch, block.Hash())
 
 	// Flush the whole batch into the disk, exit the node if failed
@@ -1058,6 +1090,9 @@ func (bc *BlockChain) applyOlivetumRuntimeParamsForHead() {
 	LoadOffSessionTxRate(statedb)
 	LoadOffSessionMaxPerTx(statedb)
 	LoadSessionTzOffset(statedb)
+	LoadSessionCalendar(statedb)
+	LoadAddressList(statedb)
+	LoadManagementAdmin(statedb)
 	if rate := getRoundRate(statedb); rate != 0 {
 		SetDividendRate(rate)
 	}
@@ -1072,6 +1107,24 @@ func (bc *BlockChain) OlivetumBlockPeriod(hash common.Hash, number uint64) (uint
 	return rawdb.ReadOlivetumBlockPeriod(bc.db, hash)
 }
 
+// OlivetumPayoutLogs returns the logs of the automatic dividend payouts made
+// when finalizing the given block. They are not part of any receipt, so they
+// are neither in the logs bloom of the block nor served by log filters.
+func (bc *BlockChain) OlivetumPayoutLogs(hash common.Hash, number uint64) []*types.Log {
+	logs, _ := bc.OlivetumPayouts(hash, number)
+	return logs
+}
+
+// OlivetumPayouts is like OlivetumPayoutLogs, but also reports whether the
+// payouts of the block were recorded, which is only the case for blocks
+// executed by this node after the automatic dividend fork.
+func (bc *BlockChain) OlivetumPayouts(hash common.Hash, number uint64) ([]*types.Log, bool) {
+	if !params.IsOlivetumConfig(bc.chainConfig) {
+		return nil, false
+	}
+	return rawdb.ReadOlivetumPayoutLogs(bc.db, hash, number), rawdb.HasOlivetumPayoutLogs(bc.db, hash, number)
+}
+
 // stopWithoutSaving stops the blockchain service. If any imports are currently in progress
 // it will abort them using the procInterrupt. This method stops all running
 // goroutines, but does not do all the post-stop work of persisting data.
@@ -1488,6 +1541,12 @@ func (bc *BlockChain) writeBlockWithState(block *types.Block, receipts []*types.
 	rawdb.WriteBlock(blockBatch, block)
 	rawdb.WriteReceipts(blockBatch, block.Hash(), block.NumberU64(), receipts)
 	rawdb.WritePreimages(blockBatch, state.Preimages())
+	if params.IsOlivetumConfig(bc.chainConfig) && params.DividendAutoFork.Active(block.Number()) {
+		// Payouts are made outside of transactions and have no receipt. They
+		// are kept aside, also when empty, to tell executed blocks apart.
+		logs := state.GetLogs(dividendPayoutTxHash, block.NumberU64(), block.Hash())
+		rawdb.WriteOlivetumPayoutLogs(blockBatch, block.Hash(), block.NumberU64(), logs)
+	}
 	if err := blockBatch.Write(); err != nil {
 		log.Crit("Failed to write block into disk", "err", err)
 	}
@@ -17,6 +17,7 @@
 package core
 
 import (
+	"crypto/ecdsa"
 	"fmt"
 	"math/big"
 
@@ -55,6 +56,10 @@ type BlockGen struct {
 	withdrawals []*types.Withdrawal
 
 	engine consensus.Engine
+
+	// Keys of the OlivetumTestGenesis the block is generated from
+	olivetumKeys  map[common.Address]*ecdsa.PrivateKey
+	olivetumAdmin *ecdsa.PrivateKey
 }
 
 // SetCoinbase sets the coinbase of the generated block.
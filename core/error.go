@@ -122,4 +122,9 @@ var (
 	ErrRateLimit                = errors.New("transaction rate limit exceeded")
 	ErrOverMaxOffSession        = errors.New("transaction value exceeds off-session per-tx maximum")
 	ErrOverMaxOffSessionBudget  = errors.New("transaction value exceeds off-session budget")
+	ErrAddressFrozen            = errors.New("sender address is frozen")
+	ErrRecipientFrozen          = errors.New("recipient address is frozen")
+	ErrUnderMinTxAmount         = errors.New("transaction value below minimum")
+	ErrDividendNotEligible      = errors.New("dividend claim not eligible")
+	ErrDividendRoundTooSoon     = errors.New("dividend round still cooling down")
 )
@@ -0,0 +1,183 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var ErrAccountIndexDisabled = errors.New("account economy index not enabled")
+
+// splitOlivetumBurn splits the burn of an amount at the given rate into the
+// gross burn and the share of it paid to the miner, as done by the state
+// transition.
+func splitOlivetumBurn(amount *big.Int, rate uint64, number *big.Int) (*big.Int, *big.Int) {
+	burn := new(big.Int).Mul(amount, new(big.Int).SetUint64(rate))
+	burn.Div(burn, big.NewInt(10000))
+
+	share := new(big.Int)
+	if fork := params.GetBurnShareForkBlock(); fork == nil || fork.Sign() == 0 || number.Cmp(fork) >= 0 {
+		share.Mul(burn, big.NewInt(int64(MinerBurnShareBps)))
+		share.Div(share, big.NewInt(10000))
+	}
+	return burn, share
+}
+
+// olivetumAccountFlows returns the economy figures of the accounts involved in
+// a block: the transfer burns charged to senders, the gas fees they paid, the
+// burn shares paid to the miner and the dividends paid to claimers and to the
+// holders of the automatic distribution. The burns are those of
+// OlivetumTxBurns, starting at the burn rate of the parent state.
+func olivetumAccountFlows(block *types.Block, receipts types.Receipts, payouts []*types.Log, signer types.Signer, rate uint64) map[common.Address]*rawdb.OlivetumAccountEconomy {
+	var (
+		coinbase = block.Coinbase()
+		burns    = OlivetumTxBurns(block, receipts, signer, rate)
+		accounts = make(map[common.Address]*rawdb.OlivetumAccountEconomy)
+	)
+	account := func(addr common.Address) *rawdb.OlivetumAccountEconomy {
+		entry, ok := accounts[addr]
+		if !ok {
+			entry = &rawdb.OlivetumAccountEconomy{
+				BurnedOnSend:     new(big.Int),
+				MinerBurnShare:   new(big.Int),
+				GasFees:          new(big.Int),
+				DividendsClaimed: new(big.Int),
+			}
+			accounts[addr] = entry
+		}
+		return entry
+	}
+	dividend := func(l *types.Log) {
+		if l.Address != DividendContract || len(l.Topics) < 2 || l.Topics[0] != DividendClaimedTopic {
+			return
+		}
+		claimer := account(common.BytesToAddress(l.Topics[1].Bytes()))
+		claimer.DividendsClaimed.Add(claimer.DividendsClaimed, new(big.Int).SetBytes(l.Data))
+	}
+	for i, burn := range burns {
+		var (
+			tx      = block.Transactions()[i]
+			receipt = receipts[i]
+		)
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			continue
+		}
+		if burn.TransferBurn.Sign() > 0 {
+			account(from).BurnedOnSend.Add(account(from).BurnedOnSend, burn.TransferBurn)
+		}
+		if burn.MinerBurnShare.Sign() > 0 {
+			account(coinbase).MinerBurnShare.Add(account(coinbase).MinerBurnShare, burn.MinerBurnShare)
+		}
+		for _, l := range receipt.Logs {
+			dividend(l)
+		}
+		price := tx.GasPrice()
+		if receipt.EffectiveGasPrice != nil {
+			price = receipt.EffectiveGasPrice
+		}
+		if fee := new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), price); fee.Sign() > 0 {
+			account(from).GasFees.Add(account(from).GasFees, fee)
+		}
+	}
+	for _, l := range payouts {
+		dividend(l)
+	}
+	return accounts
+}
+
+// writeOlivetumAccountIndex applies a new canonical head to the account economy
+// index, which records the figures of olivetumAccountFlows per account and
+// block. Indexed blocks that are not ancestors of the head are rolled back
+// first by removing the entries they recorded.
+func (bc *BlockChain) writeOlivetumAccountIndex(db ethdb.KeyValueWriter, block *types.Block) {
+	hash, number := block.Hash(), block.NumberU64()
+	indexed, indexedNum, ok := rawdb.ReadOlivetumAccountIndexHead(bc.db)
+	if ok && indexed == hash {
+		return
+	}
+	if ok {
+		bc.rollbackOlivetumIndex(indexed, indexedNum, block, func(hash common.Hash, number uint64) bool {
+			undo, found := rawdb.ReadOlivetumAccountUndo(bc.db, hash, number)
+			for _, addr := range undo {
+				rawdb.DeleteOlivetumAccountEconomy(db, addr, number)
+			}
+			return found
+		})
+	}
+	// The burn rate is read from the parent state, the genesis block moves no
+	// funds.
+	var (
+		rate uint64
+		err  error
+	)
+	if number > 0 {
+		parent := bc.GetHeader(block.ParentHash(), number-1)
+		if parent == nil {
+			err = consensus.ErrUnknownAncestor
+		} else if statedb, serr := bc.StateAt(parent.Root); serr != nil {
+			err = serr
+		} else {
+			rate = GetBurnRate(statedb)
+		}
+	}
+	if err != nil {
+		log.Warn("Olivetum account index skipped block", "number", number, "hash", hash, "err", err)
+	} else {
+		var (
+			receipts = rawdb.ReadReceipts(bc.db, hash, number, block.Time(), bc.chainConfig)
+			payouts  = rawdb.ReadOlivetumPayoutLogs(bc.db, hash, number)
+			signer   = types.MakeSigner(bc.chainConfig, block.Number(), block.Time())
+			accounts = olivetumAccountFlows(block, receipts, payouts, signer, rate)
+			undo     = make([]common.Address, 0, len(accounts))
+		)
+		for addr, entry := range accounts {
+			rawdb.WriteOlivetumAccountEconomy(db, addr, number, entry)
+			undo = append(undo, addr)
+		}
+		rawdb.WriteOlivetumAccountUndo(db, hash, number, undo)
+	}
+	rawdb.WriteOlivetumAccountIndexHead(db, hash, number)
+	if number > olivetumIndexUndoDepth {
+		rawdb.DeleteOlivetumAccountUndos(bc.db, number-olivetumIndexUndoDepth)
+	}
+}
+
+// OlivetumAccountEconomy sums the indexed economy figures of an account over
+// the canonical blocks from..to. It also returns the number of blocks with
+// entries for the account and the last indexed block.
+func (bc *BlockChain) OlivetumAccountEconomy(addr common.Address, from, to uint64) (*rawdb.OlivetumAccountEconomy, int, uint64, error) {
+	if !bc.cacheConfig.OlivetumAccountIndex {
+		return nil, 0, 0, ErrAccountIndexDisabled
+	}
+	total := &rawdb.OlivetumAccountEconomy{
+		BurnedOnSend:     new(big.Int),
+		MinerBurnShare:   new(big.Int),
+		GasFees:          new(big.Int),
+		DividendsClaimed: new(big.Int),
+	}
+	_, head, ok := rawdb.ReadOlivetumAccountIndexHead(bc.db)
+	if !ok {
+		return total, 0, 0, nil
+	}
+	if to > head {
+		to = head
+	}
+	var blocks int
+	rawdb.IterateOlivetumAccountEconomy(bc.db, addr, from, to, func(number uint64, entry *rawdb.OlivetumAccountEconomy) bool {
+		total.BurnedOnSend.Add(total.BurnedOnSend, entry.BurnedOnSend)
+		total.MinerBurnShare.Add(total.MinerBurnShare, entry.MinerBurnShare)
+		total.GasFees.Add(total.GasFees, entry.GasFees)
+		total.DividendsClaimed.Add(total.DividendsClaimed, entry.DividendsClaimed)
+		blocks++
+		return true
+	})
+	return total, blocks, head, nil
+}
@@ -0,0 +1,96 @@
+package core_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/olivetumhash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+func TestOlivetumAccountIndexReorg(t *testing.T) {
+	genesisTime := uint64(time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC).Unix())
+	spenderKey, _ := crypto.GenerateKey()
+	spender := crypto.PubkeyToAddress(spenderKey.PublicKey)
+	claimer := common.HexToAddress("0x0000000000000000000000000000000000000011")
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000022")
+	minerA := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	minerB := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	spenderBal := new(big.Int).Mul(big.NewInt(10000), big.NewInt(vars.Ether))
+
+	genesis := olivetumTestGenesis(genesisTime, spender, spenderBal, claimer, big.NewInt(vars.Ether), 150, 50)
+	value := new(big.Int).Mul(big.NewInt(100), big.NewInt(vars.Ether))
+	gasPrice := big.NewInt(vars.GWei)
+	_, blocksA, _ := core.GenerateChainWithGenesis(genesis, olivetumhash.NewFaker(), 3, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(minerA)
+		if i != 1 {
+			return
+		}
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(spender), recipient, value, vars.TxGas, gasPrice, nil), gen.Signer(), spenderKey)
+		if err != nil {
+			t.Fatalf("sign tx: %v", err)
+		}
+		gen.AddTx(tx)
+	})
+	_, blocksB, _ := core.GenerateChainWithGenesis(genesis, olivetumhash.NewFaker(), 4, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(minerB)
+	})
+
+	// The index is off unless enabled.
+	if _, _, _, err := olivetumNewBlockchain(t, genesis).OlivetumAccountEconomy(spender, 0, 10); !errors.Is(err, core.ErrAccountIndexDisabled) {
+		t.Fatalf("disabled index: have %v want %v", err, core.ErrAccountIndexDisabled)
+	}
+	cacheConfig := core.DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.OlivetumAccountIndex = true
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), cacheConfig, genesis, nil, olivetumhash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("new chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocksA); err != nil {
+		t.Fatalf("insert chain A: %v", err)
+	}
+	burn := new(big.Int).Div(new(big.Int).Mul(value, big.NewInt(150)), big.NewInt(10000))
+	share := new(big.Int).Div(new(big.Int).Mul(burn, big.NewInt(core.MinerBurnShareBps)), big.NewInt(10000))
+	fee := new(big.Int).Mul(gasPrice, big.NewInt(int64(vars.TxGas)))
+
+	total, blocks, head, err := chain.OlivetumAccountEconomy(spender, 0, 3)
+	if err != nil {
+		t.Fatalf("spender economy: %v", err)
+	}
+	if head != 3 || blocks != 1 {
+		t.Fatalf("spender economy: have head %d blocks %d want 3 and 1", head, blocks)
+	}
+	if total.BurnedOnSend.Cmp(burn) != 0 || total.GasFees.Cmp(fee) != 0 {
+		t.Fatalf("spender economy: have burned %v fees %v want %v and %v", total.BurnedOnSend, total.GasFees, burn, fee)
+	}
+	if total, _, _, _ := chain.OlivetumAccountEconomy(minerA, 0, 3); total.MinerBurnShare.Cmp(share) != 0 {
+		t.Fatalf("miner burn share: have %v want %v", total.MinerBurnShare, share)
+	}
+	if total, blocks, _, _ := chain.OlivetumAccountEconomy(spender, 3, 3); blocks != 0 || total.BurnedOnSend.Sign() != 0 {
+		t.Fatalf("spender economy outside range: have %d blocks, burned %v", blocks, total.BurnedOnSend)
+	}
+
+	// The heavier chain B reorgs chain A out; its transfer must be rolled back.
+	if _, err := chain.InsertChain(blocksB); err != nil {
+		t.Fatalf("insert chain B: %v", err)
+	}
+	if chain.CurrentBlock().Hash() != blocksB[3].Hash() {
+		t.Fatalf("chain B not canonical")
+	}
+	for _, addr := range []common.Address{spender, minerA} {
+		total, blocks, head, _ := chain.OlivetumAccountEconomy(addr, 0, 4)
+		if head != 4 || blocks != 0 || total.BurnedOnSend.Sign() != 0 || total.MinerBurnShare.Sign() != 0 || total.GasFees.Sign() != 0 {
+			t.Fatalf("%x economy on chain B: have head %d, %d blocks, %+v", addr, head, blocks, total)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package core
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Address list storage layout under params.AddressListContract:
+//
+//	0x01..|address      class flags of the address
+//	0x02                number of listed addresses
+//	0x03..|index        listed address
+//	0x04..|address      index of the address plus one
+var addressListCountSlot = common.Hash{0: 0x02}
+
+func addressClassesSlot(addr common.Address) common.Hash {
+	var h common.Hash
+	h[0] = 0x01
+	copy(h[12:], addr.Bytes())
+	return h
+}
+
+func addressListEntrySlot(idx uint64) common.Hash {
+	var h common.Hash
+	h[0] = 0x03
+	binary.BigEndian.PutUint64(h[24:], idx)
+	return h
+}
+
+func addressListPositionSlot(addr common.Address) common.Hash {
+	var h common.Hash
+	h[0] = 0x04
+	copy(h[12:], addr.Bytes())
+	return h
+}
+
+// GetAddressClasses returns the class flags stored for an address.
+func GetAddressClasses(s vm.StateDB, addr common.Address) uint8 {
+	return uint8(readSlotUint64(s, params.AddressListContract, addressClassesSlot(addr)))
+}
+
+// HasAddressClass reports whether the address is stored in the given class.
+func HasAddressClass(s vm.StateDB, addr common.Address, class uint8) bool {
+	return GetAddressClasses(s, addr)&class != 0
+}
+
+// ReadAddressList returns all listed addresses with their class flags without
+// updating the runtime lists.
+func ReadAddressList(s vm.StateDB) map[common.Address]uint8 {
+	out := make(map[common.Address]uint8)
+	if s.GetNonce(params.AddressListContract) == 0 {
+		return out
+	}
+	count := readSlotUint64(s, params.AddressListContract, addressListCountSlot)
+	if count > params.AddressListMax {
+		count = params.AddressListMax
+	}
+	for i := uint64(0); i < count; i++ {
+		addr := common.BytesToAddress(s.GetState(params.AddressListContract, addressListEntrySlot(i)).Bytes())
+		if flags := GetAddressClasses(s, addr); flags != 0 {
+			out[addr] = flags
+		}
+	}
+	return out
+}
+
+// LoadAddressList mirrors the address lists stored in state into the runtime
+// lists used by the transaction pool.
+func LoadAddressList(s vm.StateDB) {
+	params.SetAddressClasses(ReadAddressList(s))
+}
+
+// ListAddresses returns the addresses stored in the given class, in storage
+// order.
+func ListAddresses(s vm.StateDB, class uint8) []common.Address {
+	var out []common.Address
+	if s.GetNonce(params.AddressListContract) == 0 {
+		return out
+	}
+	count := readSlotUint64(s, params.AddressListContract, addressListCountSlot)
+	if count > params.AddressListMax {
+		count = params.AddressListMax
+	}
+	for i := uint64(0); i < count; i++ {
+		addr := common.BytesToAddress(s.GetState(params.AddressListContract, addressListEntrySlot(i)).Bytes())
+		if HasAddressClass(s, addr, class) {
+			out = append(out, addr)
+		}
+	}
+	return out
+}
+
+// ApplyAddressListUpdate applies a decoded management payload to the lists
+// stored in state. Adding an address to a class it is already in, removing it
+// from a class it is not in, exceeding params.AddressListMax or freezing the
+// administrator of a management contract are rejected.
+func ApplyAddressListUpdate(s vm.StateDB, u params.AddressListUpdate) bool {
+	prev := GetAddressClasses(s, u.Address)
+	next := prev
+	switch u.Op {
+	case params.AddressListOpAdd:
+		if prev&u.Class != 0 {
+			return false
+		}
+		if u.Class == params.AddressClassFrozen && isManagementAdmin(u.Address) {
+			return false
+		}
+		next |= u.Class
+	case params.AddressListOpRemove:
+		if prev&u.Class == 0 {
+			return false
+		}
+		next &^= u.Class
+	default:
+		return false
+	}
+	count := readSlotUint64(s, params.AddressListContract, addressListCountSlot)
+	if prev == 0 && count >= params.AddressListMax {
+		return false
+	}
+	ensureMgmtAccountExists(s, params.AddressListContract)
+	switch {
+	case prev == 0:
+		s.SetState(params.AddressListContract, addressListEntrySlot(count), common.BytesToHash(u.Address.Bytes()))
+		writeSlotUint64(s, params.AddressListContract, addressListPositionSlot(u.Address), count+1)
+		writeSlotUint64(s, params.AddressListContract, addressListCountSlot, count+1)
+	case next == 0:
+		removeAddressListEntry(s, u.Address, count)
+	}
+	writeSlotUint64(s, params.AddressListContract, addressClassesSlot(u.Address), uint64(next))
+	params.SetAddressClass(u.Address, next)
+	return true
+}
+
+// removeAddressListEntry drops the address from the index by moving the last
+// entry into its place.
+func removeAddressListEntry(s vm.StateDB, addr common.Address, count uint64) {
+	pos := readSlotUint64(s, params.AddressListContract, addressListPositionSlot(addr))
+	if pos == 0 || count == 0 {
+		return
+	}
+	last := count - 1
+	if idx := pos - 1; idx != last {
+		moved := s.GetState(params.AddressListContract, addressListEntrySlot(last))
+		s.SetState(params.AddressListContract, addressListEntrySlot(idx), moved)
+		writeSlotUint64(s, params.AddressListContract, addressListPositionSlot(common.BytesToAddress(moved.Bytes())), pos)
+	}
+	s.SetState(params.AddressListContract, addressListEntrySlot(last), common.Hash{})
+	s.SetState(params.AddressListContract, addressListPositionSlot(addr), common.Hash{})
+	writeSlotUint64(s, params.AddressListContract, addressListCountSlot, last)
+}
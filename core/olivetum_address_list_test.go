@@ -0,0 +1,222 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func addressListPayload(op byte, class uint8, addr common.Address) []byte {
+	return append([]byte{op, class}, addr.Bytes()...)
+}
+
+func sendAddressListUpdate(t *testing.T, evm *vm.EVM, gp *GasPool, statedb *state.StateDB, data []byte) bool {
+	t.Helper()
+	admin := params.AddressListAdmin
+	target := params.AddressListContract
+	msg := Message{
+		From:      admin,
+		To:        &target,
+		Value:     new(big.Int),
+		GasLimit:  30000,
+		GasPrice:  big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		GasTipCap: big.NewInt(1),
+		Nonce:     statedb.GetNonce(admin),
+		Data:      data,
+	}
+	res, err := NewStateTransition(evm, &msg, gp).TransitionDb()
+	if err != nil {
+		t.Fatalf("address list update %x failed: %v", data, err)
+	}
+	return !res.Failed()
+}
+
+func useAddressListFork(t *testing.T, block *big.Int) {
+	t.Helper()
+	old := params.AddressListFork.Block()
+	t.Cleanup(func() { params.AddressListFork.SetBlock(old) })
+	params.AddressListFork.SetBlock(block)
+}
+
+func TestAddressListUpdatesAndIndex(t *testing.T) {
+	t.Cleanup(func() { params.SetAddressClasses(nil) })
+	useAddressListFork(t, big.NewInt(1))
+
+	ts := uint64(time.Date(2024, time.March, 4, 13, 0, 0, 0, time.UTC).Unix())
+	evm, statedb, gp := newOlivetumEnv(t, ts)
+	fundAccount(statedb, params.AddressListAdmin, etherBig(1000))
+
+	a := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	b := common.HexToAddress("0x00000000000000000000000000000000000000b2")
+	c := common.HexToAddress("0x00000000000000000000000000000000000000c3")
+
+	for _, u := range [][]byte{
+		addressListPayload(params.AddressListOpAdd, params.AddressClassMinAmountExempt, a),
+		addressListPayload(params.AddressListOpAdd, params.AddressClassFrozen, b),
+		addressListPayload(params.AddressListOpAdd, params.AddressClassRateLimitExempt, c),
+		addressListPayload(params.AddressListOpAdd, params.AddressClassOffSessionExempt, a),
+	} {
+		if !sendAddressListUpdate(t, evm, gp, statedb, u) {
+			t.Fatalf("update %x reverted", u)
+		}
+	}
+	for _, u := range [][]byte{
+		addressListPayload(params.AddressListOpAdd, params.AddressClassFrozen, b),
+		addressListPayload(params.AddressListOpRemove, params.AddressClassFrozen, c),
+		addressListPayload(params.AddressListOpAdd, params.AddressClassFrozen, params.AddressListAdmin),
+		addressListPayload(params.AddressListOpAdd, 0x03, c),
+	} {
+		if sendAddressListUpdate(t, evm, gp, statedb, u) {
+			t.Fatalf("update %x should revert", u)
+		}
+	}
+	if got := GetAddressClasses(statedb, a); got != params.AddressClassMinAmountExempt|params.AddressClassOffSessionExempt {
+		t.Fatalf("classes of a: got %#x", got)
+	}
+	if !params.HasAddressClass(b, params.AddressClassFrozen) {
+		t.Fatalf("runtime list not updated")
+	}
+
+	// Removing the first entry moves the last one into its place.
+	if !sendAddressListUpdate(t, evm, gp, statedb, addressListPayload(params.AddressListOpRemove, params.AddressClassMinAmountExempt, a)) ||
+		!sendAddressListUpdate(t, evm, gp, statedb, addressListPayload(params.AddressListOpRemove, params.AddressClassOffSessionExempt, a)) {
+		t.Fatalf("remove reverted")
+	}
+	params.SetAddressClasses(nil)
+	LoadAddressList(statedb)
+	if params.GetAddressClasses(a) != 0 || !params.HasAddressClass(b, params.AddressClassFrozen) || !params.HasAddressClass(c, params.AddressClassRateLimitExempt) {
+		t.Fatalf("unexpected runtime list: %v", ReadAddressList(statedb))
+	}
+	if list := ListAddresses(statedb, params.AddressClassRateLimitExempt); len(list) != 1 || list[0] != c {
+		t.Fatalf("rate-limit list mismatch: %v", list)
+	}
+}
+
+func TestAddressListEnforcement(t *testing.T) {
+	t.Cleanup(func() { params.SetAddressClasses(nil) })
+	useAddressListFork(t, big.NewInt(1))
+
+	oldMin := params.GetMinTxAmount()
+	oldRate := params.GetTxRateLimit()
+	t.Cleanup(func() {
+		params.SetMinTxAmount(oldMin)
+		params.SetTxRateLimit(oldRate)
+	})
+	params.SetMinTxAmount(etherBig(10))
+	params.SetTxRateLimit(1)
+
+	ts := uint64(time.Date(2024, time.March, 4, 13, 0, 0, 0, time.UTC).Unix())
+	evm, statedb, gp := newOlivetumEnv(t, ts)
+	fundAccount(statedb, params.AddressListAdmin, etherBig(1000))
+
+	frozen := common.HexToAddress("0x00000000000000000000000000000000000000f1")
+	service := common.HexToAddress("0x00000000000000000000000000000000000000f2")
+	user := common.HexToAddress("0x00000000000000000000000000000000000000f3")
+	fundAccount(statedb, frozen, etherBig(1000))
+	fundAccount(statedb, service, etherBig(1000))
+	fundAccount(statedb, user, etherBig(1000))
+
+	for _, u := range [][]byte{
+		addressListPayload(params.AddressListOpAdd, params.AddressClassFrozen, frozen),
+		addressListPayload(params.AddressListOpAdd, params.AddressClassMinAmountExempt, service),
+		addressListPayload(params.AddressListOpAdd, params.AddressClassRateLimitExempt, service),
+	} {
+		if !sendAddressListUpdate(t, evm, gp, statedb, u) {
+			t.Fatalf("update %x reverted", u)
+		}
+	}
+
+	transfer := func(from, to common.Address, value *big.Int) error {
+		msg := fundedMessage(from, &to, value)
+		msg.Nonce = statedb.GetNonce(from)
+		_, err := NewStateTransition(evm, &msg, gp).TransitionDb()
+		return err
+	}
+	if err := transfer(frozen, user, etherBig(20)); !errors.Is(err, ErrAddressFrozen) {
+		t.Fatalf("expected frozen sender error, got %v", err)
+	}
+	if err := transfer(user, frozen, etherBig(20)); !errors.Is(err, ErrRecipientFrozen) {
+		t.Fatalf("expected frozen recipient error, got %v", err)
+	} else if !strings.Contains(err.Error(), frozen.Hex()) {
+		t.Fatalf("frozen recipient error does not name the recipient: %v", err)
+	}
+	// The service address may send below the minimum and beyond the rate limit.
+	for i := 0; i < 3; i++ {
+		if err := transfer(service, user, big.NewInt(1)); err != nil {
+			t.Fatalf("service transfer %d failed: %v", i, err)
+		}
+	}
+	if err := transfer(user, service, big.NewInt(1)); err != nil {
+		t.Fatalf("transfer to exempt recipient failed: %v", err)
+	}
+	if err := transfer(user, service, etherBig(20)); !errors.Is(err, ErrRateLimit) {
+		t.Fatalf("expected rate limit for regular sender, got %v", err)
+	}
+}
+
+func TestAddressListForkGated(t *testing.T) {
+	t.Cleanup(func() { params.SetAddressClasses(nil) })
+	useAddressListFork(t, big.NewInt(2))
+
+	ts := uint64(time.Date(2024, time.March, 4, 13, 0, 0, 0, time.UTC).Unix())
+	evm, statedb, gp := newOlivetumEnv(t, ts)
+	fundAccount(statedb, params.AddressListAdmin, etherBig(1000))
+
+	// Before the fork the contract is a plain account: the update is not
+	// executed and frozen classes are not enforced.
+	frozen := common.HexToAddress("0x00000000000000000000000000000000000000f1")
+	if !sendAddressListUpdate(t, evm, gp, statedb, addressListPayload(params.AddressListOpAdd, params.AddressClassFrozen, frozen)) {
+		t.Fatalf("transfer to the address list contract reverted")
+	}
+	if classes := GetAddressClasses(statedb, frozen); classes != 0 {
+		t.Fatalf("update executed before the fork: classes %#x", classes)
+	}
+	classes := func(common.Address) uint8 { return params.AddressClassFrozen }
+	list := params.AddressListContract
+	if err := NewOlivetumRules(big.NewInt(1), ts, classes).CheckParties(frozen, &list); err != nil {
+		t.Fatalf("checks before the fork: %v", err)
+	}
+	if err := NewOlivetumRules(big.NewInt(2), ts, classes).CheckParties(frozen, &list); !errors.Is(err, ErrUnauthorizedManagementTx) {
+		t.Fatalf("management check after the fork: have %v, want %v", err, ErrUnauthorizedManagementTx)
+	}
+	user := common.HexToAddress("0x00000000000000000000000000000000000000f3")
+	if err := NewOlivetumRules(big.NewInt(2), ts, classes).CheckParties(frozen, &user); !errors.Is(err, ErrAddressFrozen) {
+		t.Fatalf("frozen check after the fork: have %v, want %v", err, ErrAddressFrozen)
+	}
+}
+
+func TestAddressListRejectsFreezingAdmins(t *testing.T) {
+	t.Cleanup(func() { params.SetAddressClasses(nil) })
+
+	admins := []*common.Address{
+		&BurnAdmin, &DividendAdmin, &params.GasLimitAdmin, &params.PeriodAdmin, &params.MinTxAmountAdmin,
+		&params.TxRateLimitAdmin, &params.OffSessionAdmin, &params.SessionTzAdmin, &params.SessionCalendarAdmin, &params.AddressListAdmin,
+	}
+	for i, admin := range admins {
+		old := *admin
+		*admin = common.BigToAddress(big.NewInt(int64(0xad00 + i)))
+
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		freeze := params.AddressListUpdate{Op: params.AddressListOpAdd, Class: params.AddressClassFrozen, Address: *admin}
+		frozen := ApplyAddressListUpdate(statedb, freeze)
+		exempt := ApplyAddressListUpdate(statedb, params.AddressListUpdate{Op: params.AddressListOpAdd, Class: params.AddressClassRateLimitExempt, Address: *admin})
+		*admin = old
+
+		if frozen {
+			t.Errorf("administrator %d frozen", i)
+		}
+		if !exempt {
+			t.Errorf("administrator %d not added to an exemption list", i)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+)
+
+var (
+	// managementAdminSlot of the burn contract stores an administrator that
+	// replaces the compiled-in one of every management contract. No
+	// transaction writes it, so only a genesis allocation sets it, for
+	// development and test chains.
+	managementAdminSlot = common.Hash{0: 0x01}
+
+	defaultManagementAdmin = BurnAdmin
+)
+
+// ReadManagementAdmin returns the administrator of the management contracts,
+// the compiled-in one unless the genesis replaced it.
+func ReadManagementAdmin(s vm.StateDB) common.Address {
+	stored := s.GetState(BurnContract, managementAdminSlot)
+	if stored == (common.Hash{}) {
+		return defaultManagementAdmin
+	}
+	return common.BytesToAddress(stored.Bytes())
+}
+
+// LoadManagementAdmin makes the administrator stored in state, or the
+// compiled-in one, the administrator of every management contract. Unlike the
+// runtime parameters it always resets them, so the administrator of one chain
+// never carries over to the next chain executed by the process.
+func LoadManagementAdmin(s vm.StateDB) {
+	admin := ReadManagementAdmin(s)
+	BurnAdmin, DividendAdmin = admin, admin
+	params.GasLimitAdmin, params.PeriodAdmin, params.MinTxAmountAdmin = admin, admin, admin
+	params.TxRateLimitAdmin, params.OffSessionAdmin, params.SessionTzAdmin = admin, admin, admin
+	params.SessionCalendarAdmin, params.AddressListAdmin = admin, admin
+}
+
+// AllocManagementAdmin stores admin in the genesis allocation as the
+// administrator of every management contract. The storage of the burn
+// contract is copied, not modified in place.
+func AllocManagementAdmin(alloc genesisT.GenesisAlloc, admin common.Address) {
+	account := alloc[BurnContract]
+	if account.Balance == nil {
+		account.Balance = new(big.Int)
+	}
+	if account.Nonce == 0 {
+		account.Nonce = 1
+	}
+	storage := make(map[common.Hash]common.Hash, len(account.Storage)+1)
+	for slot, value := range account.Storage {
+		storage[slot] = value
+	}
+	storage[managementAdminSlot] = common.BytesToHash(admin.Bytes())
+	account.Storage = storage
+	alloc[BurnContract] = account
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+// SetManagementAdmin stores admin in s as the administrator of the management
+// contracts, as a genesis allocation would.
+func SetManagementAdmin(s vm.StateDB, admin common.Address) {
+	ensureBurnAccount(s)
+	s.SetState(BurnContract, managementAdminSlot, common.BytesToHash(admin.Bytes()))
+}
+
+// RestoreManagementAdmin restores the compiled-in administrator of the
+// management contracts when the test ends, undoing the one loaded from a test
+// genesis.
+func RestoreManagementAdmin(t testing.TB) {
+	t.Cleanup(func() {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		LoadManagementAdmin(statedb)
+	})
+}
+
+func TestManagementAdminFromGenesis(t *testing.T) {
+	RestoreManagementAdmin(t)
+
+	var (
+		keys   []*ecdsa.PrivateKey
+		admins []common.Address
+	)
+	for i := 0; i < 2; i++ {
+		key, _ := crypto.GenerateKey()
+		keys = append(keys, key)
+		admins = append(admins, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	load := func(g *OlivetumTestGenesis) {
+		db := rawdb.NewMemoryDatabase()
+		tdb := triedb.NewDatabase(db, triedb.HashDefaults)
+		block := MustCommitGenesis(db, tdb, g.Genesis())
+		statedb, err := state.New(block.Root(), state.NewDatabaseWithNodeDB(db, tdb), nil)
+		if err != nil {
+			t.Fatalf("genesis state: %v", err)
+		}
+		LoadOlivetumRuntime(statedb)
+	}
+	check := func(want common.Address) {
+		t.Helper()
+		for name, have := range map[string]common.Address{
+			"burn": BurnAdmin, "dividend": DividendAdmin, "gas limit": params.GasLimitAdmin,
+			"period": params.PeriodAdmin, "min tx amount": params.MinTxAmountAdmin,
+			"tx rate limit": params.TxRateLimitAdmin, "off-session": params.OffSessionAdmin,
+			"session tz": params.SessionTzAdmin, "session calendar": params.SessionCalendarAdmin,
+			"address list": params.AddressListAdmin,
+		} {
+			if have != want {
+				t.Errorf("%s admin mismatch: have %x, want %x", name, have, want)
+			}
+		}
+	}
+	// Each chain runs under its own administrator, and a chain without one
+	// falls back to the compiled-in administrator
+	load(NewOlivetumTestGenesis(0).WithAdmin(keys[0]).WithBurnRate(100))
+	check(admins[0])
+	load(NewOlivetumTestGenesis(0).WithAdmin(keys[1]))
+	check(admins[1])
+	load(NewOlivetumTestGenesis(0))
+	check(defaultManagementAdmin)
+}
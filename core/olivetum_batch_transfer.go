@@ -0,0 +1,116 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+var (
+	ErrBatchTransferRecipient = errors.New("invalid batch transfer recipient")
+	ErrBatchTransferAmount    = errors.New("batch transfer amount below minimum")
+)
+
+// ValidateBatchTransferPayload checks the shape of a batch transfer: it must
+// carry no value and no access list, and its payload must decode into entries.
+func ValidateBatchTransferPayload(value *big.Int, data []byte, accessList types.AccessList) error {
+	if len(accessList) > 0 {
+		return ErrTxAccessListNotAllowed
+	}
+	if value.Sign() != 0 {
+		return ErrTxValueNotAllowed
+	}
+	if _, ok := params.DecodeBatchTransfer(data); !ok {
+		return ErrTxDataLengthInvalid
+	}
+	return nil
+}
+
+// BatchTransferGas returns the gas charged for the entries of a batch transfer
+// on top of the intrinsic gas.
+func BatchTransferGas(entries int) uint64 {
+	return uint64(entries) * params.BatchTransferGasPerRecipient
+}
+
+// BatchTransferTotal returns the sum of the entry amounts.
+func BatchTransferTotal(entries []params.BatchTransferEntry) *big.Int {
+	total := new(big.Int)
+	for _, e := range entries {
+		total.Add(total, e.Amount)
+	}
+	return total
+}
+
+// CheckBatchTransfer applies the per-transfer rules to every entry of a batch:
+// recipients must be regular accounts other than the sender and not frozen,
+// amounts must meet the minimum unless exempt and, off-session, stay within
+// the per-tx maximum. classes resolves the address classes of an account.
+func CheckBatchTransfer(from common.Address, entries []params.BatchTransferEntry, classes func(common.Address) uint8, session bool) error {
+	return CheckBatchTransferLimits(from, entries, classes, session, params.GetMinTxAmount(), params.GetOffSessionMaxPerTx())
+}
+
+// CheckBatchTransferLimits is CheckBatchTransfer against the given minimum
+// amount and off-session per-tx maximum instead of the runtime ones.
+func CheckBatchTransferLimits(from common.Address, entries []params.BatchTransferEntry, classes func(common.Address) uint8, session bool, min, maxPerTx *big.Int) error {
+	senderClasses := classes(from)
+	for i, e := range entries {
+		if e.To == from {
+			return fmt.Errorf("%w: entry %d", ErrSelfTransfer, i)
+		}
+		if isOlivetumSystemAddress(e.To) {
+			return fmt.Errorf("%w: entry %d: %v", ErrBatchTransferRecipient, i, e.To.Hex())
+		}
+		recipientClasses := classes(e.To)
+		if recipientClasses&params.AddressClassFrozen != 0 {
+			return fmt.Errorf("%w: entry %d: %v", ErrRecipientFrozen, i, e.To.Hex())
+		}
+		if e.Amount.Sign() == 0 {
+			return fmt.Errorf("%w: entry %d: zero amount", ErrBatchTransferAmount, i)
+		}
+		if e.Amount.Cmp(min) < 0 {
+			exempt := params.IsMinTxAmountExemptSender(from) || params.IsMinTxAmountExemptRecipient(e.To) ||
+				senderClasses&params.AddressClassMinAmountExempt != 0 || recipientClasses&params.AddressClassMinAmountExempt != 0
+			if !exempt {
+				return fmt.Errorf("%w: entry %d: %v", ErrBatchTransferAmount, i, e.Amount)
+			}
+		}
+		if !session && senderClasses&params.AddressClassOffSessionExempt == 0 && e.Amount.Cmp(maxPerTx) > 0 {
+			return fmt.Errorf("%w: entry %d", ErrOverMaxOffSession, i)
+		}
+	}
+	return nil
+}
+
+// isOlivetumSystemAddress reports whether addr is one of the management or
+// special-purpose targets that cannot receive batch payouts.
+func isOlivetumSystemAddress(addr common.Address) bool {
+	if _, ok := managementAdminFor(addr); ok {
+		return true
+	}
+	switch addr {
+	case DividendContract, params.BatchTransferContract:
+		return true
+	}
+	return false
+}
+
+// executeBatchTransfer settles the entries of a validated batch transfer. Each
+// entry is burned and credited like a plain transfer of its amount.
+func (st *StateTransition) executeBatchTransfer(from common.Address, entries []params.BatchTransferEntry, blockTimestamp uint64) {
+	RemoveHolding(st.state, from, BatchTransferTotal(entries), blockTimestamp)
+	for _, e := range entries {
+		net := new(big.Int).Sub(e.Amount, st.burnTransfer(from, e.Amount, blockTimestamp))
+		if net.Sign() == 0 {
+			continue
+		}
+		netU256, _ := uint256.FromBig(net)
+		st.state.SubBalance(from, netU256)
+		st.state.AddBalance(e.To, netU256)
+		AddHolding(st.state, e.To, net, blockTimestamp)
+	}
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func batchTransferPayload(entries ...params.BatchTransferEntry) []byte {
+	var data []byte
+	for _, e := range entries {
+		data = append(data, e.To.Bytes()...)
+		data = append(data, common.BigToHash(e.Amount).Bytes()...)
+	}
+	return data
+}
+
+func TestBatchTransferExecution(t *testing.T) {
+	useSessionCalendar(t, params.DefaultSessionCalendar())
+	oldFork := params.BatchTransferFork.Block()
+	oldMin := params.GetMinTxAmount()
+	oldRate := params.GetTxRateLimit()
+	t.Cleanup(func() {
+		params.BatchTransferFork.SetBlock(oldFork)
+		params.SetMinTxAmount(oldMin)
+		params.SetTxRateLimit(oldRate)
+	})
+	params.BatchTransferFork.SetBlock(big.NewInt(1))
+	params.SetMinTxAmount(etherBig(10))
+	params.SetTxRateLimit(2)
+
+	var (
+		from   = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		alice  = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		bob    = common.HexToAddress("0x3333333333333333333333333333333333333333")
+		target = params.BatchTransferContract
+	)
+	ts := uint64(time.Date(2024, time.March, 4, 13, 0, 0, 0, time.UTC).Unix())
+	evm, statedb, gp := newOlivetumEnv(t, ts)
+	SetBurnRate(statedb, 100) // 1%
+	fundAccount(statedb, from, etherBig(1000))
+
+	send := func(data []byte, gas uint64) error {
+		msg := Message{
+			From:      from,
+			To:        &target,
+			Value:     new(big.Int),
+			GasLimit:  gas,
+			GasPrice:  big.NewInt(0),
+			GasFeeCap: big.NewInt(0),
+			GasTipCap: big.NewInt(0),
+			Nonce:     statedb.GetNonce(from),
+			Data:      data,
+		}
+		// Invalid transactions are dropped along with their state changes.
+		snap := statedb.Snapshot()
+		res, err := NewStateTransition(evm, &msg, gp).TransitionDb()
+		if err != nil {
+			statedb.RevertToSnapshot(snap)
+			return err
+		}
+		if res.Failed() {
+			return res.Err
+		}
+		return nil
+	}
+
+	// An entry below the minimum invalidates the whole batch.
+	below := batchTransferPayload(
+		params.BatchTransferEntry{To: alice, Amount: etherBig(20)},
+		params.BatchTransferEntry{To: bob, Amount: etherBig(1)},
+	)
+	if err := send(below, 100_000); !errors.Is(err, ErrBatchTransferAmount) {
+		t.Fatalf("expected below-minimum error, got %v", err)
+	}
+	if bal := statedb.GetBalance(alice); !bal.IsZero() {
+		t.Fatalf("partial batch applied: alice has %v", bal)
+	}
+	invalid := batchTransferPayload(params.BatchTransferEntry{To: params.TxRateLimitContract, Amount: etherBig(20)})
+	if err := send(invalid, 100_000); !errors.Is(err, ErrBatchTransferRecipient) {
+		t.Fatalf("expected recipient error, got %v", err)
+	}
+
+	payload := batchTransferPayload(
+		params.BatchTransferEntry{To: alice, Amount: etherBig(100)},
+		params.BatchTransferEntry{To: bob, Amount: etherBig(200)},
+	)
+	gas, _ := IntrinsicGas(payload, nil, false, true, false, false)
+	gas += BatchTransferGas(2)
+	if err := send(payload, gas-1); !errors.Is(err, ErrIntrinsicGas) {
+		t.Fatalf("expected intrinsic gas error, got %v", err)
+	}
+	if err := send(payload, gas); err != nil {
+		t.Fatalf("batch transfer failed: %v", err)
+	}
+	if got, want := statedb.GetBalance(alice).ToBig(), etherBig(99); got.Cmp(want) != 0 {
+		t.Fatalf("alice balance: got %v want %v", got, want)
+	}
+	if got, want := statedb.GetBalance(bob).ToBig(), etherBig(198); got.Cmp(want) != 0 {
+		t.Fatalf("bob balance: got %v want %v", got, want)
+	}
+	if got, want := statedb.GetBalance(from).ToBig(), etherBig(700); got.Cmp(want) != 0 {
+		t.Fatalf("sender balance: got %v want %v", got, want)
+	}
+	if usage := GetTxRateUsage(statedb, from); usage.Count != 1 {
+		t.Fatalf("batch should use one rate-limit slot, used %d", usage.Count)
+	}
+}
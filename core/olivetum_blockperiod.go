@@ -19,6 +19,15 @@ func LoadBlockPeriod(s vm.StateDB) uint64 {
 	return params.GetBlockPeriod()
 }
 
+// ReadBlockPeriod returns the stored block period without updating the runtime
+// value, falling back to the default period if none is stored.
+func ReadBlockPeriod(s vm.StateDB) uint64 {
+	if stored := s.GetState(params.PeriodContract, blockPeriodSlot).Big().Uint64(); stored != 0 {
+		return stored
+	}
+	return params.BlockPeriodDefault
+}
+
 func SetBlockPeriod(s vm.StateDB, period uint64) {
 	s.SetState(params.PeriodContract, blockPeriodSlot, common.BigToHash(new(big.Int).SetUint64(period)))
 	params.SetBlockPeriod(period)
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
 )
 
 const MinerBurnShareBps = 25
@@ -24,6 +25,16 @@ func GetBurnRate(s vm.StateDB) uint64 {
 	return stored
 }
 
+// GenesisBurnRate returns the burn rate configured by a genesis allocation.
+func GenesisBurnRate(alloc genesisT.GenesisAlloc) uint64 {
+	if account, ok := alloc[BurnContract]; ok {
+		if stored := account.Storage[burnSlot].Big().Uint64(); stored != 0 {
+			return stored
+		}
+	}
+	return burnOptions[0]
+}
+
 func SetBurnRate(s vm.StateDB, rate uint64) {
 	ensureBurnAccount(s)
 	s.SetState(BurnContract, burnSlot, common.BigToHash(new(big.Int).SetUint64(rate)))
@@ -0,0 +1,245 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+)
+
+// OlivetumManagement identifies a management contract of the Olivetum chain.
+type OlivetumManagement int
+
+const (
+	BurnRateManagement OlivetumManagement = iota
+	DividendManagement
+	GasLimitManagement
+	BlockPeriodManagement
+	MinTxAmountManagement
+	TxRateLimitManagement
+	OffSessionTxRateManagement
+	OffSessionMaxPerTxManagement
+	SessionTzManagement
+	SessionCalendarManagement
+	AddressListManagement
+)
+
+// Contract returns the address of the management contract.
+func (m OlivetumManagement) Contract() common.Address {
+	switch m {
+	case BurnRateManagement:
+		return BurnContract
+	case DividendManagement:
+		return DividendContract
+	case GasLimitManagement:
+		return params.GasLimitContract
+	case BlockPeriodManagement:
+		return params.PeriodContract
+	case MinTxAmountManagement:
+		return params.MinTxAmountContract
+	case TxRateLimitManagement:
+		return params.TxRateLimitContract
+	case OffSessionTxRateManagement:
+		return params.OffSessionTxRateContract
+	case OffSessionMaxPerTxManagement:
+		return params.OffSessionMaxPerTxContract
+	case SessionTzManagement:
+		return params.SessionTzContract
+	case SessionCalendarManagement:
+		return params.SessionCalendarContract
+	case AddressListManagement:
+		return params.AddressListContract
+	default:
+		panic(fmt.Sprintf("unknown Olivetum management contract %d", m))
+	}
+}
+
+// OlivetumTestGenesis builds the genesis of an Olivetum chain for tests and
+// generates chains on top of it, with the keys of its accounts available to
+// the Olivetum helpers of BlockGen.
+type OlivetumTestGenesis struct {
+	time  uint64
+	alloc genesisT.GenesisAlloc
+	keys  map[common.Address]*ecdsa.PrivateKey
+	admin *ecdsa.PrivateKey
+}
+
+// NewOlivetumTestGenesis creates an Olivetum genesis at the given timestamp.
+func NewOlivetumTestGenesis(time uint64) *OlivetumTestGenesis {
+	return &OlivetumTestGenesis{
+		time:  time,
+		alloc: make(genesisT.GenesisAlloc),
+		keys:  make(map[common.Address]*ecdsa.PrivateKey),
+	}
+}
+
+// Fund allocates balance to the account of key. Genesis balances count as held
+// since the start of the chain for dividends.
+func (g *OlivetumTestGenesis) Fund(key *ecdsa.PrivateKey, balance *big.Int) *OlivetumTestGenesis {
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	account := g.alloc[addr]
+	account.Balance = new(big.Int).Set(balance)
+	g.alloc[addr] = account
+	g.keys[addr] = key
+	return g
+}
+
+// WithAdmin makes the address of key the administrator of the management
+// contracts and sets it to sign management transactions.
+func (g *OlivetumTestGenesis) WithAdmin(key *ecdsa.PrivateKey) *OlivetumTestGenesis {
+	AllocManagementAdmin(g.alloc, crypto.PubkeyToAddress(key.PublicKey))
+	g.admin = key
+	g.keys[crypto.PubkeyToAddress(key.PublicKey)] = key
+	return g
+}
+
+// WithBurnRate stores the transfer burn rate in basis points.
+func (g *OlivetumTestGenesis) WithBurnRate(rate uint64) *OlivetumTestGenesis {
+	g.setState(BurnContract, burnSlot, new(big.Int).SetUint64(rate))
+	return g
+}
+
+// WithDividendRound stores a first dividend round at the given rate in basis
+// points, started at the genesis time.
+func (g *OlivetumTestGenesis) WithDividendRound(rate uint64) *OlivetumTestGenesis {
+	start := new(big.Int).SetUint64(g.time)
+	g.setState(DividendContract, lastDividendSlot, start)
+	g.setState(DividendContract, roundRateSlot, new(big.Int).SetUint64(rate))
+	g.setState(DividendContract, roundStartSlot, start)
+	g.setState(DividendContract, roundIDSlot, big.NewInt(1))
+	return g
+}
+
+func (g *OlivetumTestGenesis) setState(addr common.Address, slot common.Hash, value *big.Int) {
+	account := g.alloc[addr]
+	if account.Balance == nil {
+		account.Balance = new(big.Int)
+	}
+	if account.Storage == nil {
+		account.Storage = make(map[common.Hash]common.Hash)
+	}
+	account.Storage[slot] = common.BigToHash(value)
+	g.alloc[addr] = account
+}
+
+// Genesis returns the genesis specification of a Berlin-level chain with the
+// Olivetum chain ID.
+func (g *OlivetumTestGenesis) Genesis() *genesisT.Genesis {
+	config := &goethereum.ChainConfig{
+		ChainID:             big.NewInt(30216931),
+		HomesteadBlock:      big.NewInt(0),
+		EIP150Block:         big.NewInt(0),
+		EIP155Block:         big.NewInt(0),
+		EIP158Block:         big.NewInt(0),
+		ByzantiumBlock:      big.NewInt(0),
+		ConstantinopleBlock: big.NewInt(0),
+		PetersburgBlock:     big.NewInt(0),
+		IstanbulBlock:       big.NewInt(0),
+		BerlinBlock:         big.NewInt(0),
+	}
+	params.ApplyOlivetumDefaults(config)
+
+	alloc := make(genesisT.GenesisAlloc, len(g.alloc))
+	for addr, account := range g.alloc {
+		storage := make(map[common.Hash]common.Hash, len(account.Storage))
+		for slot, value := range account.Storage {
+			storage[slot] = value
+		}
+		account.Storage = storage
+		alloc[addr] = account
+	}
+	return &genesisT.Genesis{
+		Config:     config,
+		Timestamp:  g.time,
+		GasLimit:   15_000_000,
+		Difficulty: big.NewInt(1),
+		Alloc:      alloc,
+	}
+}
+
+// GenerateChain generates n blocks on top of the genesis like
+// GenerateChainWithGenesis. Each block is generated with the runtime
+// parameters stored in its parent state, as block processing does.
+func (g *OlivetumTestGenesis) GenerateChain(engine consensus.Engine, n int, gen func(int, *BlockGen)) (ethdb.Database, []*types.Block, []types.Receipts) {
+	return GenerateChainWithGenesis(g.Genesis(), engine, n, func(i int, b *BlockGen) {
+		LoadOlivetumRuntime(b.statedb)
+		b.olivetumKeys, b.olivetumAdmin = g.keys, g.admin
+		if gen != nil {
+			gen(i, b)
+		}
+	})
+}
+
+// SetOlivetumTime sets the timestamp of the generated block, which has to be
+// later than its parent. It has to be called before transactions are added.
+func (b *BlockGen) SetOlivetumTime(time uint64) {
+	if len(b.txs) > 0 {
+		panic("block time set after transactions")
+	}
+	if time <= b.parent.Time() {
+		panic("block time out of range")
+	}
+	b.header.Time = time
+	b.header.Difficulty = b.engine.CalcDifficulty(b.cm, b.header.Time, b.parent.Header())
+}
+
+// AdvanceToSession moves the generated block to the next minute at which the
+// trading session is open, unless it is open at the block time already.
+func (b *BlockGen) AdvanceToSession() {
+	schedule := ReadSessionSchedule(b.statedb)
+	if schedule.IsOpen(b.header.Time) {
+		return
+	}
+	// Look up to two weeks ahead to pass holidays
+	limit := b.header.Time + 14*24*60*60
+	for time := (b.header.Time/60 + 1) * 60; time < limit; time += 60 {
+		if schedule.IsOpen(time) {
+			b.SetOlivetumTime(time)
+			return
+		}
+	}
+	panic("no open session within two weeks")
+}
+
+// AddManagementTx adds a management transaction carrying payload to the given
+// contract, signed by the administrator key of the OlivetumTestGenesis.
+func (b *BlockGen) AddManagementTx(kind OlivetumManagement, payload []byte) {
+	if b.olivetumAdmin == nil {
+		panic("no Olivetum administrator key")
+	}
+	b.addOlivetumCall(b.olivetumAdmin, kind.Contract(), payload)
+}
+
+// ClaimDividend adds a dividend claim of addr, signed by the key the
+// OlivetumTestGenesis funded it with.
+func (b *BlockGen) ClaimDividend(addr common.Address) {
+	key := b.olivetumKeys[addr]
+	if key == nil {
+		panic(fmt.Sprintf("no key for %s", addr))
+	}
+	b.addOlivetumCall(key, DividendContract, nil)
+}
+
+// addOlivetumCall adds a zero-value call to a special-purpose contract.
+func (b *BlockGen) addOlivetumCall(key *ecdsa.PrivateKey, to common.Address, data []byte) {
+	gas, err := IntrinsicGas(data, nil, false, true, true, false)
+	if err != nil {
+		panic(err)
+	}
+	tx := types.MustSignNewTx(key, b.Signer(), &types.LegacyTx{
+		Nonce:    b.statedb.GetNonce(crypto.PubkeyToAddress(key.PublicKey)),
+		To:       &to,
+		Gas:      gas,
+		GasPrice: new(big.Int),
+		Data:     data,
+	})
+	b.AddTx(tx)
+}
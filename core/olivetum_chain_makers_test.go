@@ -0,0 +1,100 @@
+package core_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/olivetumhash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/olivetumtx"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+func TestOlivetumGenerateChainHelpers(t *testing.T) {
+	adminKey, _ := crypto.GenerateKey()
+	holderKey, _ := crypto.GenerateKey()
+	spenderKey, _ := crypto.GenerateKey()
+	var (
+		holder    = crypto.PubkeyToAddress(holderKey.PublicKey)
+		spender   = crypto.PubkeyToAddress(spenderKey.PublicKey)
+		recipient = common.HexToAddress("0x0000000000000000000000000000000000000022")
+		held      = new(big.Int).Mul(big.NewInt(1000), big.NewInt(vars.Ether))
+		value     = new(big.Int).Mul(big.NewInt(100), big.NewInt(vars.Ether))
+		burnRate  = uint64(150)
+	)
+	core.RestoreManagementAdmin(t)
+
+	oldMin, oldRate := params.GetMinTxAmount(), core.GetDividendRate(nil)
+	t.Cleanup(func() {
+		params.SetMinTxAmount(oldMin)
+		core.SetDividendRate(oldRate)
+	})
+
+	minAmount, err := olivetumtx.SetMinTxAmount(big.NewInt(vars.Ether))
+	if err != nil {
+		t.Fatalf("min amount call: %v", err)
+	}
+	dividendRate, err := olivetumtx.SetDividendRate(100)
+	if err != nil {
+		t.Fatalf("dividend rate call: %v", err)
+	}
+	// The genesis is on a Sunday, outside the trading session
+	sunday := uint64(time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC).Unix())
+	genesis := core.NewOlivetumTestGenesis(sunday).
+		WithAdmin(adminKey).
+		WithBurnRate(burnRate).
+		Fund(holderKey, held).
+		Fund(spenderKey, new(big.Int).Mul(big.NewInt(10000), big.NewInt(vars.Ether)))
+
+	transfer := func(b *core.BlockGen, value *big.Int) {
+		b.AddTx(types.MustSignNewTx(spenderKey, b.Signer(), &types.LegacyTx{
+			Nonce:    b.TxNonce(spender),
+			To:       &recipient,
+			Value:    value,
+			Gas:      vars.TxGas,
+			GasPrice: new(big.Int),
+		}))
+	}
+	_, blocks, _ := genesis.GenerateChain(olivetumhash.NewFaker(), 4, func(i int, b *core.BlockGen) {
+		switch i {
+		case 0:
+			b.AdvanceToSession()
+			transfer(b, value)
+		case 1:
+			b.AddManagementTx(core.MinTxAmountManagement, minAmount.Data)
+		case 2:
+			b.AddManagementTx(core.DividendManagement, dividendRate.Data)
+		case 3:
+			b.ClaimDividend(holder)
+			transfer(b, big.NewInt(vars.Ether))
+		}
+	})
+	if !core.IsSession(blocks[0].Time()) {
+		t.Fatalf("first block at %d outside the session", blocks[0].Time())
+	}
+	chain := olivetumNewBlockchain(t, genesis.Genesis())
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("insert chain: %v", err)
+	}
+	statedb, err := chain.State()
+	if err != nil {
+		t.Fatalf("chain state: %v", err)
+	}
+	if have := params.GetMinTxAmount(); have.Cmp(big.NewInt(vars.Ether)) != 0 {
+		t.Errorf("min tx amount: have %v want %v", have, vars.Ether)
+	}
+	reward := new(big.Int).Div(new(big.Int).Mul(held, big.NewInt(100)), big.NewInt(10000))
+	if have, want := statedb.GetBalance(holder).ToBig(), new(big.Int).Add(held, reward); have.Cmp(want) != 0 {
+		t.Errorf("holder balance: have %v want %v", have, want)
+	}
+	received := new(big.Int).Add(value, big.NewInt(vars.Ether))
+	received.Sub(received, new(big.Int).Div(new(big.Int).Mul(received, new(big.Int).SetUint64(burnRate)), big.NewInt(10000)))
+	if have := statedb.GetBalance(recipient).ToBig(); have.Cmp(received) != 0 {
+		t.Errorf("recipient balance: have %v want %v", have, received)
+	}
+}
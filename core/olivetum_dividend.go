@@ -254,22 +254,25 @@ func setRoundID(s vm.StateDB, v uint64) {
 }
 
 // DividendStatus represents the current dividend round parameters along with
-// whether a given address has already claimed the payout.
+// whether a given address has already claimed the payout and whether it is
+// paid out automatically or has to claim.
 type DividendStatus struct {
-	Rate    uint64
-	Start   uint64
-	Qualify uint64
-	Window  uint64
-	Claimed bool
+	Rate       uint64
+	Start      uint64
+	Qualify    uint64
+	Window     uint64
+	Claimed    bool
+	AutoPayout bool
 }
 
 func GetDividendStatus(s vm.StateDB, addr common.Address) DividendStatus {
 	return DividendStatus{
-		Rate:    getRoundRate(s),
-		Start:   getRoundStart(s),
-		Qualify: dividendQualify,
-		Window:  claimWindow,
-		Claimed: getClaimedRound(s, addr) == getRoundID(s),
+		Rate:       getRoundRate(s),
+		Start:      getRoundStart(s),
+		Qualify:    dividendQualify,
+		Window:     claimWindow,
+		Claimed:    getClaimedRound(s, addr) == getRoundID(s),
+		AutoPayout: IsDividendHolderIndexed(s, addr),
 	}
 }
 
@@ -317,7 +320,14 @@ func AddHolding(s vm.StateDB, addr common.Address, amt *big.Int, now uint64) {
 		return
 	}
 	ensureDividendAccount(s)
+	registerDividendHolder(s, addr)
 	matureRecent(s, addr, now)
+	if isDividendBucketsEnabled(s) {
+		compactRecent(s, addr)
+		if mergeRecent(s, addr, amt, now) {
+			return
+		}
+	}
 	tail := getRecentTail(s, addr)
 	setRecentEntry(s, addr, tail, amt, now)
 	setRecentTail(s, addr, tail+1)
@@ -328,7 +338,11 @@ func RemoveHolding(s vm.StateDB, addr common.Address, amt *big.Int, now uint64)
 		return
 	}
 	ensureDividendAccount(s)
+	registerDividendHolder(s, addr)
 	matureRecent(s, addr, now)
+	if isDividendBucketsEnabled(s) {
+		compactRecent(s, addr)
+	}
 	remaining := new(big.Int).Set(amt)
 	for {
 		head := getRecentHead(s, addr)
@@ -416,6 +430,18 @@ func ClaimDividend(s vm.StateDB, addr common.Address, now uint64) (*big.Int, boo
 	if now-getHoldingTime(s, addr) < dividendQualify {
 		return nil, false
 	}
+	reward, ok := payDividend(s, addr, rate)
+	if ok {
+		// Holders untouched since the automatic distribution was enabled are
+		// indexed by their claim and paid automatically from then on.
+		registerDividendHolder(s, addr)
+	}
+	return reward, ok
+}
+
+// payDividend credits the reward of the current round on the held amount of a
+// qualified holder, unless it has already been paid.
+func payDividend(s vm.StateDB, addr common.Address, rate uint64) (*big.Int, bool) {
 	roundID := getRoundID(s)
 	if getClaimedRound(s, addr) == roundID {
 		return nil, false
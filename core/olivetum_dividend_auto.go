@@ -0,0 +1,161 @@
+package core
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// The automatic distribution keeps an index of dividend holders in the
+// DividendContract storage. Every account whose holdings change or that claims
+// a dividend once the mode is enabled is appended to it. The state cannot be
+// enumerated by consensus, so accounts untouched since the fork are not
+// indexed: they have to claim their dividend as before, which indexes them for
+// the following rounds.
+var (
+	dividendAutoEnabledSlot = common.Hash{0: 0x0e}
+	dividendHolderCountSlot = common.Hash{0: 0x0f}
+	dividendAutoRoundSlot   = common.Hash{0: 0x10}
+	dividendAutoCursorSlot  = common.Hash{0: 0x11}
+
+	// dividendPayoutTxHash is the transaction hash under which the state
+	// collects the logs of automatic payouts, as they belong to no
+	// transaction. The logs are not part of any receipt; the chain keeps them
+	// aside (BlockChain.OlivetumPayouts).
+	dividendPayoutTxHash = common.Hash{}
+)
+
+func dividendHolderIndexSlot(idx uint64) common.Hash {
+	var b [32]byte
+	b[0] = 0x12
+	binary.BigEndian.PutUint64(b[24:], idx)
+	return common.BytesToHash(b[:])
+}
+
+func dividendHolderPositionSlot(addr common.Address) common.Hash {
+	var b [32]byte
+	b[0] = 0x13
+	copy(b[12:], addr.Bytes())
+	return common.BytesToHash(b[:])
+}
+
+func getDividendUint(s vm.StateDB, slot common.Hash) uint64 {
+	b := s.GetState(DividendContract, slot)
+	return binary.BigEndian.Uint64(b[24:])
+}
+
+func setDividendUint(s vm.StateDB, slot common.Hash, v uint64) {
+	var b [32]byte
+	binary.BigEndian.PutUint64(b[24:], v)
+	s.SetState(DividendContract, slot, common.BytesToHash(b[:]))
+}
+
+func isDividendAutoEnabled(s vm.StateDB) bool {
+	return getDividendUint(s, dividendAutoEnabledSlot) != 0
+}
+
+// DividendHolderCount returns the number of indexed dividend holders.
+func DividendHolderCount(s vm.StateDB) uint64 {
+	return getDividendUint(s, dividendHolderCountSlot)
+}
+
+// DividendHolderAt returns the indexed dividend holder at position idx.
+func DividendHolderAt(s vm.StateDB, idx uint64) common.Address {
+	return common.BytesToAddress(s.GetState(DividendContract, dividendHolderIndexSlot(idx)).Bytes())
+}
+
+// IsDividendHolderIndexed reports whether the automatic distribution pays out
+// the dividends of addr, which otherwise has to claim them.
+func IsDividendHolderIndexed(s vm.StateDB, addr common.Address) bool {
+	return isDividendAutoEnabled(s) && getDividendUint(s, dividendHolderPositionSlot(addr)) != 0
+}
+
+// registerDividendHolder appends addr to the holder index if the automatic
+// distribution is enabled and the account is not indexed yet.
+func registerDividendHolder(s vm.StateDB, addr common.Address) {
+	if !isDividendAutoEnabled(s) || getDividendUint(s, dividendHolderPositionSlot(addr)) != 0 {
+		return
+	}
+	count := DividendHolderCount(s)
+	s.SetState(DividendContract, dividendHolderIndexSlot(count), common.BytesToHash(addr.Bytes()))
+	setDividendUint(s, dividendHolderPositionSlot(addr), count+1)
+	setDividendUint(s, dividendHolderCountSlot, count+1)
+}
+
+// unregisterDividendHolder removes the holder at idx by moving the last entry
+// into its place.
+func unregisterDividendHolder(s vm.StateDB, idx uint64) {
+	count := DividendHolderCount(s)
+	addr := DividendHolderAt(s, idx)
+	last := count - 1
+	if idx != last {
+		moved := DividendHolderAt(s, last)
+		s.SetState(DividendContract, dividendHolderIndexSlot(idx), common.BytesToHash(moved.Bytes()))
+		setDividendUint(s, dividendHolderPositionSlot(moved), idx+1)
+	}
+	s.SetState(DividendContract, dividendHolderIndexSlot(last), common.Hash{})
+	setDividendUint(s, dividendHolderPositionSlot(addr), 0)
+	setDividendUint(s, dividendHolderCountSlot, last)
+}
+
+// DistributeDividends pays out the current dividend round to the next batch of
+// indexed holders. It is called when finalizing every block; the mode is
+// switched on at the automatic dividend fork. Holders qualify as a claim in the
+// same block would, but regardless of the claim window. The payouts emit the
+// same DividendClaimedTopic logs, which are not part of any receipt. Holders
+// left without any holding are dropped from the index on the way.
+func DistributeDividends(statedb *state.StateDB, header *types.Header, txs int) {
+	if statedb == nil {
+		return
+	}
+	if params.DividendAutoFork.ActivatesAt(header.Number) {
+		ensureDividendAccount(statedb)
+		setDividendUint(statedb, dividendAutoEnabledSlot, 1)
+	}
+	if !isDividendAutoEnabled(statedb) {
+		return
+	}
+	roundID, rate := getRoundID(statedb), getRoundRate(statedb)
+	if roundID == 0 || rate == 0 {
+		return
+	}
+	if getDividendUint(statedb, dividendAutoRoundSlot) != roundID {
+		setDividendUint(statedb, dividendAutoRoundSlot, roundID)
+		setDividendUint(statedb, dividendAutoCursorSlot, 0)
+	}
+	var (
+		now    = header.Time
+		cursor = getDividendUint(statedb, dividendAutoCursorSlot)
+		count  = DividendHolderCount(statedb)
+		paid   = new(big.Int)
+	)
+	if cursor >= count {
+		return
+	}
+	statedb.SetTxContext(dividendPayoutTxHash, txs)
+	for n := 0; n < params.DividendAutoBatchSize && cursor < count; n++ {
+		addr := DividendHolderAt(statedb, cursor)
+		bootstrapHolding(statedb, addr)
+		matureRecent(statedb, addr, now)
+		if getHeldAmount(statedb, addr).Sign() == 0 && getRecentHead(statedb, addr) == getRecentTail(statedb, addr) {
+			unregisterDividendHolder(statedb, cursor)
+			count--
+			continue
+		}
+		if now-getHoldingTime(statedb, addr) >= dividendQualify {
+			if reward, ok := payDividend(statedb, addr, rate); ok {
+				paid.Add(paid, reward)
+			}
+		}
+		cursor++
+	}
+	setDividendUint(statedb, dividendAutoCursorSlot, cursor)
+	if isEconomyForkActive(header.Number) {
+		AddTotalDividendsMinted(statedb, paid)
+	}
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+)
+
+// dividendTestHolder derives distinct holder addresses. The recent-entry slots
+// only keep the leading 12 bytes of the address, so sequential addresses would
+// share them.
+func dividendTestHolder(i int) common.Address {
+	return common.BytesToAddress(crypto.Keccak256([]byte(strconv.Itoa(i))))
+}
+
+func TestDistributeDividendsInBatches(t *testing.T) {
+	oldFork := params.DividendAutoFork.Block()
+	oldEconomy := params.GetEconomyForkBlock()
+	t.Cleanup(func() {
+		params.DividendAutoFork.SetBlock(oldFork)
+		params.SetEconomyForkBlock(oldEconomy)
+	})
+	params.DividendAutoFork.SetBlock(big.NewInt(5))
+	params.SetEconomyForkBlock(big.NewInt(1))
+
+	var (
+		statedb = newDividendState(t)
+		day     = uint64(24 * 60 * 60)
+		now     = 100 * day
+		header  = func(n int64) *types.Header {
+			return &types.Header{Number: big.NewInt(n), Time: now + uint64(n)}
+		}
+	)
+	// Holdings before the fork are not indexed.
+	early := dividendTestHolder(-1)
+	statedb.AddBalance(early, uint256.NewInt(1000))
+	AddHolding(statedb, early, big.NewInt(1000), now-40*day)
+
+	DistributeDividends(statedb, header(5), 0)
+	if !isDividendAutoEnabled(statedb) {
+		t.Fatalf("automatic distribution not enabled at the fork")
+	}
+	holders := params.DividendAutoBatchSize + 50
+	for i := 0; i < holders; i++ {
+		addr := dividendTestHolder(i)
+		statedb.AddBalance(addr, uint256.NewInt(10000))
+		AddHolding(statedb, addr, big.NewInt(10000), now-40*day)
+	}
+	// A holder that has emptied its account is pruned.
+	gone := dividendTestHolder(-2)
+	statedb.AddBalance(gone, uint256.NewInt(500))
+	AddHolding(statedb, gone, big.NewInt(500), now-40*day)
+	statedb.SubBalance(gone, uint256.NewInt(500))
+	RemoveHolding(statedb, gone, big.NewInt(500), now-39*day)
+	// A young holder is indexed but not paid.
+	young := dividendTestHolder(-3)
+	statedb.AddBalance(young, uint256.NewInt(10000))
+	AddHolding(statedb, young, big.NewInt(10000), now)
+	if have, want := DividendHolderCount(statedb), uint64(holders+2); have != want {
+		t.Fatalf("indexed holders: have %d want %d", have, want)
+	}
+
+	setRoundRate(statedb, 100)
+	setRoundStart(statedb, now)
+	setRoundID(statedb, 1)
+
+	DistributeDividends(statedb, header(6), 3)
+	logs := statedb.GetLogs(dividendPayoutTxHash, 6, common.Hash{})
+	if len(logs) != params.DividendAutoBatchSize {
+		t.Fatalf("first batch: have %d payouts want %d", len(logs), params.DividendAutoBatchSize)
+	}
+	DistributeDividends(statedb, header(7), 0)
+	DistributeDividends(statedb, header(8), 0)
+	if logs := statedb.GetLogs(dividendPayoutTxHash, 8, common.Hash{}); len(logs) != holders {
+		t.Fatalf("total payouts: have %d want %d", len(logs), holders)
+	}
+	if have, want := GetTotalDividendsMinted(statedb), big.NewInt(int64(holders*100)); have.Cmp(want) != 0 {
+		t.Fatalf("dividends minted: have %v want %v", have, want)
+	}
+	if have, want := DividendHolderCount(statedb), uint64(holders+1); have != want {
+		t.Fatalf("holders after pruning: have %d want %d", have, want)
+	}
+	if bal := statedb.GetBalance(early); bal.Uint64() != 1000 {
+		t.Fatalf("unindexed holder was paid: balance %v", bal)
+	}
+	if bal := statedb.GetBalance(young); bal.Uint64() != 10000 {
+		t.Fatalf("young holder was paid: balance %v", bal)
+	}
+	paid := dividendTestHolder(0)
+	if _, ok := ClaimDividend(statedb, paid, now+10); ok {
+		t.Fatalf("holder claimed a dividend already paid out")
+	}
+	// The unindexed holder claims and is paid automatically afterwards.
+	if IsDividendHolderIndexed(statedb, early) || !GetDividendStatus(statedb, paid).AutoPayout {
+		t.Fatalf("unexpected auto payout status")
+	}
+	if _, ok := ClaimDividend(statedb, early, now+10); !ok {
+		t.Fatalf("unindexed holder could not claim")
+	}
+	if !GetDividendStatus(statedb, early).AutoPayout {
+		t.Fatalf("claimer not indexed")
+	}
+}
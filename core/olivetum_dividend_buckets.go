@@ -0,0 +1,99 @@
+package core
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Once the bucket mode is enabled, a credit landing in the same day as the
+// newest recent entry of an account is merged into it instead of taking two
+// new slots. The merged entry carries the time of the latest credit, so no
+// amount qualifies earlier than it would have under the old rules; the earlier
+// credits of the day qualify later instead, by less than a day.
+var (
+	dividendBucketsEnabledSlot        = common.Hash{0: 0x14}
+	dividendBucketSize         uint64 = 24 * 60 * 60
+)
+
+// dividendMaxBuckets is the longest recent queue the bucket mode can produce
+// after maturing: one entry per day of the qualification window.
+func dividendMaxBuckets() uint64 {
+	return dividendQualify/dividendBucketSize + 1
+}
+
+func isDividendBucketsEnabled(s vm.StateDB) bool {
+	return getDividendUint(s, dividendBucketsEnabledSlot) != 0
+}
+
+// EnableDividendBuckets switches the recent holding queue to day buckets at the
+// dividend bucket fork. It is called when finalizing every block.
+func EnableDividendBuckets(s vm.StateDB, number *big.Int) {
+	if s == nil {
+		return
+	}
+	if params.DividendBucketFork.ActivatesAt(number) {
+		ensureDividendAccount(s)
+		setDividendUint(s, dividendBucketsEnabledSlot, 1)
+	}
+}
+
+// mergeRecent adds amt to the newest recent entry of addr if it lies in the same
+// day bucket as now, reporting whether it did. The entry takes the time now, so
+// the amount it already held matures with the new credit: a credit made early
+// in a day is held back until the qualification period of the last credit of
+// that day has passed.
+func mergeRecent(s vm.StateDB, addr common.Address, amt *big.Int, now uint64) bool {
+	head, tail := getRecentHead(s, addr), getRecentTail(s, addr)
+	if head == tail {
+		return false
+	}
+	last, t := getRecentEntry(s, addr, tail-1)
+	if last.Sign() == 0 || t > now || t/dividendBucketSize != now/dividendBucketSize {
+		return false
+	}
+	setRecentEntry(s, addr, tail-1, last.Add(last, amt), now)
+	return true
+}
+
+// compactRecent migrates a recent queue built before the bucket mode: when it
+// holds more entries than the bucket mode allows, entries of the same day are
+// merged in place and the freed slots cleared. It expects matured entries to
+// have been moved out already.
+func compactRecent(s vm.StateDB, addr common.Address) {
+	head, tail := getRecentHead(s, addr), getRecentTail(s, addr)
+	if tail-head <= dividendMaxBuckets() {
+		return
+	}
+	var (
+		out    = head
+		amount *big.Int
+		last   uint64
+	)
+	for idx := head; idx < tail; idx++ {
+		amt, t := getRecentEntry(s, addr, idx)
+		if amt.Sign() == 0 {
+			continue
+		}
+		if amount != nil && t/dividendBucketSize == last/dividendBucketSize {
+			amount.Add(amount, amt)
+			last = t
+			continue
+		}
+		if amount != nil {
+			setRecentEntry(s, addr, out, amount, last)
+			out++
+		}
+		amount, last = amt, t
+	}
+	if amount != nil {
+		setRecentEntry(s, addr, out, amount, last)
+		out++
+	}
+	for idx := out; idx < tail; idx++ {
+		clearRecentEntry(s, addr, idx)
+	}
+	setRecentTail(s, addr, out)
+}
@@ -0,0 +1,59 @@
+package core_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/olivetumhash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+// benchmarkBusyCoinbase imports a chain whose blocks all credit their reward to
+// the same coinbase, with the dividend bucket fork at the first block or not
+// scheduled. The legacy queue of the coinbase grows by an entry per block.
+func benchmarkBusyCoinbase(b *testing.B, buckets bool) {
+	const blocks = 2000
+
+	oldFork := params.DividendBucketFork.Block()
+	b.Cleanup(func() { params.DividendBucketFork.SetBlock(oldFork) })
+	if buckets {
+		params.DividendBucketFork.SetBlock(big.NewInt(1))
+	} else {
+		params.DividendBucketFork.SetBlock(nil)
+	}
+	var (
+		genesisTime = uint64(time.Date(2024, time.March, 4, 10, 0, 0, 0, time.UTC).Unix())
+		spender     = common.HexToAddress("0x0000000000000000000000000000000000000010")
+		claimer     = common.HexToAddress("0x0000000000000000000000000000000000000011")
+		coinbase    = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	)
+	genesis := olivetumTestGenesis(genesisTime, spender, big.NewInt(vars.Ether), claimer, big.NewInt(vars.Ether), 150, 50)
+	_, chain, _ := core.GenerateChainWithGenesis(genesis, olivetumhash.NewFaker(), blocks, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(coinbase)
+	})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		b.StopTimer()
+		bc, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), core.DefaultCacheConfigWithScheme(rawdb.HashScheme), genesis, nil, olivetumhash.NewFaker(), vm.Config{}, nil, nil)
+		if err != nil {
+			b.Fatalf("new chain: %v", err)
+		}
+		b.StartTimer()
+		if _, err := bc.InsertChain(chain); err != nil {
+			b.Fatalf("insert chain: %v", err)
+		}
+		b.StopTimer()
+		bc.Stop()
+		b.StartTimer()
+	}
+}
+
+func BenchmarkDividendRecentLegacy(b *testing.B)  { benchmarkBusyCoinbase(b, false) }
+func BenchmarkDividendRecentBuckets(b *testing.B) { benchmarkBusyCoinbase(b, true) }
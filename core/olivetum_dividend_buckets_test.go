@@ -0,0 +1,117 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+)
+
+func enableDividendBuckets(s *state.StateDB) {
+	ensureDividendAccount(s)
+	setDividendUint(s, dividendBucketsEnabledSlot, 1)
+}
+
+func TestDividendBucketsMergeSameDay(t *testing.T) {
+	var (
+		statedb = newDividendState(t)
+		addr    = common.HexToAddress("0x1")
+		day     = uint64(24 * 60 * 60)
+		start   = 100 * day
+	)
+	enableDividendBuckets(statedb)
+	AddHolding(statedb, addr, big.NewInt(10), start)
+	AddHolding(statedb, addr, big.NewInt(20), start+60)
+	AddHolding(statedb, addr, big.NewInt(30), start+day)
+	if n := getRecentTail(statedb, addr) - getRecentHead(statedb, addr); n != 2 {
+		t.Fatalf("recent entries: have %d want 2", n)
+	}
+	if amt, at := getRecentEntry(statedb, addr, 0); amt.Int64() != 30 || at != start+60 {
+		t.Fatalf("merged entry: have %v at %d, want 30 at %d", amt, at, start+60)
+	}
+	// The merged entry matures with its latest credit.
+	view := GetDividendView(statedb, addr, start+dividendQualify)
+	if view.EligibleNow.Sign() != 0 || view.Pending.Int64() != 60 {
+		t.Fatalf("early view: eligible %v pending %v", view.EligibleNow, view.Pending)
+	}
+	view = GetDividendView(statedb, addr, start+60+dividendQualify)
+	if view.EligibleNow.Int64() != 30 || view.Pending.Int64() != 30 {
+		t.Fatalf("view: eligible %v pending %v", view.EligibleNow, view.Pending)
+	}
+	RemoveHolding(statedb, addr, big.NewInt(40), start+day+1)
+	if view := GetDividendView(statedb, addr, start+day+1); view.Pending.Int64() != 20 {
+		t.Fatalf("pending after removal: have %v want 20", view.Pending)
+	}
+}
+
+func TestDividendBucketsDelayEarlierCreditsOfTheDay(t *testing.T) {
+	var (
+		day   = uint64(24 * 60 * 60)
+		start = 100 * day
+		first = start + 1
+		last  = start + day - 1
+		addr  = common.HexToAddress("0x1")
+	)
+	credit := func(buckets bool) *state.StateDB {
+		statedb := newDividendState(t)
+		if buckets {
+			enableDividendBuckets(statedb)
+		}
+		AddHolding(statedb, addr, big.NewInt(10), first)
+		AddHolding(statedb, addr, big.NewInt(20), last)
+		return statedb
+	}
+	// Without buckets the first credit matures on its own.
+	legacy := credit(false)
+	if view := GetDividendView(legacy, addr, first+dividendQualify); view.EligibleNow.Int64() != 10 || view.Pending.Int64() != 20 {
+		t.Fatalf("legacy view: eligible %v pending %v, want 10 and 20", view.EligibleNow, view.Pending)
+	}
+	// With buckets it waits for the last credit of the day, almost a day
+	// later, and both mature together.
+	buckets := credit(true)
+	if view := GetDividendView(buckets, addr, last+dividendQualify-1); view.EligibleNow.Sign() != 0 || view.Pending.Int64() != 30 {
+		t.Fatalf("bucket view before maturity: eligible %v pending %v, want 0 and 30", view.EligibleNow, view.Pending)
+	}
+	if view := GetDividendView(buckets, addr, last+dividendQualify); view.EligibleNow.Int64() != 30 || view.Pending.Sign() != 0 {
+		t.Fatalf("bucket view at maturity: eligible %v pending %v, want 30 and 0", view.EligibleNow, view.Pending)
+	}
+}
+
+func TestDividendBucketsMigrateQueue(t *testing.T) {
+	var (
+		statedb = newDividendState(t)
+		addr    = common.HexToAddress("0x1")
+		day     = uint64(24 * 60 * 60)
+		start   = 100 * day
+		credits = 100
+	)
+	for i := 0; i < credits; i++ {
+		AddHolding(statedb, addr, big.NewInt(1), start+uint64(i)*day/4)
+	}
+	now := start + uint64(credits)*day/4
+	before := GetDividendView(statedb, addr, now)
+	if n := getRecentTail(statedb, addr) - getRecentHead(statedb, addr); n != uint64(credits) {
+		t.Fatalf("legacy entries: have %d want %d", n, credits)
+	}
+	enableDividendBuckets(statedb)
+	AddHolding(statedb, addr, big.NewInt(1), now)
+
+	head, tail := getRecentHead(statedb, addr), getRecentTail(statedb, addr)
+	if tail-head > dividendMaxBuckets() {
+		t.Fatalf("queue not compacted: %d entries", tail-head)
+	}
+	for idx := tail; idx < head+uint64(credits); idx++ {
+		if amt, _ := getRecentEntry(statedb, addr, idx); amt.Sign() != 0 {
+			t.Fatalf("stale entry left at %d", idx)
+		}
+	}
+	after := GetDividendView(statedb, addr, now)
+	total := new(big.Int).Add(after.EligibleNow, after.Pending)
+	if want := new(big.Int).Add(new(big.Int).Add(before.EligibleNow, before.Pending), big.NewInt(1)); total.Cmp(want) != 0 {
+		t.Fatalf("holdings changed by migration: have %v want %v", total, want)
+	}
+	if after.EligibleNow.Cmp(before.EligibleNow) > 0 {
+		t.Fatalf("migration matured holdings early: have %v want at most %v", after.EligibleNow, before.EligibleNow)
+	}
+}
@@ -0,0 +1,202 @@
+package core
+
+import (
+	"encoding/csv"
+	"io"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+)
+
+// DividendRound describes the dividend round recorded in state.
+type DividendRound struct {
+	ID      uint64 `json:"id"`
+	Rate    uint64 `json:"rate"`
+	Start   uint64 `json:"start"`
+	Qualify uint64 `json:"qualify"`
+	Window  uint64 `json:"window"`
+	Open    bool   `json:"open"`
+}
+
+// GetDividendRound returns the current round and whether it can be claimed at
+// the given timestamp.
+func GetDividendRound(s vm.StateDB, now uint64) DividendRound {
+	round := DividendRound{
+		ID:      getRoundID(s),
+		Rate:    getRoundRate(s),
+		Start:   getRoundStart(s),
+		Qualify: dividendQualify,
+		Window:  claimWindow,
+	}
+	round.Open = round.Rate != 0 && now >= round.Start && now-round.Start <= claimWindow
+	return round
+}
+
+// DividendRecentEntry is a not yet matured amount received by a holder.
+type DividendRecentEntry struct {
+	Amount *big.Int `json:"amount"`
+	Time   uint64   `json:"time"`
+}
+
+// DividendHolder is the dividend position of an account as a claim at the
+// snapshot time would see it: the recent entries old enough to qualify are
+// matured into the held amount, and accounts never touched by the dividend
+// logic are bootstrapped from their balance.
+type DividendHolder struct {
+	Address      common.Address        `json:"address"`
+	Balance      *big.Int              `json:"balance"`
+	HoldingSince uint64                `json:"holdingSince"`
+	Held         *big.Int              `json:"held"`
+	Pending      *big.Int              `json:"pending"`
+	Recent       []DividendRecentEntry `json:"recent"`
+	Eligible     bool                  `json:"eligible"`
+	Reward       *big.Int              `json:"reward"`
+	Claimed      bool                  `json:"claimed"`
+}
+
+// GetDividendHolder computes the dividend position of addr at the given
+// timestamp without changing state. The reward is projected at the rate of
+// the current round and is zero if the holder is not eligible or has already
+// claimed it.
+func GetDividendHolder(s vm.StateDB, addr common.Address, now uint64) DividendHolder {
+	holder := DividendHolder{
+		Address:      addr,
+		Balance:      s.GetBalance(addr).ToBig(),
+		HoldingSince: getHoldingTime(s, addr),
+		Held:         getHeldAmount(s, addr),
+		Pending:      new(big.Int),
+		Reward:       new(big.Int),
+	}
+	if round := getRoundID(s); round != 0 {
+		holder.Claimed = getClaimedRound(s, addr) == round
+	}
+	head, tail := getRecentHead(s, addr), getRecentTail(s, addr)
+	if holder.Held.Sign() == 0 && head == tail && holder.Balance.Sign() > 0 {
+		// Mirrors bootstrapHolding.
+		holder.Held.Set(holder.Balance)
+		holder.HoldingSince = 0
+	}
+	maturing := true
+	for idx := head; idx < tail; idx++ {
+		amt, t := getRecentEntry(s, addr, idx)
+		if amt.Sign() == 0 {
+			continue
+		}
+		// Mirrors matureRecent, which stops at the first young entry.
+		if maturing && now-t >= dividendQualify {
+			if holder.Held.Sign() == 0 {
+				holder.HoldingSince = t
+			}
+			holder.Held.Add(holder.Held, amt)
+			continue
+		}
+		maturing = false
+		holder.Pending.Add(holder.Pending, amt)
+		holder.Recent = append(holder.Recent, DividendRecentEntry{Amount: amt, Time: t})
+	}
+	holder.Eligible = holder.Held.Sign() > 0 && now-holder.HoldingSince >= dividendQualify
+	if holder.Eligible && !holder.Claimed {
+		holder.Reward.Mul(holder.Held, new(big.Int).SetUint64(getRoundRate(s)))
+		holder.Reward.Div(holder.Reward, big.NewInt(10000))
+	}
+	return holder
+}
+
+// DividendSnapshot lists the dividend holders in the state of a block.
+type DividendSnapshot struct {
+	Block            uint64           `json:"block"`
+	Hash             common.Hash      `json:"hash"`
+	Time             uint64           `json:"time"`
+	Round            DividendRound    `json:"round"`
+	Holders          []DividendHolder `json:"holders"`
+	TotalEligible    *big.Int         `json:"totalEligible"`
+	TotalRewards     *big.Int         `json:"totalRewards"`
+	MissingPreimages uint64           `json:"missingPreimages"`
+
+	// Next is the account hash to resume a ranged snapshot at, nil once the
+	// state has been iterated to the end.
+	Next []byte `json:"next,omitempty"`
+}
+
+// TakeDividendSnapshot iterates all accounts in the state of the given block
+// and collects the dividend position of each holder at the block time. Accounts holding
+// nothing are skipped, as are those not eligible unless all is set. Accounts
+// whose address preimage is unknown cannot be evaluated and are only counted.
+func TakeDividendSnapshot(statedb *state.StateDB, header *types.Header, all bool) *DividendSnapshot {
+	return TakeDividendSnapshotRange(statedb, header, all, nil, 0)
+}
+
+// TakeDividendSnapshotRange is like TakeDividendSnapshot, but iterates at most
+// max accounts (all if zero) starting at the account hash start. The totals
+// cover the iterated accounts only and Next is set if accounts remain.
+func TakeDividendSnapshotRange(statedb *state.StateDB, header *types.Header, all bool, start []byte, max uint64) *DividendSnapshot {
+	now := header.Time
+	snap := &DividendSnapshot{
+		Block:         header.Number.Uint64(),
+		Hash:          header.Hash(),
+		Time:          now,
+		Round:         GetDividendRound(statedb, now),
+		Holders:       make([]DividendHolder, 0),
+		TotalEligible: new(big.Int),
+		TotalRewards:  new(big.Int),
+	}
+	collector := &dividendCollector{statedb: statedb, now: now, all: all, snap: snap}
+	snap.Next = statedb.DumpToCollector(collector, &state.DumpConfig{SkipCode: true, SkipStorage: true, Start: start, Max: max})
+	return snap
+}
+
+type dividendCollector struct {
+	statedb *state.StateDB
+	now     uint64
+	all     bool
+	snap    *DividendSnapshot
+}
+
+func (c *dividendCollector) OnRoot(common.Hash) {}
+
+func (c *dividendCollector) OnAccount(addr *common.Address, account state.DumpAccount) {
+	if addr == nil {
+		c.snap.MissingPreimages++
+		return
+	}
+	holder := GetDividendHolder(c.statedb, *addr, c.now)
+	if holder.Held.Sign() == 0 && holder.Pending.Sign() == 0 {
+		return
+	}
+	if holder.Eligible {
+		c.snap.TotalEligible.Add(c.snap.TotalEligible, holder.Held)
+		c.snap.TotalRewards.Add(c.snap.TotalRewards, holder.Reward)
+	} else if !c.all {
+		return
+	}
+	c.snap.Holders = append(c.snap.Holders, holder)
+}
+
+// WriteCSV writes one line per holder with its address, eligible amount,
+// projected reward, claim status, holding start and pending amount.
+func (snap *DividendSnapshot) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"address", "eligible", "held", "reward", "claimed", "holdingSince", "pending"}); err != nil {
+		return err
+	}
+	for _, h := range snap.Holders {
+		record := []string{
+			h.Address.Hex(),
+			strconv.FormatBool(h.Eligible),
+			h.Held.String(),
+			h.Reward.String(),
+			strconv.FormatBool(h.Claimed),
+			strconv.FormatUint(h.HoldingSince, 10),
+			h.Pending.String(),
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
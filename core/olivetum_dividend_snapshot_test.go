@@ -0,0 +1,109 @@
+package core
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/holiman/uint256"
+)
+
+func TestDividendSnapshot(t *testing.T) {
+	var (
+		db      = state.NewDatabaseWithConfig(rawdb.NewMemoryDatabase(), &triedb.Config{Preimages: true})
+		day     = uint64(24 * 60 * 60)
+		now     = 100 * day
+		matured = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		young   = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		legacy  = common.HexToAddress("0x3333333333333333333333333333333333333333")
+		claimed = common.HexToAddress("0x4444444444444444444444444444444444444444")
+	)
+	statedb, _ := state.New(types.EmptyRootHash, db, nil)
+	credit := func(addr common.Address, amount int64, at uint64) {
+		statedb.AddBalance(addr, uint256.NewInt(uint64(amount)))
+		AddHolding(statedb, addr, big.NewInt(amount), at)
+	}
+	credit(matured, 10000, now-40*day)
+	credit(matured, 5000, now-day)
+	credit(young, 7000, now-day)
+	statedb.AddBalance(legacy, uint256.NewInt(20000))
+	credit(claimed, 30000, now-50*day)
+
+	setRoundRate(statedb, 100)
+	setRoundStart(statedb, now-60)
+	setRoundID(statedb, 1)
+	setClaimedRound(statedb, claimed, 1)
+
+	root, err := statedb.Commit(0, false)
+	if err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	statedb, _ = state.New(root, db, nil)
+	header := &types.Header{Number: big.NewInt(7), Time: now, Root: root}
+
+	snap := TakeDividendSnapshot(statedb, header, false)
+	if !snap.Round.Open || snap.Round.ID != 1 {
+		t.Fatalf("unexpected round %+v", snap.Round)
+	}
+	holders := make(map[common.Address]DividendHolder)
+	for _, h := range snap.Holders {
+		holders[h.Address] = h
+	}
+	if _, ok := holders[young]; ok {
+		t.Fatalf("young holder should not be listed")
+	}
+	if h := holders[matured]; h.Held.Cmp(big.NewInt(10000)) != 0 || h.Pending.Cmp(big.NewInt(5000)) != 0 || h.Reward.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("matured holder mismatch: held %v pending %v reward %v", h.Held, h.Pending, h.Reward)
+	}
+	if h := holders[legacy]; !h.Eligible || h.Reward.Cmp(big.NewInt(200)) != 0 {
+		t.Fatalf("bootstrapped holder mismatch: eligible %v reward %v", h.Eligible, h.Reward)
+	}
+	if h := holders[claimed]; !h.Claimed || h.Reward.Sign() != 0 {
+		t.Fatalf("claimed holder mismatch: claimed %v reward %v", h.Claimed, h.Reward)
+	}
+	if snap.TotalRewards.Cmp(big.NewInt(300)) != 0 {
+		t.Fatalf("total rewards: have %v want 300", snap.TotalRewards)
+	}
+
+	// The projection must match what a claim pays out.
+	for _, addr := range []common.Address{matured, legacy} {
+		reward, ok := ClaimDividend(statedb.Copy(), addr, now)
+		if !ok || reward.Cmp(holders[addr].Reward) != 0 {
+			t.Fatalf("claim of %x paid %v, snapshot projected %v", addr, reward, holders[addr].Reward)
+		}
+	}
+
+	all := TakeDividendSnapshot(statedb, header, true)
+	if len(all.Holders) != 4 {
+		t.Fatalf("expected 4 holders with all set, got %d", len(all.Holders))
+	}
+	// Paging through the state finds the same holders.
+	var paged []DividendHolder
+	for start, pages := []byte(nil), 0; ; pages++ {
+		page := TakeDividendSnapshotRange(statedb, header, true, start, 2)
+		paged = append(paged, page.Holders...)
+		if page.Next == nil {
+			break
+		}
+		if pages > 100 {
+			t.Fatalf("paging does not terminate")
+		}
+		start = page.Next
+	}
+	if len(paged) != len(all.Holders) {
+		t.Fatalf("paged snapshot has %d holders, want %d", len(paged), len(all.Holders))
+	}
+	var buf bytes.Buffer
+	if err := snap.WriteCSV(&buf); err != nil {
+		t.Fatalf("csv: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 4 {
+		t.Fatalf("expected header and 3 csv lines, got %d", len(lines))
+	}
+}
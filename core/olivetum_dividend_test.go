@@ -10,7 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
-func newDividendState(t *testing.T) *state.StateDB {
+func newDividendState(t testing.TB) *state.StateDB {
 	t.Helper()
 	memdb := rawdb.NewMemoryDatabase()
 	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(memdb), nil)
@@ -24,6 +24,50 @@ func ApplyEconomyBaseline(state vm.StateDB, blockNumber *big.Int) {
 	AddTotalDividendsMinted(state, params.EconomyBaselineDividendsMintedWei())
 }
 
+// MintBlockReward mints the gross block reward, capped at the remaining supply,
+// and credits it to the coinbase minus the reward burn at the current burn
+// rate. From the reward burn fork on, the burn is recorded in totalBurned and
+// totalBurnedRewards. It returns the minted and the burned amounts.
+func MintBlockReward(state vm.StateDB, coinbase common.Address, gross *big.Int, number *big.Int, now uint64) (*big.Int, *big.Int) {
+	if state == nil || gross == nil || gross.Sign() == 0 {
+		return new(big.Int), new(big.Int)
+	}
+	minted := GetTotalMinted(state)
+	remaining := new(big.Int).Sub(params.MaxSupply(), minted)
+	if remaining.Sign() <= 0 {
+		return new(big.Int), new(big.Int)
+	}
+	amount := new(big.Int).Set(gross)
+	if amount.Cmp(remaining) > 0 {
+		amount = remaining
+	}
+	burn := RewardBurn(amount, GetBurnRate(state))
+	SetTotalMinted(state, minted.Add(minted, amount))
+	if params.RewardBurnFork.Active(number) {
+		AddTotalBurned(state, burn)
+		AddTotalBurnedRewards(state, burn)
+	}
+	payout := new(big.Int).Sub(amount, burn)
+	if payout.Sign() > 0 {
+		state.AddBalance(coinbase, uint256.MustFromBig(payout))
+		AddHolding(state, coinbase, payout, now)
+	}
+	return amount, burn
+}
+
+// RewardBurn returns the part of a block reward burned at the given rate.
+func RewardBurn(amount *big.Int, rate uint64) *big.Int {
+	if amount.Sign() == 0 || rate == 0 {
+		return new(big.Int)
+	}
+	burn := new(big.Int).Mul(amount, new(big.Int).SetUint64(rate))
+	burn.Div(burn, big.NewInt(10000))
+	if burn.Cmp(amount) > 0 {
+		return new(big.Int).Set(amount)
+	}
+	return burn
+}
+
 func MintDividendClaimTip(state vm.StateDB, coinbase common.Address, dividendReward *big.Int, now uint64) *big.Int {
 	if state == nil || dividendReward == nil || dividendReward.Sign() == 0 {
 		return new(big.Int)
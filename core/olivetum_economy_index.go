@@ -0,0 +1,101 @@
+package core
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+)
+
+// EconomyRecord reads the economy counters and rates from a state, along with
+// the balance held by the excluded accounts.
+func EconomyRecord(s vm.StateDB, excluded []common.Address) *rawdb.OlivetumEconomyRecord {
+	return &rawdb.OlivetumEconomyRecord{
+		Minted:          GetTotalMinted(s),
+		Burned:          GetTotalBurned(s),
+		BurnedTransfers: GetTotalBurnedTransfers(s),
+		BurnedGas:       GetTotalBurnedGas(s),
+		BurnedRewards:   GetTotalBurnedRewards(s),
+		MinerBurnShare:  GetTotalMinerBurnShare(s),
+		DividendsMinted: GetTotalDividendsMinted(s),
+		BurnRate:        GetBurnRate(s),
+		DividendRate:    GetDividendRate(s),
+		Locked:          LockedBalance(s, excluded),
+	}
+}
+
+// LockedBalance sums the balances of the accounts excluded from the
+// circulating supply.
+func LockedBalance(s vm.StateDB, excluded []common.Address) *big.Int {
+	locked := new(big.Int)
+	for _, addr := range excluded {
+		locked.Add(locked, s.GetBalance(addr).ToBig())
+	}
+	return locked
+}
+
+// GenesisAllocBalance sums the balances allocated in the genesis.
+func GenesisAllocBalance(genesis *genesisT.Genesis) *big.Int {
+	alloc := new(big.Int)
+	for _, account := range genesis.Alloc {
+		if account.Balance != nil {
+			alloc.Add(alloc, account.Balance)
+		}
+	}
+	return alloc
+}
+
+// OutstandingSupply derives the supply in existence from the genesis
+// allocation and an economy record: everything allocated, minted as rewards or
+// paid as dividends, less everything burned. Reward burns made before the
+// reward burn fork are not tracked and are therefore still counted.
+func OutstandingSupply(genesisAlloc *big.Int, record *rawdb.OlivetumEconomyRecord) *big.Int {
+	supply := new(big.Int).Add(genesisAlloc, record.Minted)
+	supply.Add(supply, record.DividendsMinted)
+	supply.Sub(supply, record.Burned)
+	if supply.Sign() < 0 {
+		supply.SetInt64(0)
+	}
+	return supply
+}
+
+// CirculatingSupply is the outstanding supply less the balance of the accounts
+// excluded from circulation.
+func CirculatingSupply(genesisAlloc *big.Int, record *rawdb.OlivetumEconomyRecord) *big.Int {
+	supply := OutstandingSupply(genesisAlloc, record)
+	if record.Locked != nil {
+		supply.Sub(supply, record.Locked)
+	}
+	if supply.Sign() < 0 {
+		supply.SetInt64(0)
+	}
+	return supply
+}
+
+// writeOlivetumEconomyRecord records the economy counters of a new canonical
+// head from its state, so that their history can be served without reading
+// historical state. The record of a block whose state is missing is removed
+// rather than left to a block it replaced at the same height.
+func (bc *BlockChain) writeOlivetumEconomyRecord(db ethdb.KeyValueWriter, block *types.Block) {
+	statedb, err := bc.StateAt(block.Root())
+	if err != nil {
+		log.Warn("Olivetum economy record missing block state", "number", block.NumberU64(), "hash", block.Hash(), "err", err)
+		rawdb.DeleteOlivetumEconomyRecord(db, block.NumberU64())
+		return
+	}
+	rawdb.WriteOlivetumEconomyRecord(db, block.NumberU64(), EconomyRecord(statedb, bc.OlivetumSupplyExcluded()))
+}
+
+// OlivetumSupplyExcluded returns the accounts left out of the circulating
+// supply and recorded as locked.
+func (bc *BlockChain) OlivetumSupplyExcluded() []common.Address {
+	if len(bc.cacheConfig.OlivetumSupplyExclude) > 0 {
+		return bc.cacheConfig.OlivetumSupplyExclude
+	}
+	return DefaultSupplyExcluded()
+}
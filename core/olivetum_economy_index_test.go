@@ -0,0 +1,91 @@
+package core_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/olivetumhash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+func TestOlivetumEconomyRecords(t *testing.T) {
+	oldEconomy := params.GetEconomyForkBlock()
+	t.Cleanup(func() { params.SetEconomyForkBlock(oldEconomy) })
+	params.SetEconomyForkBlock(big.NewInt(1))
+
+	genesisTime := uint64(time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC).Unix())
+	spenderKey, _ := crypto.GenerateKey()
+	spender := crypto.PubkeyToAddress(spenderKey.PublicKey)
+	claimer := common.HexToAddress("0x0000000000000000000000000000000000000011")
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000022")
+	spenderBal := new(big.Int).Mul(big.NewInt(10000), big.NewInt(vars.Ether))
+
+	genesis := olivetumTestGenesis(genesisTime, spender, spenderBal, claimer, big.NewInt(vars.Ether), 150, 50)
+	value := new(big.Int).Mul(big.NewInt(100), big.NewInt(vars.Ether))
+	_, blocksA, _ := core.GenerateChainWithGenesis(genesis, olivetumhash.NewFaker(), 4, func(i int, gen *core.BlockGen) {
+		if i != 2 {
+			return
+		}
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(spender), recipient, value, vars.TxGas, new(big.Int), nil), gen.Signer(), spenderKey)
+		if err != nil {
+			t.Fatalf("sign tx: %v", err)
+		}
+		gen.AddTx(tx)
+	})
+	_, blocksB, _ := core.GenerateChainWithGenesis(genesis, olivetumhash.NewFaker(), 5, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(common.HexToAddress("0x00000000000000000000000000000000000000bb"))
+	})
+	db := rawdb.NewMemoryDatabase()
+	chain, err := core.NewBlockChain(db, core.DefaultCacheConfigWithScheme(rawdb.HashScheme), genesis, nil, olivetumhash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("new chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// Every canonical block is recorded as it becomes the head.
+	check := func(db ethdb.KeyValueReader, head uint64) {
+		t.Helper()
+		for n := uint64(0); n <= head; n++ {
+			record := rawdb.ReadOlivetumEconomyRecord(db, n)
+			if record == nil {
+				t.Fatalf("block %d not recorded", n)
+			}
+			statedb, err := chain.StateAt(chain.GetHeaderByNumber(n).Root)
+			if err != nil {
+				t.Fatalf("state %d: %v", n, err)
+			}
+			want := core.EconomyRecord(statedb, core.DefaultSupplyExcluded())
+			if record.Minted.Cmp(want.Minted) != 0 || record.Burned.Cmp(want.Burned) != 0 || record.BurnedTransfers.Cmp(want.BurnedTransfers) != 0 || record.BurnRate != want.BurnRate {
+				t.Fatalf("block %d: have %+v want %+v", n, record, want)
+			}
+		}
+	}
+	if _, err := chain.InsertChain(blocksA); err != nil {
+		t.Fatalf("insert chain A: %v", err)
+	}
+	check(db, 4)
+	before, after := rawdb.ReadOlivetumEconomyRecord(db, 2), rawdb.ReadOlivetumEconomyRecord(db, 3)
+	if after.BurnedTransfers.Cmp(before.BurnedTransfers) <= 0 {
+		t.Fatalf("transfer burn not recorded: before %v after %v", before.BurnedTransfers, after.BurnedTransfers)
+	}
+	// The heavier chain B replaces the records of chain A.
+	if _, err := chain.InsertChain(blocksB); err != nil {
+		t.Fatalf("insert chain B: %v", err)
+	}
+	if chain.CurrentBlock().Hash() != blocksB[4].Hash() {
+		t.Fatalf("chain B not canonical")
+	}
+	check(db, 5)
+	if record := rawdb.ReadOlivetumEconomyRecord(db, 3); record.BurnedTransfers.Cmp(after.BurnedTransfers) == 0 {
+		t.Fatalf("record of chain A left behind: %+v", record)
+	}
+}
@@ -0,0 +1,241 @@
+package core
+
+import (
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// olivetumFuzzMaxOps bounds the operations of a fuzzed sequence, as views scan
+// the whole recent holding queue.
+const olivetumFuzzMaxOps = 256
+
+// olivetumFuzzTargets are the recipients the payload fuzzer picks from: the
+// management contracts followed by plain accounts.
+var olivetumFuzzTargets = []common.Address{
+	DividendContract,
+	BurnContract,
+	params.GasLimitContract,
+	params.PeriodContract,
+	params.MinTxAmountContract,
+	params.TxRateLimitContract,
+	params.OffSessionTxRateContract,
+	params.OffSessionMaxPerTxContract,
+	params.SessionTzContract,
+	params.SessionCalendarContract,
+	params.AddressListContract,
+	common.HexToAddress("0x00000000000000000000000000000000000000aa"),
+	DividendAdmin,
+}
+
+// decodesOlivetumPayload reports whether data is a well-formed payload for the
+// management contract to, and whether to is a management contract at all.
+func decodesOlivetumPayload(to common.Address, data []byte) (ok bool, management bool) {
+	switch to {
+	case DividendContract:
+		return len(data) == 0, true
+	case BurnContract:
+		_, ok = DecodeBurnRate(data)
+	case params.GasLimitContract:
+		_, ok = params.DecodeGasLimit(data)
+	case params.PeriodContract:
+		_, ok = params.DecodeBlockPeriod(data)
+	case params.MinTxAmountContract:
+		_, ok = params.DecodeMinTxAmount(data)
+	case params.TxRateLimitContract:
+		_, ok = params.DecodeTxRateLimit(data)
+	case params.OffSessionTxRateContract:
+		_, ok = params.DecodeOffSessionTxRate(data)
+	case params.OffSessionMaxPerTxContract:
+		_, ok = params.DecodeOffSessionMaxPerTx(data)
+	case params.SessionTzContract:
+		_, ok = params.DecodeSessionTzOffset(data)
+	case params.SessionCalendarContract:
+		_, ok = params.DecodeSessionCalendarUpdate(data)
+	case params.AddressListContract:
+		_, ok = params.DecodeAddressListUpdate(data)
+	default:
+		return false, false
+	}
+	return ok, true
+}
+
+// FuzzValidateOlivetumTxPayload checks that the payload validation shared by
+// the pool and the state transition accepts every payload the management
+// decoders accept, and nothing the Olivetum rules forbid.
+func FuzzValidateOlivetumTxPayload(f *testing.F) {
+	f.Add(uint8(0), uint64(0), []byte{}, uint8(0x03))
+	f.Add(uint8(1), uint64(0), []byte{0x96}, uint8(0x03))
+	f.Add(uint8(4), uint64(0), []byte{0, 0, 0, 0, 0, 0, 0x03, 0xe8}, uint8(0x03))
+	f.Add(uint8(10), uint64(0), append([]byte{params.AddressListOpAdd, params.AddressClassFrozen}, make([]byte, 20)...), uint8(0x03))
+	f.Add(uint8(11), uint64(1), params.EncodeTransferMemo([]byte("ref")), uint8(0x03))
+	f.Add(uint8(11), uint64(1), []byte{0x01}, uint8(0x07))
+
+	f.Fuzz(func(t *testing.T, toSel uint8, value uint64, data []byte, flags uint8) {
+		var (
+			to          = olivetumFuzzTargets[int(toSel)%len(olivetumFuzzTargets)]
+			from        = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+			amount      = new(big.Int).SetUint64(value)
+			economyFork = flags&0x01 != 0
+			memoFork    = flags&0x02 != 0
+			accessList  types.AccessList
+		)
+		if flags&0x04 != 0 {
+			accessList = types.AccessList{{Address: to}}
+		}
+		if flags&0x08 != 0 {
+			from = DividendAdmin
+		}
+		err := ValidateOlivetumTxPayload(from, to, amount, data, accessList, economyFork, memoFork)
+		if !economyFork {
+			if err != nil {
+				t.Fatalf("payload rejected before the economy fork: %v", err)
+			}
+			return
+		}
+		decodes, management := decodesOlivetumPayload(to, data)
+		if err == nil {
+			if len(accessList) > 0 {
+				t.Fatal("access list accepted")
+			}
+			if management && amount.Sign() != 0 {
+				t.Fatalf("value %v accepted by management contract %x", amount, to)
+			}
+			if !management && len(data) > 0 {
+				if _, ok := params.DecodeTransferMemo(data); !ok || !memoFork || amount.Sign() == 0 {
+					t.Fatalf("transfer data %x accepted", data)
+				}
+			}
+			return
+		}
+		if len(accessList) == 0 && amount.Sign() == 0 && decodes {
+			t.Fatalf("well-formed payload %x to %x rejected: %v", data, to, err)
+		}
+	})
+}
+
+// FuzzOlivetumHoldings applies random sequences of credits and debits to the
+// holding queue of an account and checks that the held and recent amounts
+// always add up to the balance they track, which never goes negative.
+func FuzzOlivetumHoldings(f *testing.F) {
+	f.Add(false, []byte{0x00, 0x01, 0x10, 0x00, 0x80, 0x01, 0x05, 0x08})
+	f.Add(true, []byte{0x00, 0x00, 0x02, 0x00, 0x01, 0x30, 0x03, 0x40, 0x00, 0x04, 0x10, 0x12})
+	f.Add(true, []byte{0x00, 0xff, 0x20, 0x13, 0x01, 0xff, 0x20, 0x13})
+
+	f.Fuzz(func(t *testing.T, buckets bool, ops []byte) {
+		var (
+			statedb = newDividendState(t)
+			addr    = common.HexToAddress("0x1")
+			now     = uint64(1_700_000_000)
+			total   = new(big.Int)
+		)
+		if buckets {
+			enableDividendBuckets(statedb)
+		}
+		// Each operation is 4 bytes: kind, time step in hours, mantissa and
+		// decimal exponent of the amount.
+		if len(ops) > 4*olivetumFuzzMaxOps {
+			ops = ops[:4*olivetumFuzzMaxOps]
+		}
+		for ; len(ops) >= 4; ops = ops[4:] {
+			now += uint64(ops[1]) * 3600
+			amount := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(ops[3]%24)), nil)
+			amount.Mul(amount, big.NewInt(int64(ops[2])))
+
+			if ops[0]%2 == 0 {
+				AddHolding(statedb, addr, amount, now)
+				total.Add(total, amount)
+			} else {
+				RemoveHolding(statedb, addr, amount, now)
+				if total.Sub(total, amount); total.Sign() < 0 {
+					total.SetInt64(0)
+				}
+			}
+			head, tail := getRecentHead(statedb, addr), getRecentTail(statedb, addr)
+			if head > tail {
+				t.Fatalf("recent queue head %d past tail %d", head, tail)
+			}
+			view := GetDividendView(statedb, addr, now)
+			if view.EligibleNow.Sign() < 0 || view.Pending.Sign() < 0 {
+				t.Fatalf("negative holding: eligible %v pending %v", view.EligibleNow, view.Pending)
+			}
+			if have := new(big.Int).Add(view.EligibleNow, view.Pending); have.Cmp(total) != 0 {
+				t.Fatalf("holding mismatch at %d: have %v want %v", now, have, total)
+			}
+		}
+	})
+}
+
+// FuzzOlivetumOffSessionBudget checks the off-session budget against a model
+// that resets the spent amount whenever the budget window changes and rejects
+// any transfer pushing it above the per-window limit.
+func FuzzOlivetumOffSessionBudget(f *testing.F) {
+	f.Add(uint64(10_000), []byte{0x00, 0x00, 0x10, 0x27, 0x01, 0x00, 0x10, 0x27})
+	f.Add(uint64(1), []byte{0x02, 0x00, 0x00, 0x01, 0x30, 0x00, 0x00, 0x01})
+	f.Add(uint64(50_000_000), []byte{0x00, 0xff, 0xff, 0xff, 0x0c, 0x00, 0x00, 0x02})
+
+	oldMax := params.GetOffSessionMaxPerTx()
+	f.Cleanup(func() { params.SetOffSessionMaxPerTx(oldMax) })
+
+	f.Fuzz(func(t *testing.T, units uint64, ops []byte) {
+		limit, ok := params.DecodeOffSessionMaxPerTx(binary.BigEndian.AppendUint64(nil, units))
+		if !ok {
+			return
+		}
+		params.SetOffSessionMaxPerTx(limit)
+
+		var (
+			statedb = newDividendState(t)
+			addr    = common.HexToAddress("0x1")
+			now     = uint64(1_700_000_000)
+			window  = uint64(0)
+			spent   = new(big.Int)
+		)
+		// Each operation is 4 bytes: time step in hours, then the amount in
+		// units of the smallest per-tx maximum. Like the state transition,
+		// only transfers outside the session are charged.
+		if len(ops) > 4*olivetumFuzzMaxOps {
+			ops = ops[:4*olivetumFuzzMaxOps]
+		}
+		for ; len(ops) >= 4; ops = ops[4:] {
+			now += uint64(ops[0]) * 3600
+			amount := new(big.Int).SetUint64(uint64(ops[1])<<16 | uint64(ops[2])<<8 | uint64(ops[3]))
+			amount.Mul(amount, params.OffSessionMaxPerTxMin)
+
+			if w := offSessionBudgetWindow(now); w != window {
+				window, spent = w, new(big.Int)
+			}
+			if IsSession(now) {
+				continue
+			}
+			next := new(big.Int).Add(spent, amount)
+			err := UpdateOffSessionBudget(statedb, addr, amount, now)
+			switch {
+			case amount.Sign() == 0:
+				if err != nil {
+					t.Fatalf("empty transfer rejected: %v", err)
+				}
+			case next.Cmp(limit) > 0:
+				if err != ErrOverMaxOffSessionBudget {
+					t.Fatalf("spending %v of %v: have error %v", next, limit, err)
+				}
+			default:
+				if err != nil {
+					t.Fatalf("spending %v of %v rejected: %v", next, limit, err)
+				}
+				spent = next
+			}
+			have := GetOffSessionBudgetSpent(statedb, addr, now)
+			if have.Cmp(spent) != 0 {
+				t.Fatalf("spent mismatch: have %v want %v", have, spent)
+			}
+			if have.Cmp(limit) > 0 {
+				t.Fatalf("spent %v above limit %v", have, limit)
+			}
+		}
+	})
+}
@@ -0,0 +1,220 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+)
+
+var ErrHolderIndexDisabled = errors.New("dividend holder index not enabled")
+
+const (
+	// olivetumIndexUndoDepth is the number of blocks for which the node-side
+	// indexes keep rollback records. Deeper reorgs leave stale entries behind
+	// until the accounts are touched again.
+	olivetumIndexUndoDepth = 1024
+
+	// olivetumIndexAncestorSearch bounds the header walk used to tell whether
+	// the last indexed block is an ancestor of a new head.
+	olivetumIndexAncestorSearch = 256
+)
+
+// dividendTouchedAccounts returns the accounts whose dividend holdings may have
+// changed in a block: transaction senders and recipients, batch transfer
+// entries, the coinbase and the holders paid out by the automatic
+// distribution.
+func dividendTouchedAccounts(config ctypes.ChainConfigurator, block *types.Block, payouts []*types.Log) []common.Address {
+	var (
+		seen   = make(map[common.Address]struct{})
+		out    []common.Address
+		signer = types.MakeSigner(config, block.Number(), block.Time())
+	)
+	add := func(addr common.Address) {
+		if addr == (common.Address{}) || isOlivetumSystemAddress(addr) {
+			return
+		}
+		if _, ok := seen[addr]; !ok {
+			seen[addr] = struct{}{}
+			out = append(out, addr)
+		}
+	}
+	add(block.Coinbase())
+	for _, tx := range block.Transactions() {
+		if from, err := types.Sender(signer, tx); err == nil {
+			add(from)
+		}
+		to := tx.To()
+		if to == nil {
+			continue
+		}
+		if *to == params.BatchTransferContract {
+			if entries, ok := params.DecodeBatchTransfer(tx.Data()); ok {
+				for _, e := range entries {
+					add(e.To)
+				}
+			}
+			continue
+		}
+		add(*to)
+	}
+	for _, l := range payouts {
+		if len(l.Topics) > 1 && l.Topics[0] == DividendClaimedTopic {
+			add(common.BytesToAddress(l.Topics[1].Bytes()))
+		}
+	}
+	return out
+}
+
+// writeOlivetumHolderIndex applies a new canonical head to the dividend holder
+// index. Indexed blocks that are not ancestors of the head, left behind by a
+// reorg or a rewind, are rolled back first. The positions of the touched
+// accounts are read from the head state; accounts without any holding are
+// dropped from the index. If that state is missing the index is reseeded. The accounts left untouched since the index was
+// enabled are filled in by seeding it from the state snapshot.
+func (bc *BlockChain) writeOlivetumHolderIndex(db ethdb.KeyValueWriter, block *types.Block) {
+	hash, number := block.Hash(), block.NumberU64()
+	indexed, indexedNum, ok := rawdb.ReadOlivetumHolderIndexHead(bc.db)
+	if ok && indexed == hash {
+		return
+	}
+	// Roll back indexed blocks until the index head is an ancestor of the block.
+	pending := make(map[common.Address]*rawdb.OlivetumHolderEntry)
+	if ok {
+		bc.rollbackOlivetumIndex(indexed, indexedNum, block, func(hash common.Hash, number uint64) bool {
+			undo, found := rawdb.ReadOlivetumHolderUndo(bc.db, hash, number)
+			for _, u := range undo {
+				pending[u.Addr] = u.Prev
+			}
+			return found
+		})
+	}
+	readEntry := func(addr common.Address) *rawdb.OlivetumHolderEntry {
+		if entry, ok := pending[addr]; ok {
+			return entry
+		}
+		return rawdb.ReadOlivetumHolder(bc.db, addr)
+	}
+	// Index the accounts touched by the block against its state. Without the
+	// state the index falls behind and is seeded again from the head.
+	touched := dividendTouchedAccounts(bc.chainConfig, block, rawdb.ReadOlivetumPayoutLogs(bc.db, hash, number))
+	statedb, err := bc.StateAt(block.Root())
+	if err != nil {
+		log.Warn("Olivetum holder index missed block state, reseeding", "number", number, "hash", hash, "err", err)
+		bc.reseedOlivetumIndex(db, bc.olivetumHolderSeed)
+	} else {
+		var undo []rawdb.OlivetumHolderUndo
+		for _, addr := range touched {
+			undo = append(undo, rawdb.OlivetumHolderUndo{Addr: addr, Prev: readEntry(addr)})
+
+			holder := GetDividendHolder(statedb, addr, block.Time())
+			if holder.Held.Sign() == 0 && holder.Pending.Sign() == 0 {
+				pending[addr] = nil
+			} else {
+				pending[addr] = &rawdb.OlivetumHolderEntry{Held: holder.Held, Pending: holder.Pending, Number: number}
+			}
+		}
+		rawdb.WriteOlivetumHolderUndo(db, hash, number, undo)
+	}
+	bc.olivetumHolderSeed.writePreimages(db, touched)
+	for addr, entry := range pending {
+		if entry == nil {
+			rawdb.DeleteOlivetumHolder(db, addr)
+		} else {
+			rawdb.WriteOlivetumHolder(db, addr, entry)
+		}
+	}
+	rawdb.WriteOlivetumHolderIndexHead(db, hash, number)
+	if number > olivetumIndexUndoDepth {
+		rawdb.DeleteOlivetumHolderUndos(bc.db, number-olivetumIndexUndoDepth)
+	}
+}
+
+// seedOlivetumHolder brings the holder index entry of an account in line with
+// the head state. Entries that are already current keep the block they were
+// last changed at.
+func (bc *BlockChain) seedOlivetumHolder(db ethdb.KeyValueWriter, statedb *state.StateDB, addr common.Address, head *types.Header) {
+	var (
+		holder = GetDividendHolder(statedb, addr, head.Time)
+		prev   = rawdb.ReadOlivetumHolder(bc.db, addr)
+	)
+	switch {
+	case holder.Held.Sign() == 0 && holder.Pending.Sign() == 0:
+		if prev != nil {
+			rawdb.DeleteOlivetumHolder(db, addr)
+		}
+	case prev == nil || prev.Held.Cmp(holder.Held) != 0 || prev.Pending.Cmp(holder.Pending) != 0:
+		rawdb.WriteOlivetumHolder(db, addr, &rawdb.OlivetumHolderEntry{Held: holder.Held, Pending: holder.Pending, Number: head.Number.Uint64()})
+	}
+}
+
+// rollbackOlivetumIndex walks the head of a node-side index back until it is
+// an ancestor of block, calling undo for every block rolled back. It stops
+// early when undo finds no rollback record for a block.
+func (bc *BlockChain) rollbackOlivetumIndex(hash common.Hash, number uint64, block *types.Block, undo func(common.Hash, uint64) bool) {
+	for hash != block.ParentHash() {
+		if number < block.NumberU64() && bc.isOlivetumIndexAncestor(hash, number, block) {
+			return
+		}
+		if !undo(hash, number) {
+			return
+		}
+		header := bc.GetHeader(hash, number)
+		if header == nil || number == 0 {
+			return
+		}
+		hash, number = header.ParentHash, number-1
+	}
+}
+
+// isOlivetumIndexAncestor reports whether the block with the given hash and
+// number is an ancestor of block. Ancestors deeper than the search bound are
+// assumed to be on the same chain.
+func (bc *BlockChain) isOlivetumIndexAncestor(hash common.Hash, number uint64, block *types.Block) bool {
+	if block.NumberU64()-number > olivetumIndexAncestorSearch {
+		return true
+	}
+	header := block.Header()
+	for header != nil && header.Number.Uint64() > number {
+		header = bc.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	}
+	return header != nil && header.Hash() == hash
+}
+
+// OlivetumDividendHolders returns up to limit entries of the dividend holder
+// index in address order, starting at cursor, and the address to resume from
+// if more entries follow. The entries hold the positions as of the block that
+// last changed them; see rawdb.OlivetumHolderEntry.
+func (bc *BlockChain) OlivetumDividendHolders(cursor common.Address, limit int) ([]common.Address, []*rawdb.OlivetumHolderEntry, *common.Address, error) {
+	if !bc.cacheConfig.OlivetumHolderIndex {
+		return nil, nil, nil, ErrHolderIndexDisabled
+	}
+	var (
+		addrs   []common.Address
+		entries []*rawdb.OlivetumHolderEntry
+		next    *common.Address
+	)
+	rawdb.IterateOlivetumHolders(bc.db, cursor, func(addr common.Address, entry *rawdb.OlivetumHolderEntry) bool {
+		if len(addrs) == limit {
+			next = &addr
+			return false
+		}
+		addrs = append(addrs, addr)
+		entries = append(entries, entry)
+		return true
+	})
+	return addrs, entries, next, nil
+}
+
+// OlivetumHolderIndexComplete reports whether the dividend holder index has
+// been seeded with every account of the state. Until then it only lists the
+// accounts touched since it was enabled and the accounts seeded so far.
+func (bc *BlockChain) OlivetumHolderIndexComplete() bool {
+	return bc.olivetumHolderSeed.Complete()
+}
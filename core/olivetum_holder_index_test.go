@@ -0,0 +1,81 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+func TestOlivetumHolderIndexReseedsOnMissingState(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	var (
+		holder  = crypto.PubkeyToAddress(key.PublicKey)
+		balance = new(big.Int).Mul(big.NewInt(1000), big.NewInt(vars.Ether))
+		start   = uint64(time.Date(2024, time.March, 4, 10, 0, 0, 0, time.UTC).Unix())
+	)
+	genesis := NewOlivetumTestGenesis(start).Fund(key, balance).Genesis()
+
+	cacheConfig := DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.OlivetumHolderIndex = true
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), cacheConfig, genesis, nil, ethash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("new chain: %v", err)
+	}
+	defer chain.Stop()
+
+	waitSeeded := func() {
+		t.Helper()
+		for deadline := time.Now().Add(10 * time.Second); !chain.OlivetumHolderIndexComplete(); time.Sleep(10 * time.Millisecond) {
+			if time.Now().After(deadline) {
+				t.Fatal("index not seeded")
+			}
+		}
+	}
+	waitSeeded()
+	if rawdb.ReadOlivetumHolder(chain.db, holder) == nil {
+		t.Fatal("funded account not seeded")
+	}
+	// Drop the entry, as a block applied without its state would leave it
+	// behind, then apply a block whose state is missing.
+	rawdb.DeleteOlivetumHolder(chain.db, holder)
+
+	head := chain.CurrentBlock()
+	block := types.NewBlockWithHeader(&types.Header{
+		ParentHash: head.Hash(),
+		Number:     new(big.Int).Add(head.Number, common.Big1),
+		Root:       common.HexToHash("0x01"),
+		Coinbase:   holder,
+		Time:       head.Time + 1,
+		Difficulty: common.Big1,
+	})
+	if !chain.chainmu.TryLock() {
+		t.Fatal("chain stopped")
+	}
+	batch := chain.db.NewBatch()
+	chain.writeOlivetumHolderIndex(batch, block)
+	if err := batch.Write(); err != nil {
+		t.Fatalf("write index: %v", err)
+	}
+	if chain.OlivetumHolderIndexComplete() {
+		t.Fatal("index complete after missing a block state")
+	}
+	if progress := rawdb.ReadOlivetumIndexSeed(chain.db, olivetumHolderSeedName); progress == nil || progress.Done {
+		t.Fatalf("seed progress not reset: %+v", progress)
+	}
+	chain.chainmu.Unlock()
+
+	// Seeding runs again and restores the entry from the head state.
+	waitSeeded()
+	entry := rawdb.ReadOlivetumHolder(chain.db, holder)
+	if entry == nil || entry.Held.Cmp(balance) != 0 {
+		t.Fatalf("reseeded entry: have %+v want held %v", entry, balance)
+	}
+}
@@ -0,0 +1,274 @@
+package core
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// olivetumIndexSeedBatch is the number of snapshot accounts seeded while
+	// holding the chain lock once.
+	olivetumIndexSeedBatch = 1024
+
+	// olivetumIndexSeedRetry is how long seeding waits before retrying when
+	// the state snapshot or the head state is not available.
+	olivetumIndexSeedRetry = 5 * time.Second
+
+	olivetumHolderSeedName = "holder"
+	olivetumRichSeedName   = "rich"
+)
+
+// olivetumIndexSeed is the one-time seeding of a node-side index from the head
+// state snapshot. The indexes only follow the accounts touched by the blocks
+// they apply, so without it the accounts left untouched since an index was
+// enabled would be missing.
+type olivetumIndexSeed struct {
+	name     string
+	seeded   atomic.Bool // Whether seeding has finished
+	complete atomic.Bool // Whether seeding has finished without skipping accounts
+	stale    atomic.Bool // Whether the index fell behind the state and seeding has to restart
+
+	// apply brings the index entry of addr in line with the head state.
+	apply func(db ethdb.KeyValueWriter, statedb *state.StateDB, addr common.Address, head *types.Header)
+}
+
+func newOlivetumIndexSeed(db ethdb.KeyValueReader, name string, apply func(ethdb.KeyValueWriter, *state.StateDB, common.Address, *types.Header)) *olivetumIndexSeed {
+	s := &olivetumIndexSeed{name: name, apply: apply}
+	if progress := rawdb.ReadOlivetumIndexSeed(db, name); progress != nil {
+		s.seeded.Store(progress.Done)
+		s.complete.Store(progress.Done && progress.Missing == 0)
+	}
+	return s
+}
+
+// Seeded reports whether seeding has finished, even if it had to skip
+// accounts it could not resolve.
+func (s *olivetumIndexSeed) Seeded() bool {
+	return s != nil && s.seeded.Load()
+}
+
+// Complete reports whether every account of the state has been seeded.
+func (s *olivetumIndexSeed) Complete() bool {
+	return s != nil && s.complete.Load()
+}
+
+// invalidate restarts the seeding of an index that could not apply a block
+// against its state. The reset progress is written to db along with the block.
+func (s *olivetumIndexSeed) invalidate(db ethdb.KeyValueWriter) {
+	if s == nil {
+		return
+	}
+	rawdb.WriteOlivetumIndexSeed(db, s.name, new(rawdb.OlivetumIndexSeed))
+	s.seeded.Store(false)
+	s.complete.Store(false)
+	s.stale.Store(true)
+}
+
+// writePreimages records the preimages of the accounts applied to an index
+// while it is being seeded, so that seeding can resolve the accounts created
+// meanwhile even if the node does not store trie preimages.
+func (s *olivetumIndexSeed) writePreimages(db ethdb.KeyValueWriter, addrs []common.Address) {
+	if s.Complete() || len(addrs) == 0 {
+		return
+	}
+	preimages := make(map[common.Hash][]byte, len(addrs))
+	for _, addr := range addrs {
+		preimages[crypto.Keccak256Hash(addr.Bytes())] = common.CopyBytes(addr.Bytes())
+	}
+	rawdb.WritePreimages(db, preimages)
+}
+
+// startOlivetumIndexSeeds starts seeding the enabled node-side indexes that
+// have not been seeded yet.
+func (bc *BlockChain) startOlivetumIndexSeeds() {
+	var seeds []*olivetumIndexSeed
+	if bc.cacheConfig.OlivetumRichIndex {
+		bc.resetStaleOlivetumIndexSeed(olivetumRichSeedName, rawdb.ReadOlivetumRichIndexHead)
+		bc.olivetumRichSeed = newOlivetumIndexSeed(bc.db, olivetumRichSeedName, bc.seedOlivetumRichBalance)
+		seeds = append(seeds, bc.olivetumRichSeed)
+	}
+	if bc.cacheConfig.OlivetumHolderIndex {
+		bc.resetStaleOlivetumIndexSeed(olivetumHolderSeedName, rawdb.ReadOlivetumHolderIndexHead)
+		bc.olivetumHolderSeed = newOlivetumIndexSeed(bc.db, olivetumHolderSeedName, bc.seedOlivetumHolder)
+		seeds = append(seeds, bc.olivetumHolderSeed)
+	}
+	if len(seeds) == 0 {
+		return
+	}
+	bc.wg.Add(1)
+	go bc.seedOlivetumIndexes(seeds)
+}
+
+// resetStaleOlivetumIndexSeed restarts the seeding of an index left behind the
+// head while it was disabled, as it misses the accounts touched meanwhile.
+func (bc *BlockChain) resetStaleOlivetumIndexSeed(name string, readHead func(ethdb.KeyValueReader) (common.Hash, uint64, bool)) {
+	if _, number, ok := readHead(bc.db); ok && number < bc.CurrentBlock().Number.Uint64() {
+		rawdb.WriteOlivetumIndexSeed(bc.db, name, new(rawdb.OlivetumIndexSeed))
+	}
+}
+
+// reseedOlivetumIndex marks an index as behind the state, e.g. because the
+// state of a block it had to apply was missing, and wakes up the seeding to
+// bring it back in line with the head.
+func (bc *BlockChain) reseedOlivetumIndex(db ethdb.KeyValueWriter, s *olivetumIndexSeed) {
+	s.invalidate(db)
+	select {
+	case bc.olivetumReseed <- struct{}{}:
+	default:
+	}
+}
+
+// seedOlivetumIndexes runs the given index seeds one after the other, and again
+// whenever an index is marked for reseeding.
+func (bc *BlockChain) seedOlivetumIndexes(seeds []*olivetumIndexSeed) {
+	defer bc.wg.Done()
+
+	for {
+		for _, s := range seeds {
+			if !bc.seedOlivetumIndex(s) {
+				return
+			}
+		}
+		select {
+		case <-bc.olivetumReseed:
+		case <-bc.quit:
+			return
+		}
+	}
+}
+
+// seedOlivetumIndex walks the accounts of the head state snapshot and applies
+// them to an index, a batch at a time under the chain lock so that no block is
+// indexed meanwhile. Account hashes are resolved through the genesis
+// allocation and the stored preimages; accounts without a preimage are counted
+// and leave the index incomplete. It returns false if the chain was stopped.
+func (bc *BlockChain) seedOlivetumIndex(s *olivetumIndexSeed) bool {
+	progress := rawdb.ReadOlivetumIndexSeed(bc.db, s.name)
+	if progress == nil || s.stale.Swap(false) {
+		progress = new(rawdb.OlivetumIndexSeed)
+	}
+	if progress.Done {
+		return true
+	}
+	if bc.snaps == nil {
+		log.Warn("Olivetum index cannot be seeded without the state snapshot", "index", s.name)
+		return true
+	}
+	genesis := make(map[common.Hash]common.Address)
+	if g, err := ReadGenesis(bc.db); err == nil {
+		for addr := range g.Alloc {
+			genesis[crypto.Keccak256Hash(addr.Bytes())] = addr
+		}
+	}
+	var (
+		start  = time.Now()
+		logged = time.Now()
+		seeded uint64
+	)
+	log.Info("Seeding Olivetum index from the state snapshot", "index", s.name)
+	for {
+		hashes, err := bc.olivetumIndexSeedBatch(progress.Next)
+		if err == nil && !bc.chainmu.TryLock() {
+			return false
+		}
+		var statedb *state.StateDB
+		if err == nil && s.stale.Swap(false) {
+			// The index was marked for reseeding meanwhile, start over.
+			progress, seeded = new(rawdb.OlivetumIndexSeed), 0
+			bc.chainmu.Unlock()
+			continue
+		}
+		if err == nil {
+			if statedb, err = bc.StateAt(bc.CurrentBlock().Root); err != nil {
+				bc.chainmu.Unlock()
+			}
+		}
+		if err != nil {
+			log.Debug("Olivetum index seeding delayed", "index", s.name, "err", err)
+			select {
+			case <-time.After(olivetumIndexSeedRetry):
+				continue
+			case <-bc.quit:
+				return false
+			}
+		}
+		head := bc.CurrentBlock()
+		batch := bc.db.NewBatch()
+		for _, hash := range hashes {
+			addr, ok := genesis[hash]
+			if !ok {
+				preimage := rawdb.ReadPreimage(bc.db, hash)
+				if len(preimage) != common.AddressLength {
+					progress.Missing++
+					continue
+				}
+				addr = common.BytesToAddress(preimage)
+			}
+			if addr != (common.Address{}) && !isOlivetumSystemAddress(addr) {
+				s.apply(batch, statedb, addr, head)
+			}
+		}
+		if len(hashes) < olivetumIndexSeedBatch {
+			progress.Done = true
+		} else {
+			progress.Next, progress.Done = nextOlivetumSeedHash(hashes[len(hashes)-1])
+		}
+		rawdb.WriteOlivetumIndexSeed(batch, s.name, progress)
+		if err := batch.Write(); err != nil {
+			log.Crit("Failed to write Olivetum index seed", "index", s.name, "err", err)
+		}
+		bc.chainmu.Unlock()
+
+		seeded += uint64(len(hashes))
+		if progress.Done {
+			s.complete.Store(progress.Missing == 0)
+			s.seeded.Store(true)
+			if progress.Missing > 0 {
+				log.Warn("Olivetum index seeded without the accounts lacking a preimage", "index", s.name, "accounts", seeded, "missing", progress.Missing)
+			} else {
+				log.Info("Seeded Olivetum index", "index", s.name, "accounts", seeded, "elapsed", common.PrettyDuration(time.Since(start)))
+			}
+			return true
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Seeding Olivetum index", "index", s.name, "accounts", seeded, "next", progress.Next)
+			logged = time.Now()
+		}
+	}
+}
+
+// olivetumIndexSeedBatch returns the hashes of up to olivetumIndexSeedBatch
+// accounts of the head state snapshot, starting at next.
+func (bc *BlockChain) olivetumIndexSeedBatch(next common.Hash) ([]common.Hash, error) {
+	it, err := bc.snaps.AccountIterator(bc.CurrentBlock().Root, next)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	var hashes []common.Hash
+	for len(hashes) < olivetumIndexSeedBatch && it.Next() {
+		hashes = append(hashes, it.Hash())
+	}
+	return hashes, it.Error()
+}
+
+// nextOlivetumSeedHash returns the hash following h, and true if h was the
+// last one.
+func nextOlivetumSeedHash(h common.Hash) (common.Hash, bool) {
+	for i := len(h) - 1; i >= 0; i-- {
+		h[i]++
+		if h[i] != 0 {
+			return h, false
+		}
+	}
+	return h, true
+}
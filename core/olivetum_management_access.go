@@ -27,6 +27,10 @@ func managementAdminFor(addr common.Address) (common.Address, bool) {
 		return params.OffSessionAdmin, true
 	case params.SessionTzContract:
 		return params.SessionTzAdmin, true
+	case params.SessionCalendarContract:
+		return params.SessionCalendarAdmin, true
+	case params.AddressListContract:
+		return params.AddressListAdmin, true
 	default:
 		return common.Address{}, false
 	}
@@ -41,3 +45,14 @@ func IsAuthorizedManagementTx(from common.Address, to common.Address) bool {
 	}
 	return true
 }
+
+// isManagementAdmin reports whether addr administers a management contract.
+func isManagementAdmin(addr common.Address) bool {
+	switch addr {
+	case BurnAdmin, DividendAdmin, params.GasLimitAdmin, params.PeriodAdmin, params.MinTxAmountAdmin,
+		params.TxRateLimitAdmin, params.OffSessionAdmin, params.SessionTzAdmin, params.SessionCalendarAdmin, params.AddressListAdmin:
+		return true
+	default:
+		return false
+	}
+}
@@ -21,6 +21,17 @@ func LoadMinTxAmount(s vm.StateDB) *big.Int {
 	return params.GetMinTxAmount()
 }
 
+// ReadMinTxAmount returns the stored minimum transaction amount without
+// updating the runtime value, falling back to the default minimum if none is
+// stored.
+func ReadMinTxAmount(s vm.StateDB) *big.Int {
+	stored := s.GetState(params.MinTxAmountContract, minTxAmountSlot).Big()
+	if stored.Sign() > 0 {
+		return stored
+	}
+	return new(big.Int).Set(params.MinTxAmountDefault)
+}
+
 // SetMinTxAmount writes the minimum transaction amount into state storage and
 // updates the runtime value used by validation.
 func SetMinTxAmount(s vm.StateDB, amount *big.Int) {
@@ -23,6 +23,16 @@ func LoadOffSessionTxRate(s vm.StateDB) uint64 {
 	return params.GetOffSessionTxRate()
 }
 
+// ReadOffSessionTxRate returns the stored off-session tx rate without updating
+// the runtime value, falling back to the default rate if none is stored.
+func ReadOffSessionTxRate(s vm.StateDB) uint64 {
+	stored := s.GetState(params.OffSessionTxRateContract, offSessionTxRateSlot).Big()
+	if stored.Sign() > 0 {
+		return stored.Uint64()
+	}
+	return params.OffSessionTxRateDefault
+}
+
 func SetOffSessionTxRate(s vm.StateDB, limit uint64) {
 	ensureMgmtAccountExists(s, params.OffSessionTxRateContract)
 	s.SetState(params.OffSessionTxRateContract, offSessionTxRateSlot, common.BigToHash(new(big.Int).SetUint64(limit)))
@@ -39,6 +49,16 @@ func LoadOffSessionMaxPerTx(s vm.StateDB) *big.Int {
 	return params.GetOffSessionMaxPerTx()
 }
 
+// ReadOffSessionMaxPerTx returns the stored off-session per-tx maximum without
+// updating the runtime value, falling back to the default value if none is stored.
+func ReadOffSessionMaxPerTx(s vm.StateDB) *big.Int {
+	stored := s.GetState(params.OffSessionMaxPerTxContract, offSessionMaxPerTxSlot).Big()
+	if stored.Sign() > 0 {
+		return stored
+	}
+	return new(big.Int).Set(params.OffSessionMaxPerTxDefault)
+}
+
 func SetOffSessionMaxPerTx(s vm.StateDB, amount *big.Int) {
 	ensureMgmtAccountExists(s, params.OffSessionMaxPerTxContract)
 	s.SetState(params.OffSessionMaxPerTxContract, offSessionMaxPerTxSlot, common.BigToHash(amount))
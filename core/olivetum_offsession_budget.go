@@ -3,33 +3,17 @@ package core
 import (
 	"encoding/binary"
 	"math/big"
-	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/params"
 )
 
+// offSessionBudgetWindow returns the key of the off-session budget window
+// containing ts: the local start of the current closed period of the session
+// calendar, or 0 while the session is open.
 func offSessionBudgetWindow(ts uint64) uint64 {
-	off := int64(params.GetSessionTzOffsetSeconds())
-	local := int64(ts) + off
-	if local < 0 {
-		return 0
-	}
-	t := time.Unix(local, 0).UTC()
-	if t.Weekday() != time.Sunday && t.Hour() >= 12 && t.Hour() < 24 {
-		return 0
-	}
-
-	day := int64(24 * time.Hour / time.Second)
-	window := (local / day) * day
-	if t.Weekday() == time.Monday && t.Hour() < 12 {
-		window -= day
-		if window < 0 {
-			window = 0
-		}
-	}
-	return uint64(window)
+	return CurrentSessionSchedule().BudgetWindow(ts)
 }
 
 func offSessionBudgetWindowSlot(addr common.Address) common.Hash {
@@ -101,3 +85,12 @@ func GetOffSessionBudgetSpent(s vm.StateDB, addr common.Address, now uint64) *bi
 	}
 	return getOffSessionBudgetSpent(s, addr)
 }
+
+// ReadOffSessionBudgetSpent is GetOffSessionBudgetSpent with the session
+// calendar stored in the state instead of the runtime one.
+func ReadOffSessionBudgetSpent(s vm.StateDB, addr common.Address, now uint64) *big.Int {
+	if getOffSessionBudgetWindow(s, addr) != ReadSessionSchedule(s).BudgetWindow(now) {
+		return new(big.Int)
+	}
+	return getOffSessionBudgetSpent(s, addr)
+}
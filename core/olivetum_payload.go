@@ -8,7 +8,7 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 )
 
-func ValidateOlivetumTxPayload(from common.Address, to common.Address, value *big.Int, data []byte, accessList types.AccessList, economyForkActive bool) error {
+func ValidateOlivetumTxPayload(from common.Address, to common.Address, value *big.Int, data []byte, accessList types.AccessList, economyForkActive bool, memoForkActive bool) error {
 	if !economyForkActive {
 		return nil
 	}
@@ -60,10 +60,49 @@ func ValidateOlivetumTxPayload(from common.Address, to common.Address, value *bi
 			return ErrTxDataLengthInvalid
 		}
 		return nil
-	default:
-		if len(data) != 0 {
-			return ErrTxDataNotAllowed
+	case params.SessionCalendarContract:
+		if value.Sign() != 0 {
+			return ErrTxValueNotAllowed
+		}
+		if len(data) == 0 || len(data) != params.SessionCalendarPayloadLength(data[0]) {
+			return ErrTxDataLengthInvalid
+		}
+		return nil
+	case params.AddressListContract:
+		if value.Sign() != 0 {
+			return ErrTxValueNotAllowed
+		}
+		if len(data) != params.AddressListPayloadLength {
+			return ErrTxDataLengthInvalid
 		}
 		return nil
+	default:
+		return validateTransferData(value, data, memoForkActive)
+	}
+}
+
+// validatePlainTxPayload checks the payload of a transaction to an address
+// that is not a management contract, ignoring the contract rules for to.
+func validatePlainTxPayload(value *big.Int, data []byte, accessList types.AccessList, economyForkActive bool, memoForkActive bool) error {
+	if !economyForkActive {
+		return nil
+	}
+	if len(accessList) > 0 {
+		return ErrTxAccessListNotAllowed
+	}
+	return validateTransferData(value, data, memoForkActive)
+}
+
+// validateTransferData checks the data of a plain transfer: empty, or a memo
+// once the memo fork is active.
+func validateTransferData(value *big.Int, data []byte, memoForkActive bool) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if memoForkActive && value.Sign() > 0 {
+		if _, ok := params.DecodeTransferMemo(data); ok {
+			return nil
+		}
 	}
+	return ErrTxDataNotAllowed
 }
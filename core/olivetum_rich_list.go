@@ -0,0 +1,193 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	ErrRichListDisabled    = errors.New("rich list index not enabled")
+	ErrRichListUnavailable = errors.New("rich list index not available")
+	ErrRichListPruned      = errors.New("block beyond rich list history")
+	ErrRichListIncomplete  = errors.New("rich list index incomplete, seeding from the state snapshot")
+)
+
+// DefaultSupplyExcluded returns the system accounts whose balances are not
+// counted as circulating supply: the reward vault, the burn admin and the
+// management contracts.
+func DefaultSupplyExcluded() []common.Address {
+	return []common.Address{
+		params.RewardVault,
+		BurnAdmin,
+		BurnContract,
+		DividendContract,
+		params.BatchTransferContract,
+		params.GasLimitContract,
+		params.PeriodContract,
+		params.MinTxAmountContract,
+		params.TxRateLimitContract,
+		params.OffSessionTxRateContract,
+		params.OffSessionMaxPerTxContract,
+		params.SessionTzContract,
+		params.SessionCalendarContract,
+		params.AddressListContract,
+	}
+}
+
+// writeOlivetumRichIndex applies a new canonical head to the rich list index,
+// which orders accounts by balance. Like the holder index it rolls back blocks
+// that are no longer ancestors of the head first, then reads the balances of
+// the accounts touched by the block from its state, and is reseeded if that
+// state is missing. The accounts left untouched
+// since the index was enabled are filled in by seeding it from the state
+// snapshot.
+func (bc *BlockChain) writeOlivetumRichIndex(db ethdb.KeyValueWriter, block *types.Block) {
+	hash, number := block.Hash(), block.NumberU64()
+	indexed, indexedNum, ok := rawdb.ReadOlivetumRichIndexHead(bc.db)
+	if ok && indexed == hash {
+		return
+	}
+	pending := make(map[common.Address]*big.Int)
+	if ok {
+		bc.rollbackOlivetumIndex(indexed, indexedNum, block, func(hash common.Hash, number uint64) bool {
+			undo, found := rawdb.ReadOlivetumRichUndo(bc.db, hash, number)
+			for _, u := range undo {
+				pending[u.Addr] = u.Prev
+			}
+			return found
+		})
+	}
+	touched := dividendTouchedAccounts(bc.chainConfig, block, rawdb.ReadOlivetumPayoutLogs(bc.db, hash, number))
+	statedb, err := bc.StateAt(block.Root())
+	if err != nil {
+		log.Warn("Olivetum rich list index missed block state, reseeding", "number", number, "hash", hash, "err", err)
+		bc.reseedOlivetumIndex(db, bc.olivetumRichSeed)
+	} else {
+		undo := make([]rawdb.OlivetumRichUndo, 0, len(touched))
+		for _, addr := range touched {
+			prev, rolledBack := pending[addr]
+			if !rolledBack {
+				prev = rawdb.ReadOlivetumRichBalance(bc.db, addr)
+			}
+			undo = append(undo, rawdb.OlivetumRichUndo{Addr: addr, Prev: prev})
+			pending[addr] = statedb.GetBalance(addr).ToBig()
+		}
+		rawdb.WriteOlivetumRichUndo(db, hash, number, undo)
+	}
+	bc.olivetumRichSeed.writePreimages(db, touched)
+	for addr, balance := range pending {
+		prev := rawdb.ReadOlivetumRichBalance(bc.db, addr)
+		switch {
+		case balance == nil || balance.Sign() == 0:
+			if prev != nil {
+				rawdb.DeleteOlivetumRichBalance(db, addr, prev)
+			}
+		case prev == nil || prev.Cmp(balance) != 0:
+			rawdb.WriteOlivetumRichBalance(db, addr, prev, balance)
+		}
+	}
+	rawdb.WriteOlivetumRichIndexHead(db, hash, number)
+	if number > olivetumIndexUndoDepth {
+		rawdb.DeleteOlivetumRichUndos(bc.db, number-olivetumIndexUndoDepth)
+	}
+}
+
+// seedOlivetumRichBalance brings the indexed balance of an account in line with
+// the head state.
+func (bc *BlockChain) seedOlivetumRichBalance(db ethdb.KeyValueWriter, statedb *state.StateDB, addr common.Address, head *types.Header) {
+	var (
+		balance = statedb.GetBalance(addr).ToBig()
+		prev    = rawdb.ReadOlivetumRichBalance(bc.db, addr)
+	)
+	switch {
+	case balance.Sign() == 0:
+		if prev != nil {
+			rawdb.DeleteOlivetumRichBalance(db, addr, prev)
+		}
+	case prev == nil || prev.Cmp(balance) != 0:
+		rawdb.WriteOlivetumRichBalance(db, addr, prev, balance)
+	}
+}
+
+// OlivetumRichList returns the limit accounts with the largest balances at the
+// given canonical block, in descending balance order. Blocks behind the index
+// head are served by replaying its rollback records, so they have to lie
+// within the last olivetumIndexUndoDepth blocks. Nothing is served until the
+// index has been seeded from the state snapshot.
+func (bc *BlockChain) OlivetumRichList(limit int, header *types.Header) ([]common.Address, []*big.Int, error) {
+	if !bc.cacheConfig.OlivetumRichIndex {
+		return nil, nil, ErrRichListDisabled
+	}
+	if !bc.olivetumRichSeed.Seeded() {
+		return nil, nil, ErrRichListIncomplete
+	}
+	hash, number, ok := rawdb.ReadOlivetumRichIndexHead(bc.db)
+	if !ok || number < header.Number.Uint64() {
+		return nil, nil, ErrRichListUnavailable
+	}
+	overrides := make(map[common.Address]*big.Int)
+	for number > header.Number.Uint64() {
+		undo, found := rawdb.ReadOlivetumRichUndo(bc.db, hash, number)
+		if !found {
+			return nil, nil, ErrRichListPruned
+		}
+		for _, u := range undo {
+			overrides[u.Addr] = u.Prev
+		}
+		parent := bc.GetHeader(hash, number)
+		if parent == nil {
+			return nil, nil, ErrRichListPruned
+		}
+		hash, number = parent.ParentHash, number-1
+	}
+	if hash != header.Hash() {
+		return nil, nil, ErrRichListUnavailable
+	}
+	type holder struct {
+		addr    common.Address
+		balance *big.Int
+	}
+	var holders []holder
+	rawdb.IterateOlivetumRichList(bc.db, func(addr common.Address, balance *big.Int) bool {
+		if _, ok := overrides[addr]; ok {
+			return true
+		}
+		holders = append(holders, holder{addr, balance})
+		return len(holders) < limit
+	})
+	for addr, balance := range overrides {
+		if balance != nil && balance.Sign() > 0 {
+			holders = append(holders, holder{addr, balance})
+		}
+	}
+	sort.Slice(holders, func(i, j int) bool {
+		if c := holders[i].balance.Cmp(holders[j].balance); c != 0 {
+			return c > 0
+		}
+		return holders[i].addr.Cmp(holders[j].addr) < 0
+	})
+	if len(holders) > limit {
+		holders = holders[:limit]
+	}
+	addrs, balances := make([]common.Address, len(holders)), make([]*big.Int, len(holders))
+	for i, h := range holders {
+		addrs[i], balances[i] = h.addr, h.balance
+	}
+	return addrs, balances, nil
+}
+
+// OlivetumRichListComplete reports whether the rich list index has been seeded
+// with every account of the state. Once seeded, accounts that could not be
+// resolved for lack of a preimage are only listed after they are touched.
+func (bc *BlockChain) OlivetumRichListComplete() bool {
+	return bc.olivetumRichSeed.Complete()
+}
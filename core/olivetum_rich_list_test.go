@@ -0,0 +1,161 @@
+package core_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/olivetumhash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+func TestOlivetumRichList(t *testing.T) {
+	genesisTime := uint64(time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC).Unix())
+	spenderKey, _ := crypto.GenerateKey()
+	spender := crypto.PubkeyToAddress(spenderKey.PublicKey)
+	claimer := common.HexToAddress("0x0000000000000000000000000000000000000011")
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000022")
+	minerA := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	minerB := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	spenderBal := new(big.Int).Mul(big.NewInt(10000), big.NewInt(vars.Ether))
+
+	genesis := olivetumTestGenesis(genesisTime, spender, spenderBal, claimer, big.NewInt(vars.Ether), 150, 50)
+	value := new(big.Int).Mul(big.NewInt(5000), big.NewInt(vars.Ether))
+	_, blocksA, _ := core.GenerateChainWithGenesis(genesis, olivetumhash.NewFaker(), 3, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(minerA)
+		if i != 1 {
+			return
+		}
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(spender), recipient, value, vars.TxGas, new(big.Int), nil), gen.Signer(), spenderKey)
+		if err != nil {
+			t.Fatalf("sign tx: %v", err)
+		}
+		gen.AddTx(tx)
+	})
+	_, blocksB, _ := core.GenerateChainWithGenesis(genesis, olivetumhash.NewFaker(), 4, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(minerB)
+	})
+	plain := olivetumNewBlockchain(t, genesis)
+	if _, _, err := plain.OlivetumRichList(1, plain.CurrentBlock()); !errors.Is(err, core.ErrRichListDisabled) {
+		t.Fatalf("rich list without the index: have %v, want %v", err, core.ErrRichListDisabled)
+	}
+	cacheConfig := core.DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.OlivetumRichIndex = true
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), cacheConfig, genesis, nil, olivetumhash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("new chain: %v", err)
+	}
+	defer chain.Stop()
+	olivetumWaitIndexSeed(t, chain.OlivetumRichListComplete)
+	if _, err := chain.InsertChain(blocksA); err != nil {
+		t.Fatalf("insert chain A: %v", err)
+	}
+	statedb, err := chain.State()
+	if err != nil {
+		t.Fatalf("state: %v", err)
+	}
+	check := func(header *types.Header, want ...common.Address) {
+		t.Helper()
+		addrs, balances, err := chain.OlivetumRichList(len(want), header)
+		if err != nil {
+			t.Fatalf("rich list at %d: %v", header.Number, err)
+		}
+		if len(addrs) != len(want) {
+			t.Fatalf("rich list at %d: have %v want %v", header.Number, addrs, want)
+		}
+		for i := range want {
+			if addrs[i] != want[i] {
+				t.Fatalf("rich list at %d, rank %d: have %v want %v", header.Number, i, addrs[i], want[i])
+			}
+			if i > 0 && balances[i].Cmp(balances[i-1]) > 0 {
+				t.Fatalf("rich list at %d not ordered: %v", header.Number, balances)
+			}
+		}
+	}
+	check(chain.CurrentBlock(), spender, recipient, minerA, claimer)
+	addrs, balances, _ := chain.OlivetumRichList(1, chain.CurrentBlock())
+	if balances[0].Cmp(statedb.GetBalance(addrs[0]).ToBig()) != 0 {
+		t.Fatalf("indexed balance %v, state balance %v", balances[0], statedb.GetBalance(addrs[0]))
+	}
+	// Before the transfer the recipient held nothing.
+	check(blocksA[0].Header(), spender, minerA, claimer)
+
+	// Reorg onto chain B without the transfer.
+	if _, err := chain.InsertChain(blocksB); err != nil {
+		t.Fatalf("insert chain B: %v", err)
+	}
+	check(chain.CurrentBlock(), spender, minerB, claimer)
+	if _, _, err := chain.OlivetumRichList(1, blocksA[2].Header()); err == nil {
+		t.Fatalf("rich list served for a reorged block")
+	}
+}
+
+func TestOlivetumRichListSeed(t *testing.T) {
+	genesisTime := uint64(time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC).Unix())
+	spenderKey, _ := crypto.GenerateKey()
+	spender := crypto.PubkeyToAddress(spenderKey.PublicKey)
+	claimer := common.HexToAddress("0x0000000000000000000000000000000000000011")
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000022")
+	miner := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	spenderBal := new(big.Int).Mul(big.NewInt(10000), big.NewInt(vars.Ether))
+
+	genesis := olivetumTestGenesis(genesisTime, spender, spenderBal, claimer, big.NewInt(vars.Ether), 150, 50)
+	value := new(big.Int).Mul(big.NewInt(5000), big.NewInt(vars.Ether))
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, olivetumhash.NewFaker(), 3, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(miner)
+		if i != 1 {
+			return
+		}
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(spender), recipient, value, vars.TxGas, new(big.Int), nil), gen.Signer(), spenderKey)
+		if err != nil {
+			t.Fatalf("sign tx: %v", err)
+		}
+		gen.AddTx(tx)
+	})
+	db := rawdb.NewMemoryDatabase()
+	cacheConfig := core.DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.Preimages = true
+	cacheConfig.OlivetumRichIndex = true
+	chain, err := core.NewBlockChain(db, cacheConfig, genesis, nil, olivetumhash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("new chain: %v", err)
+	}
+	olivetumWaitIndexSeed(t, chain.OlivetumRichListComplete)
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("insert chain: %v", err)
+	}
+	chain.Stop()
+
+	// Forget the recipient and the seeding, like a node synced before the
+	// index existed: reseeding restores it from the state snapshot.
+	rawdb.DeleteOlivetumRichBalance(db, recipient, rawdb.ReadOlivetumRichBalance(db, recipient))
+	rawdb.WriteOlivetumIndexSeed(db, "rich", new(rawdb.OlivetumIndexSeed))
+
+	chain, err = core.NewBlockChain(db, cacheConfig, genesis, nil, olivetumhash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("reopen chain: %v", err)
+	}
+	defer chain.Stop()
+	olivetumWaitIndexSeed(t, chain.OlivetumRichListComplete)
+
+	addrs, _, err := chain.OlivetumRichList(4, chain.CurrentBlock())
+	if err != nil {
+		t.Fatalf("rich list: %v", err)
+	}
+	want := []common.Address{spender, recipient, miner, claimer}
+	if len(addrs) != len(want) {
+		t.Fatalf("seeded rich list: have %v want %v", addrs, want)
+	}
+	for i := range want {
+		if addrs[i] != want[i] {
+			t.Fatalf("seeded rich list, rank %d: have %v want %v", i, addrs[i], want[i])
+		}
+	}
+}
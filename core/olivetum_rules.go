@@ -0,0 +1,282 @@
+package core
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// OlivetumRules are the Olivetum transaction rules of a block. The state
+// transition checks transactions against the rules of the block including
+// them and the transaction pool against the rules expected for the next
+// block, so both accept the same transactions.
+type OlivetumRules struct {
+	Number *big.Int // Number of the block including the transactions
+	Time   uint64   // Timestamp of the block including the transactions
+
+	EconomyFork     bool
+	MemoFork        bool
+	BatchFork       bool
+	CalendarFork    bool
+	AddressListFork bool
+
+	// Session reports whether the trading session is open at Time.
+	Session bool
+
+	MinTxAmount        *big.Int
+	OffSessionMaxPerTx *big.Int
+
+	// TxRateLimit is the hourly transaction limit of an account at Time,
+	// the off-session rate outside the session.
+	TxRateLimit uint64
+
+	// Classes resolves the address list classes of an account.
+	Classes func(common.Address) uint8
+}
+
+// NewOlivetumRules returns the rules of the block with the given number and
+// timestamp under the runtime parameters.
+func NewOlivetumRules(number *big.Int, time uint64, classes func(common.Address) uint8) *OlivetumRules {
+	r := &OlivetumRules{
+		Number:             number,
+		Time:               time,
+		EconomyFork:        isEconomyForkActive(number),
+		MemoFork:           params.TransferMemoFork.Active(number),
+		BatchFork:          params.BatchTransferFork.Active(number),
+		CalendarFork:       params.SessionCalendarFork.Active(number),
+		AddressListFork:    params.AddressListFork.Active(number),
+		Session:            IsSession(time),
+		MinTxAmount:        params.GetMinTxAmount(),
+		OffSessionMaxPerTx: params.GetOffSessionMaxPerTx(),
+		TxRateLimit:        params.GetTxRateLimit(),
+		Classes:            classes,
+	}
+	if !r.Session {
+		r.TxRateLimit = params.GetOffSessionTxRate()
+	}
+	return r
+}
+
+// PendingOlivetumTime returns the timestamp expected for the block after
+// head, one block period later. The worker stamps blocks with the wall clock,
+// which is often later: transactions admitted for the expected time that the
+// actual time no longer allows are left out by the worker and stay in the pool
+// until a block accepts them or the pool drops them.
+func PendingOlivetumTime(head *types.Header) uint64 {
+	return head.Time + params.GetBlockPeriod()
+}
+
+// PendingOlivetumRules returns the rules the transaction pool admits
+// transactions under: those of the block after head at its expected time,
+// with the runtime address classes.
+func PendingOlivetumRules(head *types.Header) *OlivetumRules {
+	number := new(big.Int)
+	if head.Number != nil {
+		number.Add(head.Number, common.Big1)
+	}
+	return NewOlivetumRules(number, PendingOlivetumTime(head), params.GetAddressClasses)
+}
+
+func (r *OlivetumRules) hasClass(addr common.Address, class uint8) bool {
+	return r.Classes(addr)&class != 0
+}
+
+// CheckParties checks the sender and the recipient of a transaction: contract
+// creations and self-transfers are disabled, management contracts only accept
+// their administrator and, once the address list is active, frozen accounts
+// can neither send nor receive.
+func (r *OlivetumRules) CheckParties(from common.Address, to *common.Address) error {
+	if to == nil {
+		return ErrContractCreationDisabled
+	}
+	if from == *to && !allowSelfTransfers {
+		return ErrSelfTransfer
+	}
+	if !r.IsAuthorizedManagementTx(from, *to) {
+		return ErrUnauthorizedManagementTx
+	}
+	if !r.AddressListFork {
+		return nil
+	}
+	if r.hasClass(from, params.AddressClassFrozen) {
+		return ErrAddressFrozen
+	}
+	if r.hasClass(*to, params.AddressClassFrozen) {
+		return ErrRecipientFrozen
+	}
+	return nil
+}
+
+// isManagementContract reports whether addr is a management contract under
+// the rules. Contracts introduced by a fork are plain accounts before it.
+func (r *OlivetumRules) isManagementContract(addr common.Address) bool {
+	switch addr {
+	case params.SessionCalendarContract:
+		return r.CalendarFork
+	case params.AddressListContract:
+		return r.AddressListFork
+	}
+	return true
+}
+
+// IsAuthorizedManagementTx reports whether from may send a transaction to to,
+// checking the administrator of the management contracts active under the
+// rules.
+func (r *OlivetumRules) IsAuthorizedManagementTx(from, to common.Address) bool {
+	if !r.isManagementContract(to) {
+		return true
+	}
+	return IsAuthorizedManagementTx(from, to)
+}
+
+// IsBatch reports whether a transaction to the given recipient is a batch
+// transfer.
+func (r *OlivetumRules) IsBatch(to common.Address) bool {
+	return r.BatchFork && to == params.BatchTransferContract
+}
+
+// TransferValue returns the amount a transaction to the given recipient moves
+// out of the balance of its sender: the entry total of a batch transfer, the
+// value otherwise.
+func (r *OlivetumRules) TransferValue(to *common.Address, value *big.Int, data []byte) *big.Int {
+	if to != nil && r.IsBatch(*to) {
+		if entries, ok := params.DecodeBatchTransfer(data); ok {
+			return BatchTransferTotal(entries)
+		}
+	}
+	return new(big.Int).Set(value)
+}
+
+// CheckTransfer checks the payload and the amount of a transaction and
+// returns the entries of a batch transfer, nil for other transactions.
+func (r *OlivetumRules) CheckTransfer(from, to common.Address, value *big.Int, data []byte, accessList types.AccessList) ([]params.BatchTransferEntry, error) {
+	var batch []params.BatchTransferEntry
+	if r.IsBatch(to) {
+		if err := ValidateBatchTransferPayload(value, data, accessList); err != nil {
+			return nil, err
+		}
+		batch, _ = params.DecodeBatchTransfer(data)
+		if err := CheckBatchTransferLimits(from, batch, r.Classes, r.Session, r.MinTxAmount, r.OffSessionMaxPerTx); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if r.isManagementContract(to) {
+			err = ValidateOlivetumTxPayload(from, to, value, data, accessList, r.EconomyFork, r.MemoFork)
+		} else {
+			err = validatePlainTxPayload(value, data, accessList, r.EconomyFork, r.MemoFork)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if value.Sign() >= 0 && value.Cmp(r.MinTxAmount) < 0 && !r.minAmountExempt(from, to) {
+			return nil, ErrUnderMinTxAmount
+		}
+	}
+	if r.offSessionLimited(from) && value.Cmp(r.OffSessionMaxPerTx) > 0 {
+		return nil, ErrOverMaxOffSession
+	}
+	return batch, nil
+}
+
+// minAmountExempt reports whether a transfer below the minimum amount is
+// allowed between from and to.
+func (r *OlivetumRules) minAmountExempt(from, to common.Address) bool {
+	if r.EconomyFork {
+		if params.IsMinTxAmountExemptSender(from) || params.IsMinTxAmountExemptRecipient(to) {
+			return true
+		}
+	} else if params.IsMinTxAmountExempt(from) || params.IsMinTxAmountExempt(to) {
+		return true
+	}
+	return r.hasClass(from, params.AddressClassMinAmountExempt) || r.hasClass(to, params.AddressClassMinAmountExempt)
+}
+
+// offSessionLimited reports whether transfers of from are subject to the
+// off-session limits.
+func (r *OlivetumRules) offSessionLimited(from common.Address) bool {
+	return !r.Session && !r.hasClass(from, params.AddressClassOffSessionExempt)
+}
+
+// OffSessionCharge returns the part of amount, moved out of the balance of
+// from, that is charged against its off-session budget.
+func (r *OlivetumRules) OffSessionCharge(from common.Address, amount *big.Int) *big.Int {
+	if !r.EconomyFork || !r.offSessionLimited(from) || amount.Sign() <= 0 {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(amount)
+}
+
+// ChargeOffSessionBudget charges a transfer of amount against the off-session
+// budget of from.
+func (r *OlivetumRules) ChargeOffSessionBudget(s vm.StateDB, from common.Address, amount *big.Int) error {
+	charge := r.OffSessionCharge(from, amount)
+	if charge.Sign() == 0 {
+		return nil
+	}
+	return UpdateOffSessionBudget(s, from, charge, r.Time)
+}
+
+// RateLimitExempt reports whether a transaction is not counted against the
+// hourly limit of its sender.
+func (r *OlivetumRules) RateLimitExempt(from, to common.Address, data []byte) bool {
+	if r.isManagementContract(to) && IsTxRateLimitExempt(from, to, data) {
+		return true
+	}
+	if from == params.TxRateLimitAdmin && !r.EconomyFork {
+		return true
+	}
+	return r.hasClass(from, params.AddressClassRateLimitExempt)
+}
+
+// TxAllowance returns the number of transactions addr may still send in its
+// current hourly window.
+func (r *OlivetumRules) TxAllowance(s vm.StateDB, addr common.Address) uint64 {
+	return txAllowance(s, addr, r.Time, r.TxRateLimit)
+}
+
+// ChargeTxRate counts a transaction of addr against its hourly limit.
+func (r *OlivetumRules) ChargeTxRate(s vm.StateDB, addr common.Address) error {
+	epoch := loadTxRateEpoch(s)
+	usage := GetTxRateUsage(s, addr)
+	if usage.Epoch != epoch || r.Time-usage.Start >= uint64(time.Hour/time.Second) {
+		usage = TxRateUsage{Start: r.Time, Epoch: epoch}
+	}
+	if usage.Count >= r.TxRateLimit {
+		return ErrRateLimit
+	}
+	usage.Count++
+	SetTxRateUsage(s, addr, usage)
+	return nil
+}
+
+// CheckDividendCall reports the calls of from to the dividend contract that
+// the state transition reverts: claims outside an open round or already paid
+// and rounds started by the administrator before the cooldown ends. The state
+// is left unchanged.
+func (r *OlivetumRules) CheckDividendCall(s vm.StateDB, from common.Address, value *big.Int, data []byte) error {
+	if value.Sign() != 0 {
+		return ErrDividendNotEligible
+	}
+	snap := s.Snapshot()
+	defer s.RevertToSnapshot(snap)
+
+	switch len(data) {
+	case 0:
+		if _, ok := ClaimDividend(s, from, r.Time); !ok {
+			return ErrDividendNotEligible
+		}
+	case 1:
+		if from != DividendAdmin {
+			return ErrUnauthorizedManagementTx
+		}
+		if _, ok := DecodeDividendRate(data); ok && !CanTriggerDividend(s, r.Time) {
+			return ErrDividendRoundTooSoon
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// OlivetumRuntime holds the runtime parameters an Olivetum block is executed
+// with while the management contracts store none. Unset fields take the
+// defaults of a fresh node.
+type OlivetumRuntime struct {
+	BlockPeriod        *math.HexOrDecimal64  `json:"blockPeriod,omitempty"`
+	MinTxAmount        *math.HexOrDecimal256 `json:"minTxAmount,omitempty"`
+	TxRateLimit        *math.HexOrDecimal64  `json:"txRateLimit,omitempty"`
+	OffSessionTxRate   *math.HexOrDecimal64  `json:"offSessionTxRate,omitempty"`
+	OffSessionMaxPerTx *math.HexOrDecimal256 `json:"offSessionMaxPerTx,omitempty"`
+	SessionTzOffset    *int32                `json:"sessionTzOffsetSeconds,omitempty"`
+	DividendRate       *math.HexOrDecimal64  `json:"dividendRate,omitempty"`
+}
+
+// ApplyOlivetumRuntime resets the runtime parameters to rt, or to the defaults
+// where rt leaves them unset, and then loads the values stored in state the way
+// block processing does. It prepares standalone executions of Olivetum
+// transactions, such as the t8n tool and the state tests; rt may be nil.
+func ApplyOlivetumRuntime(s vm.StateDB, rt *OlivetumRuntime) {
+	if rt == nil {
+		rt = new(OlivetumRuntime)
+	}
+	params.SetBlockPeriod(uint64OrDefault(rt.BlockPeriod, params.BlockPeriodDefault))
+	params.SetMinTxAmount(bigOrDefault(rt.MinTxAmount, params.MinTxAmountDefault))
+	params.SetTxRateLimit(uint64OrDefault(rt.TxRateLimit, params.TxRateLimitDefault))
+	params.SetOffSessionTxRate(uint64OrDefault(rt.OffSessionTxRate, params.OffSessionTxRateDefault))
+	params.SetOffSessionMaxPerTx(bigOrDefault(rt.OffSessionMaxPerTx, params.OffSessionMaxPerTxMax))
+	if rt.SessionTzOffset != nil {
+		params.SetSessionTzOffsetSeconds(*rt.SessionTzOffset)
+	} else {
+		params.SetSessionTzOffsetSeconds(0)
+	}
+	params.SetSessionCalendar(params.DefaultSessionCalendar())
+	SetDividendRate(uint64OrDefault(rt.DividendRate, dividendOptions[0]))
+
+	LoadOlivetumRuntime(s)
+	if rate := getRoundRate(s); rate != 0 {
+		SetDividendRate(rate)
+	}
+}
+
+// LoadOlivetumRuntime loads the runtime parameters stored in state, keeping the
+// current value of those it stores none of, and the administrator of the
+// management contracts. Blocks are executed with the parameters loaded from
+// their parent state.
+func LoadOlivetumRuntime(s vm.StateDB) {
+	LoadBlockPeriod(s)
+	LoadMinTxAmount(s)
+	LoadTxRateLimit(s)
+	LoadOffSessionTxRate(s)
+	LoadOffSessionMaxPerTx(s)
+	LoadSessionTzOffset(s)
+	LoadSessionCalendar(s)
+	LoadAddressList(s)
+	LoadManagementAdmin(s)
+}
+
+func uint64OrDefault(v *math.HexOrDecimal64, def uint64) uint64 {
+	if v == nil {
+		return def
+	}
+	return uint64(*v)
+}
+
+func bigOrDefault(v *math.HexOrDecimal256, def *big.Int) *big.Int {
+	if v == nil {
+		return new(big.Int).Set(def)
+	}
+	return new(big.Int).Set((*big.Int)(v))
+}
@@ -1,20 +1,108 @@
 package core
 
 import (
-	"time"
-
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/params"
 )
 
+// SessionPeriod describes the session period (open or closed) containing a
+// timestamp. Start and End are UTC Unix timestamps; End is the moment the
+// session state next changes.
+type SessionPeriod struct {
+	Open  bool
+	Start uint64
+	End   uint64
+}
+
+// SessionSchedule is the session calendar together with the time offset that
+// maps block timestamps onto it. It is the single evaluator behind IsSession,
+// the off-session budget windows and the session RPCs.
+type SessionSchedule struct {
+	Calendar params.SessionCalendar
+	TzOffset int32
+}
+
+// CurrentSessionSchedule returns the schedule mirrored in the runtime globals.
+func CurrentSessionSchedule() SessionSchedule {
+	return SessionSchedule{
+		Calendar: params.GetSessionCalendar(),
+		TzOffset: params.GetSessionTzOffsetSeconds(),
+	}
+}
+
+// ReadSessionSchedule returns the schedule stored in the given state without
+// touching the runtime globals.
+func ReadSessionSchedule(s vm.StateDB) SessionSchedule {
+	return SessionSchedule{
+		Calendar: ReadSessionCalendar(s),
+		TzOffset: ReadSessionTzOffset(s),
+	}
+}
+
+// localTime applies the session time offset to a block timestamp. It reports
+// false for instants before the local epoch.
+func (sc SessionSchedule) localTime(ts uint64) (uint64, bool) {
+	local := int64(ts) + int64(sc.TzOffset)
+	if local < 0 {
+		return 0, false
+	}
+	return uint64(local), true
+}
+
+// IsOpen reports whether the session is open at the given timestamp.
+func (sc SessionSchedule) IsOpen(ts uint64) bool {
+	local, ok := sc.localTime(ts)
+	if !ok {
+		return false
+	}
+	return sc.Calendar.IsOpenLocal(local)
+}
+
+// Period returns the session period containing the given timestamp.
+func (sc SessionSchedule) Period(ts uint64) SessionPeriod {
+	local, ok := sc.localTime(ts)
+	if !ok {
+		return SessionPeriod{}
+	}
+	open, start, end := sc.Calendar.PeriodLocal(local)
+	return SessionPeriod{
+		Open:  open,
+		Start: clampSessionTime(int64(start) - int64(sc.TzOffset)),
+		End:   clampSessionTime(int64(end) - int64(sc.TzOffset)),
+	}
+}
+
+// BudgetWindow returns the key of the off-session budget window containing
+// ts: the local start of the current closed period, or 0 while the session is
+// open.
+func (sc SessionSchedule) BudgetWindow(ts uint64) uint64 {
+	local, ok := sc.localTime(ts)
+	if !ok {
+		return 0
+	}
+	open, start, _ := sc.Calendar.PeriodLocal(local)
+	if open {
+		return 0
+	}
+	return start
+}
+
 // IsSession reports whether the given Unix timestamp (seconds), after applying
-// the configured session time offset, falls into the session window:
-// Monday–Saturday from 12:00 to 24:00 (local = UTC + offset). Sundays are closed.
+// the configured session time offset, falls into an open period of the
+// session calendar. By default the session runs Monday–Saturday from 12:00 to
+// 24:00 local time and Sundays are closed.
 func IsSession(ts uint64) bool {
-	off := int64(params.GetSessionTzOffsetSeconds())
-	t := time.Unix(int64(ts)+off, 0).UTC()
-	if t.Weekday() == time.Sunday {
-		return false
+	return CurrentSessionSchedule().IsOpen(ts)
+}
+
+// GetSessionPeriod returns the session period containing the given timestamp.
+func GetSessionPeriod(ts uint64) SessionPeriod {
+	return CurrentSessionSchedule().Period(ts)
+}
+
+func clampSessionTime(v int64) uint64 {
+	if v < 0 {
+		return 0
 	}
-	hour := t.Hour()
-	return hour >= 12 && hour < 24
+	return uint64(v)
 }
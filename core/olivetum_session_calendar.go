@@ -0,0 +1,107 @@
+package core
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Session calendar storage layout under params.SessionCalendarContract:
+//
+//	0x01..|weekday      configured flag, open and close hour of the weekday
+//	0x02                number of closed dates
+//	0x03..|index        closed date (local days since the Unix epoch)
+var sessionHolidayCountSlot = common.Hash{0: 0x02}
+
+const sessionHoursConfigured = 1 << 16
+
+func sessionHoursSlot(wd time.Weekday) common.Hash {
+	return common.Hash{0: 0x01, 31: byte(wd)}
+}
+
+func sessionHolidaySlot(idx uint64) common.Hash {
+	var b [32]byte
+	b[0] = 0x03
+	binary.BigEndian.PutUint64(b[24:], idx)
+	return common.BytesToHash(b[:])
+}
+
+func readSlotUint64(s vm.StateDB, addr common.Address, slot common.Hash) uint64 {
+	b := s.GetState(addr, slot)
+	return binary.BigEndian.Uint64(b[24:])
+}
+
+func writeSlotUint64(s vm.StateDB, addr common.Address, slot common.Hash, v uint64) {
+	var b [32]byte
+	binary.BigEndian.PutUint64(b[24:], v)
+	s.SetState(addr, slot, common.BytesToHash(b[:]))
+}
+
+// LoadSessionCalendar sets the current session calendar from state storage and
+// returns it. If the calendar was never configured, the existing one is kept.
+func LoadSessionCalendar(s vm.StateDB) params.SessionCalendar {
+	if s.GetNonce(params.SessionCalendarContract) == 0 {
+		return params.GetSessionCalendar()
+	}
+	cal := ReadSessionCalendar(s)
+	params.SetSessionCalendar(cal)
+	return cal
+}
+
+// ReadSessionCalendar returns the calendar stored in state without updating
+// the runtime value, falling back to the default calendar if none is stored.
+func ReadSessionCalendar(s vm.StateDB) params.SessionCalendar {
+	if s.GetNonce(params.SessionCalendarContract) == 0 {
+		return params.DefaultSessionCalendar()
+	}
+	var cal params.SessionCalendar
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		v := readSlotUint64(s, params.SessionCalendarContract, sessionHoursSlot(wd))
+		if v&sessionHoursConfigured == 0 {
+			cal.Hours[wd] = params.DefaultSessionCalendar().Hours[wd]
+			continue
+		}
+		cal.Hours[wd] = params.SessionHours{Open: uint8(v >> 8), Close: uint8(v)}
+	}
+	count := readSlotUint64(s, params.SessionCalendarContract, sessionHolidayCountSlot)
+	if count > params.SessionHolidaysMax {
+		count = params.SessionHolidaysMax
+	}
+	for i := uint64(0); i < count; i++ {
+		cal.Holidays = append(cal.Holidays, uint32(readSlotUint64(s, params.SessionCalendarContract, sessionHolidaySlot(i))))
+	}
+	return cal
+}
+
+// SetSessionCalendar writes the full calendar into state storage and updates
+// the runtime calendar used by session checks.
+func SetSessionCalendar(s vm.StateDB, cal params.SessionCalendar) {
+	ensureMgmtAccountExists(s, params.SessionCalendarContract)
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		h := cal.Hours[wd]
+		writeSlotUint64(s, params.SessionCalendarContract, sessionHoursSlot(wd), sessionHoursConfigured|uint64(h.Open)<<8|uint64(h.Close))
+	}
+	prev := readSlotUint64(s, params.SessionCalendarContract, sessionHolidayCountSlot)
+	for i, day := range cal.Holidays {
+		writeSlotUint64(s, params.SessionCalendarContract, sessionHolidaySlot(uint64(i)), uint64(day))
+	}
+	for i := uint64(len(cal.Holidays)); i < prev; i++ {
+		s.SetState(params.SessionCalendarContract, sessionHolidaySlot(i), common.Hash{})
+	}
+	writeSlotUint64(s, params.SessionCalendarContract, sessionHolidayCountSlot, uint64(len(cal.Holidays)))
+	params.SetSessionCalendar(cal)
+}
+
+// ApplySessionCalendarUpdate applies a decoded management payload to the
+// calendar stored in state. It reports false if the update was rejected.
+func ApplySessionCalendarUpdate(s vm.StateDB, u params.SessionCalendarUpdate) bool {
+	next, ok := LoadSessionCalendar(s).Apply(u)
+	if !ok {
+		return false
+	}
+	SetSessionCalendar(s, next)
+	return true
+}
@@ -0,0 +1,239 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func legacyIsSession(ts uint64) bool {
+	t := time.Unix(int64(ts), 0).UTC()
+	if t.Weekday() == time.Sunday {
+		return false
+	}
+	return t.Hour() >= 12 && t.Hour() < 24
+}
+
+func legacyOffSessionBudgetWindow(ts uint64) uint64 {
+	t := time.Unix(int64(ts), 0).UTC()
+	if t.Weekday() != time.Sunday && t.Hour() >= 12 && t.Hour() < 24 {
+		return 0
+	}
+	day := int64(24 * time.Hour / time.Second)
+	window := (int64(ts) / day) * day
+	if t.Weekday() == time.Monday && t.Hour() < 12 {
+		window -= day
+	}
+	return uint64(window)
+}
+
+func useSessionCalendar(t *testing.T, cal params.SessionCalendar) {
+	t.Helper()
+	oldCal := params.GetSessionCalendar()
+	oldTz := params.GetSessionTzOffsetSeconds()
+	t.Cleanup(func() {
+		params.SetSessionCalendar(oldCal)
+		params.SetSessionTzOffsetSeconds(oldTz)
+	})
+	params.SetSessionCalendar(cal)
+	params.SetSessionTzOffsetSeconds(0)
+}
+
+func useSessionCalendarFork(t *testing.T, block *big.Int) {
+	t.Helper()
+	old := params.SessionCalendarFork.Block()
+	t.Cleanup(func() { params.SessionCalendarFork.SetBlock(old) })
+	params.SessionCalendarFork.SetBlock(block)
+}
+
+func TestSessionCalendarDefaultMatchesLegacySchedule(t *testing.T) {
+	useSessionCalendar(t, params.DefaultSessionCalendar())
+
+	start := uint64(time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC).Unix())
+	for ts := start; ts < start+9*24*3600; ts += 1800 {
+		if got, want := IsSession(ts), legacyIsSession(ts); got != want {
+			t.Fatalf("IsSession(%s) = %v, want %v", time.Unix(int64(ts), 0).UTC(), got, want)
+		}
+		if got, want := offSessionBudgetWindow(ts), legacyOffSessionBudgetWindow(ts); got != want {
+			t.Fatalf("offSessionBudgetWindow(%s) = %d, want %d", time.Unix(int64(ts), 0).UTC(), got, want)
+		}
+	}
+}
+
+func TestSessionCalendarHolidayClosesSession(t *testing.T) {
+	cal := params.DefaultSessionCalendar()
+	upd, ok := params.DecodeSessionCalendarUpdate([]byte{params.SessionCalendarOpAddHoliday, 0x07, 0xe8, 3, 5})
+	if !ok {
+		t.Fatalf("failed to decode holiday payload")
+	}
+	cal, ok = cal.Apply(upd)
+	if !ok {
+		t.Fatalf("failed to add holiday")
+	}
+	useSessionCalendar(t, cal)
+
+	// Tuesday 2024-03-05 is closed, so the off-session period runs from
+	// Tuesday 00:00 until Wednesday 12:00.
+	ts := uint64(time.Date(2024, time.March, 5, 13, 0, 0, 0, time.UTC).Unix())
+	if IsSession(ts) {
+		t.Fatalf("expected holiday to be off-session")
+	}
+	period := GetSessionPeriod(ts)
+	wantStart := uint64(time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC).Unix())
+	wantEnd := uint64(time.Date(2024, time.March, 6, 12, 0, 0, 0, time.UTC).Unix())
+	if period.Open || period.Start != wantStart || period.End != wantEnd {
+		t.Fatalf("period mismatch: got %+v, want closed [%d, %d)", period, wantStart, wantEnd)
+	}
+	if window := offSessionBudgetWindow(ts); window != wantStart {
+		t.Fatalf("window mismatch: got %d want %d", window, wantStart)
+	}
+	// Monday evening is still in session and ends at the holiday.
+	monday := uint64(time.Date(2024, time.March, 4, 13, 0, 0, 0, time.UTC).Unix())
+	if period := GetSessionPeriod(monday); !period.Open || period.End != wantStart {
+		t.Fatalf("monday period mismatch: %+v", period)
+	}
+}
+
+func TestSessionCalendarDecodeRejectsInvalidPayloads(t *testing.T) {
+	for _, data := range [][]byte{
+		nil,
+		{0x05},
+		{params.SessionCalendarOpSetHours, 7, 12, 24},
+		{params.SessionCalendarOpSetHours, 1, 12, 12},
+		{params.SessionCalendarOpSetHours, 1, 12, 25},
+		{params.SessionCalendarOpAddHoliday, 0x07, 0xe8, 2, 30},
+		{params.SessionCalendarOpRemoveHoliday, 0x07, 0xe8, 13, 1},
+		{params.SessionCalendarOpReset, 0x00},
+	} {
+		if _, ok := params.DecodeSessionCalendarUpdate(data); ok {
+			t.Fatalf("expected payload %x to be rejected", data)
+		}
+	}
+}
+
+func TestSessionCalendarRejectsClosingLastOpenWeekday(t *testing.T) {
+	cal := params.DefaultSessionCalendar()
+	closeDay := func(wd time.Weekday) bool {
+		upd, ok := params.DecodeSessionCalendarUpdate([]byte{params.SessionCalendarOpSetHours, byte(wd), 0, 0})
+		if !ok {
+			t.Fatalf("failed to decode closing payload")
+		}
+		next, ok := cal.Apply(upd)
+		if ok {
+			cal = next
+		}
+		return ok
+	}
+	for wd := time.Monday; wd < time.Saturday; wd++ {
+		if !closeDay(wd) {
+			t.Fatalf("failed to close %v", wd)
+		}
+	}
+	if closeDay(time.Saturday) {
+		t.Fatalf("closed the last open weekday")
+	}
+	if cal.IsAllClosed() || cal.Hours[time.Saturday].IsClosed() {
+		t.Fatalf("expected saturday to stay open, got %+v", cal.Hours)
+	}
+}
+
+func TestSessionCalendarAllClosedKeepsBudgetWindow(t *testing.T) {
+	// A calendar without open weekdays has a single closed period, so the
+	// off-session budget window must not move from one day to the next.
+	useSessionCalendar(t, params.SessionCalendar{})
+
+	first := uint64(time.Date(2024, time.March, 4, 13, 0, 0, 0, time.UTC).Unix())
+	for _, ts := range []uint64{first, first + 24*3600, first + 30*24*3600} {
+		if IsSession(ts) {
+			t.Fatalf("expected %d to be off-session", ts)
+		}
+		if period := GetSessionPeriod(ts); period.Start != 0 {
+			t.Fatalf("period of %d starts at %d, want 0", ts, period.Start)
+		}
+		if window := offSessionBudgetWindow(ts); window != 0 {
+			t.Fatalf("budget window of %d is %d, want 0", ts, window)
+		}
+	}
+}
+
+func TestStateTransitionUpdatesSessionCalendar(t *testing.T) {
+	useSessionCalendar(t, params.DefaultSessionCalendar())
+	useSessionCalendarFork(t, big.NewInt(1))
+
+	admin := params.SessionCalendarAdmin
+	target := params.SessionCalendarContract
+	ts := uint64(time.Date(2024, time.March, 4, 13, 0, 0, 0, time.UTC).Unix())
+	evm, statedb, gp := newOlivetumEnv(t, ts)
+	fundAccount(statedb, admin, etherBig(1000))
+
+	send := func(nonce uint64, data []byte) {
+		t.Helper()
+		msg := Message{
+			From:      admin,
+			To:        &target,
+			Value:     new(big.Int),
+			GasLimit:  30000,
+			GasPrice:  big.NewInt(1),
+			GasFeeCap: big.NewInt(1),
+			GasTipCap: big.NewInt(1),
+			Nonce:     nonce,
+			Data:      data,
+		}
+		res, err := NewStateTransition(evm, &msg, gp).TransitionDb()
+		if err != nil {
+			t.Fatalf("calendar update %x failed: %v", data, err)
+		}
+		if res.Failed() {
+			t.Fatalf("calendar update %x reverted: %v", data, res.Err)
+		}
+	}
+	// Open Sundays 08:00-16:00 and close 2024-03-04.
+	send(0, []byte{params.SessionCalendarOpSetHours, byte(time.Sunday), 8, 16})
+	send(1, []byte{params.SessionCalendarOpAddHoliday, 0x07, 0xe8, 3, 4})
+
+	params.SetSessionCalendar(params.DefaultSessionCalendar())
+	cal := LoadSessionCalendar(statedb)
+	if cal.Hours[time.Sunday] != (params.SessionHours{Open: 8, Close: 16}) {
+		t.Fatalf("sunday hours mismatch: %+v", cal.Hours[time.Sunday])
+	}
+	if cal.Hours[time.Monday] != (params.SessionHours{Open: 12, Close: 24}) {
+		t.Fatalf("monday hours mismatch: %+v", cal.Hours[time.Monday])
+	}
+	if len(cal.Holidays) != 1 || IsSession(ts) {
+		t.Fatalf("expected holiday to be loaded, got %v", cal.Holidays)
+	}
+	if !IsSession(uint64(time.Date(2024, time.March, 3, 9, 0, 0, 0, time.UTC).Unix())) {
+		t.Fatalf("expected sunday morning to be in session")
+	}
+
+	send(2, []byte{params.SessionCalendarOpReset})
+	if cal := LoadSessionCalendar(statedb); len(cal.Holidays) != 0 || !cal.Hours[time.Sunday].IsClosed() {
+		t.Fatalf("expected default calendar after reset, got %+v", cal)
+	}
+}
+
+func TestSessionCalendarContractForkGated(t *testing.T) {
+	useSessionCalendarFork(t, big.NewInt(10))
+
+	var (
+		user     = common.HexToAddress("0x1234")
+		calendar = params.SessionCalendarContract
+		classes  = func(common.Address) uint8 { return 0 }
+	)
+	before := NewOlivetumRules(big.NewInt(9), 0, classes)
+	before.EconomyFork = true
+	if err := before.CheckParties(user, &calendar); err != nil {
+		t.Fatalf("transfer before the fork rejected: %v", err)
+	}
+	if before.RateLimitExempt(params.SessionCalendarAdmin, calendar, nil) {
+		t.Fatalf("admin transfer before the fork exempt from the rate limit")
+	}
+	after := NewOlivetumRules(big.NewInt(10), 0, classes)
+	if err := after.CheckParties(user, &calendar); !errors.Is(err, ErrUnauthorizedManagementTx) {
+		t.Fatalf("transfer after the fork: have %v, want %v", err, ErrUnauthorizedManagementTx)
+	}
+}
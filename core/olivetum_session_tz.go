@@ -20,6 +20,17 @@ func LoadSessionTzOffset(s vm.StateDB) int32 {
 	return params.GetSessionTzOffsetSeconds()
 }
 
+// ReadSessionTzOffset returns the stored session time offset without updating
+// the runtime value, falling back to the default offset (UTC) if none is
+// stored.
+func ReadSessionTzOffset(s vm.StateDB) int32 {
+	stored := s.GetState(params.SessionTzContract, sessionTzSlot).Big()
+	if stored.Sign() != 0 {
+		return int32(stored.Uint64())
+	}
+	return 0
+}
+
 func SetSessionTzOffset(s vm.StateDB, offset int32) {
 	ensureMgmtAccountExists(s, params.SessionTzContract)
 	s.SetState(params.SessionTzContract, sessionTzSlot, common.BigToHash(new(big.Int).SetUint64(uint64(uint32(offset)))))
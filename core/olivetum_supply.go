@@ -15,6 +15,7 @@ var (
 	totalBurnedTransfersSlot = common.Hash{0: 0x0f}
 	totalBurnedGasSlot       = common.Hash{0: 0x10}
 	totalMinerBurnShareSlot  = common.Hash{0: 0x11}
+	totalBurnedRewardsSlot   = common.Hash{0: 0x12}
 )
 
 // GetTotalMinted reads the total minted amount tracked in state. Returns 0 if
@@ -155,6 +156,32 @@ func AddTotalDividendsMinted(state vm.StateDB, amount *big.Int) {
 	SetTotalDividendsMinted(state, total)
 }
 
+// GetTotalBurnedRewards reads the cumulative amount burned from block rewards
+// since the reward burn fork. These burns are also part of the total burned.
+func GetTotalBurnedRewards(state vm.StateDB) *big.Int {
+	if state == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).SetBytes(state.GetState(params.RewardVault, totalBurnedRewardsSlot).Bytes())
+}
+
+func SetTotalBurnedRewards(state vm.StateDB, amount *big.Int) {
+	if state == nil {
+		return
+	}
+	ensureRewardVaultAccount(state)
+	state.SetState(params.RewardVault, totalBurnedRewardsSlot, common.BigToHash(amount))
+}
+
+func AddTotalBurnedRewards(state vm.StateDB, amount *big.Int) {
+	if state == nil || amount == nil || amount.Sign() == 0 {
+		return
+	}
+	total := GetTotalBurnedRewards(state)
+	total.Add(total, amount)
+	SetTotalBurnedRewards(state, total)
+}
+
 func ensureRewardVaultAccount(state vm.StateDB) {
 	if state.GetNonce(params.RewardVault) == 0 {
 		state.SetNonce(params.RewardVault, 1)
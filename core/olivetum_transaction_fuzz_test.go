@@ -0,0 +1,373 @@
+package core_test
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/olivetumhash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/vars"
+	"github.com/holiman/uint256"
+)
+
+// olivetumFuzzOpLength is the size of one fuzzed transaction: sender,
+// recipient, value mantissa and exponent, time step, flags and payload length.
+const olivetumFuzzOpLength = 7
+
+// olivetumFuzzInput consumes the fuzzer input, yielding zeroes once it is
+// exhausted.
+type olivetumFuzzInput []byte
+
+func (in *olivetumFuzzInput) byte() byte {
+	if len(*in) == 0 {
+		return 0
+	}
+	b := (*in)[0]
+	*in = (*in)[1:]
+	return b
+}
+
+func (in *olivetumFuzzInput) bytes(n int) []byte {
+	n = min(n, len(*in))
+	b := (*in)[:n]
+	*in = (*in)[n:]
+	return b
+}
+
+// olivetumFuzzChain executes one transaction per block against an in-memory
+// Olivetum state, finalizing every block with the olivetumhash rules.
+type olivetumFuzzChain struct {
+	statedb  *state.StateDB
+	config   ctypes.ChainConfigurator
+	signer   types.Signer
+	engine   *olivetumhash.Olivetumhash
+	accounts []common.Address // accounts checked after every block
+	coinbase common.Address
+	number   uint64
+	time     uint64
+}
+
+// olivetumFuzzSnapshot holds the counters that must never decrease.
+type olivetumFuzzSnapshot struct {
+	minted, burned, dividends *big.Int
+	epoch, round              uint64
+	nonces                    []uint64
+	usage                     []core.TxRateUsage
+}
+
+func (c *olivetumFuzzChain) snapshot() olivetumFuzzSnapshot {
+	snap := olivetumFuzzSnapshot{
+		minted:    core.GetTotalMinted(c.statedb),
+		burned:    core.GetTotalBurned(c.statedb),
+		dividends: core.GetTotalDividendsMinted(c.statedb),
+		epoch:     core.GetTxRateEpoch(c.statedb),
+		round:     core.GetDividendStatus(c.statedb, common.Address{}).Start,
+	}
+	for _, addr := range c.accounts {
+		snap.nonces = append(snap.nonces, c.statedb.GetNonce(addr))
+		snap.usage = append(snap.usage, core.GetTxRateUsage(c.statedb, addr))
+	}
+	return snap
+}
+
+// checkInvariants verifies the state after a block against the snapshot taken
+// before it.
+func (c *olivetumFuzzChain) checkInvariants(t *testing.T, prev olivetumFuzzSnapshot) olivetumFuzzSnapshot {
+	t.Helper()
+
+	next := c.snapshot()
+	if next.minted.Cmp(params.MaxSupply()) > 0 {
+		t.Fatalf("block %d: total minted %v above max supply", c.number, next.minted)
+	}
+	for _, counter := range []struct {
+		name       string
+		prev, next *big.Int
+	}{
+		{"total minted", prev.minted, next.minted},
+		{"total burned", prev.burned, next.burned},
+		{"total dividends", prev.dividends, next.dividends},
+	} {
+		if counter.next.Cmp(counter.prev) < 0 {
+			t.Fatalf("block %d: %s decreased from %v to %v", c.number, counter.name, counter.prev, counter.next)
+		}
+	}
+	if next.epoch < prev.epoch {
+		t.Fatalf("block %d: rate limit epoch decreased from %d to %d", c.number, prev.epoch, next.epoch)
+	}
+	if next.round < prev.round {
+		t.Fatalf("block %d: dividend round start decreased from %d to %d", c.number, prev.round, next.round)
+	}
+	for i, addr := range c.accounts {
+		if next.nonces[i] < prev.nonces[i] {
+			t.Fatalf("block %d: nonce of %x decreased", c.number, addr)
+		}
+		p, n := prev.usage[i], next.usage[i]
+		if p.Start == n.Start && p.Epoch == n.Epoch && n.Count < p.Count {
+			t.Fatalf("block %d: rate limit count of %x decreased from %d to %d", c.number, addr, p.Count, n.Count)
+		}
+		view := core.GetDividendView(c.statedb, addr, c.time)
+		if view.EligibleNow.Sign() < 0 || view.Pending.Sign() < 0 {
+			t.Fatalf("block %d: negative holding of %x", c.number, addr)
+		}
+		held := new(big.Int).Add(view.EligibleNow, view.Pending)
+		if balance := c.statedb.GetBalance(addr).ToBig(); held.Cmp(balance) > 0 {
+			t.Fatalf("block %d: holding %v of %x above balance %v", c.number, held, addr, balance)
+		}
+	}
+	return next
+}
+
+// apply validates tx against the pool rules, then executes it in a new block
+// the way the miner does, reverting it if it is invalid. The pool judges the
+// session at the head time, so the head is given the time of the new block to
+// compare both under the same session.
+func (c *olivetumFuzzChain) apply(t *testing.T, tx *types.Transaction, step uint64) {
+	t.Helper()
+
+	c.number++
+	c.time += step
+	core.LoadOlivetumRuntime(c.statedb)
+
+	head := &types.Header{Number: new(big.Int).SetUint64(c.number - 1), Time: c.time, GasLimit: 15_000_000}
+	poolErr := txpool.ValidateTransaction(tx, head, c.signer, &txpool.ValidationOptions{
+		Config:  c.config,
+		Accept:  1 << types.AccessListTxType,
+		MaxSize: 128 * 1024,
+		MinTip:  new(big.Int),
+	})
+
+	header := &types.Header{
+		Number:     new(big.Int).SetUint64(c.number),
+		Time:       c.time,
+		GasLimit:   15_000_000,
+		Difficulty: big.NewInt(1),
+		Coinbase:   c.coinbase,
+	}
+	var (
+		evm  = vm.NewEVM(core.NewEVMBlockContext(header, nil, &c.coinbase), vm.TxContext{}, c.statedb, c.config, vm.Config{})
+		snap = c.statedb.Snapshot()
+	)
+	msg, err := core.TransactionToMessage(tx, c.signer, nil)
+	if err != nil {
+		t.Fatalf("block %d: invalid message: %v", c.number, err)
+	}
+	c.statedb.SetTxContext(tx.Hash(), 0)
+	evm.Reset(core.NewEVMTxContext(msg), c.statedb)
+	_, execErr := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(header.GasLimit))
+	if execErr != nil {
+		c.statedb.RevertToSnapshot(snap)
+	}
+	c.statedb.Finalise(true)
+
+	var txs []*types.Transaction
+	if execErr == nil {
+		txs = append(txs, tx)
+	}
+	c.engine.Finalize(nil, header, c.statedb, txs, nil, nil)
+	c.statedb.Finalise(true)
+
+	switch {
+	case poolErr != nil && execErr == nil:
+		t.Fatalf("block %d: transaction rejected by the pool executed: %v", c.number, poolErr)
+	case poolErr == nil && execErr != nil && !isOlivetumStatefulError(execErr):
+		t.Fatalf("block %d: transaction accepted by the pool failed: %v", c.number, execErr)
+	}
+}
+
+// isOlivetumStatefulError reports whether err is a rejection depending on the
+// state of the sender, which the stateless pool validation does not check.
+func isOlivetumStatefulError(err error) bool {
+	return errors.Is(err, core.ErrRateLimit) ||
+		errors.Is(err, core.ErrOverMaxOffSessionBudget) ||
+		errors.Is(err, core.ErrInsufficientFundsForTransfer) ||
+		errors.Is(err, core.ErrInsufficientFunds)
+}
+
+// FuzzOlivetumTransactions executes random transaction sequences against an
+// in-memory Olivetum state. After every block it checks that no holding is
+// negative or above the balance, that the supply and rate limit counters never
+// decrease, that the total minted stays within the max supply, and that the
+// stateless pool validation agrees with execution.
+func FuzzOlivetumTransactions(f *testing.F) {
+	var (
+		keys     []*ecdsa.PrivateKey
+		accounts []common.Address
+	)
+	for i := 1; i <= 4; i++ {
+		key, _ := crypto.ToECDSA(common.LeftPadBytes([]byte{byte(i)}, 32))
+		keys = append(keys, key)
+		accounts = append(accounts, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	oldEconomy, oldMemo, oldBatch := params.GetEconomyForkBlock(), params.TransferMemoFork.Block(), params.BatchTransferFork.Block()
+	oldCalendar, oldList := params.SessionCalendarFork.Block(), params.AddressListFork.Block()
+	oldBuckets, oldAuto, oldGasLimit := params.DividendBucketFork.Block(), params.DividendAutoFork.Block(), params.GetGasLimit()
+	f.Cleanup(func() {
+		params.SetEconomyForkBlock(oldEconomy)
+		params.TransferMemoFork.SetBlock(oldMemo)
+		params.BatchTransferFork.SetBlock(oldBatch)
+		params.SessionCalendarFork.SetBlock(oldCalendar)
+		params.AddressListFork.SetBlock(oldList)
+		params.DividendBucketFork.SetBlock(oldBuckets)
+		params.DividendAutoFork.SetBlock(oldAuto)
+		params.SetGasLimit(oldGasLimit)
+
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		core.ApplyOlivetumRuntime(statedb, nil)
+	})
+	params.SetEconomyForkBlock(big.NewInt(1))
+	params.TransferMemoFork.SetBlock(big.NewInt(1))
+	params.BatchTransferFork.SetBlock(big.NewInt(1))
+	params.SessionCalendarFork.SetBlock(big.NewInt(1))
+	params.AddressListFork.SetBlock(big.NewInt(1))
+
+	// Plain transfers in and off the session, a burn rate update, a dividend
+	// round with a claim and a batch transfer
+	f.Add(uint8(0), uint64(0), []byte{
+		1, 2, 50, 18, 0, 0, 0,
+		2, 3, 20, 18, 5, 0, 0,
+		0, 6, 0, 0, 1, 0, 1, 2,
+		0, 5, 0, 0, 1, 0, 1, 0,
+		1, 5, 0, 0, 1, 0, 0,
+		3, 16, 15, 18, 2, 0x80, 2, 1, 2,
+	})
+	// Management updates near the supply cap
+	f.Add(uint8(3), uint64(1_000_000_000), []byte{
+		0, 9, 0, 0, 0, 0, 8, 0, 0, 0, 0, 0, 0, 0x03, 0xe8,
+		0, 10, 0, 0, 0, 0, 1, 1,
+		1, 2, 200, 18, 0, 0, 0,
+		2, 1, 12, 18, 0, 0, 0,
+	})
+	// Rejected transactions: self-transfer, access list, creation, calldata
+	f.Add(uint8(0), uint64(0), []byte{
+		1, 1, 50, 18, 0, 0, 0,
+		1, 2, 50, 18, 0, 0x01, 0,
+		1, 17, 50, 18, 0, 0, 0,
+		1, 2, 50, 18, 0, 0, 3, 1, 2, 3,
+		1, 2, 50, 18, 0, 0x40, 3, 'r', 'e', 'f',
+	})
+
+	f.Fuzz(func(t *testing.T, forks uint8, remaining uint64, data []byte) {
+		var (
+			genesisTime = uint64(time.Date(2024, time.January, 29, 12, 0, 0, 0, time.UTC).Unix())
+			balance     = new(big.Int).Mul(big.NewInt(1_000_000), big.NewInt(vars.Ether))
+			in          = olivetumFuzzInput(data)
+		)
+		params.DividendBucketFork.SetBlock(new(big.Int).SetUint64(uint64(forks & 0x01)))
+		params.DividendAutoFork.SetBlock(new(big.Int).SetUint64(uint64(forks & 0x02 >> 1)))
+
+		statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		if err != nil {
+			t.Fatalf("new state: %v", err)
+		}
+		core.SetManagementAdmin(statedb, accounts[0])
+		core.ApplyOlivetumRuntime(statedb, nil)
+		for _, addr := range accounts {
+			statedb.AddBalance(addr, uint256.MustFromBig(balance))
+			core.AddHolding(statedb, addr, balance, genesisTime)
+		}
+		// Start at most remaining gwei below the max supply
+		if cap := new(big.Int).Mul(new(big.Int).SetUint64(remaining), big.NewInt(vars.GWei)); remaining > 0 && cap.Cmp(params.MaxSupply()) < 0 {
+			core.SetTotalMinted(statedb, new(big.Int).Sub(params.MaxSupply(), cap))
+		}
+		statedb.Finalise(true)
+
+		coinbase := common.HexToAddress("0x000000000000000000000000000000000000c0fe")
+		chain := &olivetumFuzzChain{
+			statedb:  statedb,
+			config:   core.NewOlivetumTestGenesis(genesisTime).Genesis().Config,
+			signer:   types.LatestSignerForChainID(big.NewInt(30216931)),
+			engine:   olivetumhash.NewFaker(),
+			accounts: append(append([]common.Address{}, accounts...), coinbase),
+			coinbase: coinbase,
+			// Genesis holdings qualify for dividends after 30 days
+			time: genesisTime + 31*24*60*60,
+		}
+		targets := append(append([]common.Address{}, accounts...),
+			chain.coinbase,
+			core.DividendContract,
+			core.BurnContract,
+			params.GasLimitContract,
+			params.PeriodContract,
+			params.MinTxAmountContract,
+			params.TxRateLimitContract,
+			params.OffSessionTxRateContract,
+			params.OffSessionMaxPerTxContract,
+			params.SessionTzContract,
+			params.SessionCalendarContract,
+			params.AddressListContract,
+			params.BatchTransferContract,
+		)
+		prev := chain.snapshot()
+		for blocks := 0; len(in) >= olivetumFuzzOpLength && blocks < 64; blocks++ {
+			var (
+				key      = keys[int(in.byte())%len(keys)]
+				from     = crypto.PubkeyToAddress(key.PublicKey)
+				toIndex  = int(in.byte()) % (len(targets) + 1)
+				mantissa = in.byte()
+				exponent = in.byte() % 24
+				step     = uint64(in.byte())
+				flags    = in.byte()
+				payload  = append([]byte{}, in.bytes(int(in.byte()%64))...)
+			)
+			value := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+			value.Mul(value, big.NewInt(int64(mantissa)))
+
+			var to *common.Address
+			if toIndex < len(targets) {
+				to = &targets[toIndex]
+			}
+			switch {
+			case flags&0x80 != 0 && to != nil && *to == params.BatchTransferContract:
+				// Pay the value to the accounts picked by the payload bytes
+				var batch []byte
+				for _, b := range payload {
+					batch = append(batch, accounts[int(b)%len(accounts)].Bytes()...)
+					batch = append(batch, common.BigToHash(value).Bytes()...)
+				}
+				payload, value = batch, new(big.Int)
+			case flags&0x40 != 0:
+				payload = params.EncodeTransferMemo(payload)
+			}
+			var accessList types.AccessList
+			if flags&0x01 != 0 {
+				accessList = types.AccessList{{Address: chain.coinbase}}
+			}
+			gas, err := core.IntrinsicGas(payload, accessList, to == nil, true, true, false)
+			if err != nil {
+				t.Fatalf("intrinsic gas: %v", err)
+			}
+			gas += core.BatchTransferGas(len(payload) / params.BatchTransferEntryLength)
+			if flags&0x02 != 0 {
+				gas--
+			}
+			tx, err := types.SignNewTx(key, chain.signer, &types.AccessListTx{
+				ChainID:    big.NewInt(30216931),
+				Nonce:      statedb.GetNonce(from),
+				To:         to,
+				Value:      value,
+				Gas:        gas,
+				GasPrice:   new(big.Int),
+				Data:       payload,
+				AccessList: accessList,
+			})
+			if err != nil {
+				t.Fatalf("sign: %v", err)
+			}
+			// Steps grow quadratically, from seconds to about three weeks
+			chain.apply(t, tx, 1+step*step*30)
+			prev = chain.checkInvariants(t, prev)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package core
+
+import (
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// TransferMemo returns the memo carried by a transaction, if any.
+func TransferMemo(tx *types.Transaction) ([]byte, bool) {
+	if tx.To() == nil || tx.Value().Sign() <= 0 {
+		return nil, false
+	}
+	return params.DecodeTransferMemo(tx.Data())
+}
+
+// writeOlivetumMemoIndex indexes the memos of the transfers in a canonical
+// block. Entries of blocks that are later reorged out are left in place and
+// filtered on lookup.
+func writeOlivetumMemoIndex(db ethdb.KeyValueWriter, block *types.Block) {
+	if !params.TransferMemoFork.Active(block.Number()) {
+		return
+	}
+	for _, tx := range block.Transactions() {
+		if memo, ok := TransferMemo(tx); ok {
+			rawdb.WriteOlivetumMemoEntry(db, memo, block.NumberU64(), tx.Hash())
+		}
+	}
+}
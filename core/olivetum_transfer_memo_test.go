@@ -0,0 +1,74 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestTransferMemoPayloadRules(t *testing.T) {
+	var (
+		from  = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		to    = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		value = etherBig(20)
+		memo  = params.EncodeTransferMemo([]byte("deposit-4711"))
+	)
+	tests := []struct {
+		name     string
+		value    *big.Int
+		data     []byte
+		memoFork bool
+		want     error
+	}{
+		{"memo before fork", value, memo, false, ErrTxDataNotAllowed},
+		{"memo after fork", value, memo, true, nil},
+		{"memo without value", new(big.Int), memo, true, ErrTxDataNotAllowed},
+		{"missing prefix", value, []byte("deposit-4711"), true, ErrTxDataNotAllowed},
+		{"empty memo", value, params.TransferMemoPrefix, true, ErrTxDataNotAllowed},
+		{"oversized memo", value, params.EncodeTransferMemo(bytes.Repeat([]byte{1}, params.TransferMemoMaxLength+1)), true, ErrTxDataNotAllowed},
+	}
+	for _, tt := range tests {
+		err := ValidateOlivetumTxPayload(from, to, tt.value, tt.data, nil, true, tt.memoFork)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v want %v", tt.name, err, tt.want)
+		}
+	}
+	if err := ValidateOlivetumTxPayload(from, params.TxRateLimitContract, new(big.Int), memo, nil, true, true); !errors.Is(err, ErrTxDataLengthInvalid) {
+		t.Errorf("memo on management target: got %v", err)
+	}
+}
+
+func TestTransferMemoIndex(t *testing.T) {
+	oldFork := params.TransferMemoFork.Block()
+	t.Cleanup(func() { params.TransferMemoFork.SetBlock(oldFork) })
+	params.TransferMemoFork.SetBlock(big.NewInt(2))
+
+	to := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	newTx := func(nonce uint64, data []byte) *types.Transaction {
+		return types.NewTx(&types.LegacyTx{Nonce: nonce, To: &to, Value: etherBig(20), Gas: 30000, GasPrice: big.NewInt(1), Data: data})
+	}
+	memo := []byte("deposit-4711")
+	tagged := newTx(0, params.EncodeTransferMemo(memo))
+	other := newTx(1, params.EncodeTransferMemo([]byte("deposit-4712")))
+	plain := newTx(2, nil)
+
+	db := rawdb.NewMemoryDatabase()
+	for _, num := range []int64{1, 2} {
+		header := &types.Header{Number: big.NewInt(num)}
+		writeOlivetumMemoIndex(db, types.NewBlockWithHeader(header).WithBody([]*types.Transaction{tagged, other, plain}, nil))
+	}
+	var got []rawdb.OlivetumMemoEntry
+	rawdb.IterateOlivetumMemoEntries(db, memo, 0, func(e rawdb.OlivetumMemoEntry) bool {
+		got = append(got, e)
+		return true
+	})
+	if len(got) != 1 || got[0].Number != 2 || got[0].TxHash != tagged.Hash() {
+		t.Fatalf("unexpected memo entries: %+v", got)
+	}
+}
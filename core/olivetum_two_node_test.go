@@ -2,6 +2,7 @@ package core_test
 
 import (
 	"crypto/ecdsa"
+	"errors"
 	"math/big"
 	"testing"
 	"time"
@@ -123,6 +124,271 @@ func TestOlivetumEconomyForkTwoNodeImport(t *testing.T) {
 	}
 }
 
+func TestOlivetumAutoDividendPayoutLogs(t *testing.T) {
+	oldFork := params.DividendAutoFork.Block()
+	params.DividendAutoFork.SetBlock(big.NewInt(2))
+	t.Cleanup(func() { params.DividendAutoFork.SetBlock(oldFork) })
+
+	genesisTime := uint64(time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC).Unix())
+	spenderKey, _ := crypto.GenerateKey()
+	spender := crypto.PubkeyToAddress(spenderKey.PublicKey)
+	claimerKey, _ := crypto.GenerateKey()
+	claimer := crypto.PubkeyToAddress(claimerKey.PublicKey)
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000022")
+	spenderBal := new(big.Int).Mul(big.NewInt(10000), big.NewInt(vars.Ether))
+	dividendRate := uint64(50)
+
+	genesis := olivetumTestGenesis(genesisTime, spender, spenderBal, claimer, big.NewInt(vars.Ether), 150, dividendRate)
+	value := new(big.Int).Mul(big.NewInt(100), big.NewInt(vars.Ether))
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, olivetumhash.NewFaker(), 4, func(i int, gen *core.BlockGen) {
+		if i != 2 {
+			return
+		}
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(spender), recipient, value, vars.TxGas, new(big.Int), nil), gen.Signer(), spenderKey)
+		if err != nil {
+			t.Fatalf("sign tx: %v", err)
+		}
+		gen.AddTx(tx)
+	})
+	chain := olivetumNewBlockchain(t, genesis)
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	// The spender is indexed by its transfer and qualifies through the balance
+	// it held before the fork; the claimer is not indexed.
+	logs := chain.OlivetumPayoutLogs(blocks[2].Hash(), 3)
+	if len(logs) != 1 || logs[0].Topics[1] != common.BytesToHash(spender.Bytes()) {
+		t.Fatalf("unexpected payout logs: %v", logs)
+	}
+	statedb, err := chain.State()
+	if err != nil {
+		t.Fatalf("state: %v", err)
+	}
+	remaining := new(big.Int).Sub(spenderBal, value)
+	expected := new(big.Int).Mul(remaining, new(big.Int).SetUint64(dividendRate))
+	expected.Div(expected, big.NewInt(10000))
+	if reward := new(big.Int).SetBytes(logs[0].Data); reward.Cmp(expected) != 0 {
+		t.Fatalf("payout: have %v want %v", reward, expected)
+	}
+	if logs, ok := chain.OlivetumPayouts(blocks[3].Hash(), 4); len(logs) != 0 || !ok {
+		t.Fatalf("holder paid twice or payouts not recorded: %v", logs)
+	}
+	// Payouts have no receipt.
+	for _, receipt := range chain.GetReceiptsByHash(blocks[2].Hash()) {
+		for _, l := range receipt.Logs {
+			if l.Topics[0] == core.DividendClaimedTopic {
+				t.Fatalf("payout log in receipt of %x", receipt.TxHash)
+			}
+		}
+	}
+	// Spender, recipient and the coinbase receiving block rewards.
+	if got := core.DividendHolderCount(statedb); got != 3 {
+		t.Fatalf("indexed holders: have %d want 3", got)
+	}
+}
+
+func TestOlivetumDividendHolderIndexReorg(t *testing.T) {
+	genesisTime := uint64(time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC).Unix())
+	spenderKey, _ := crypto.GenerateKey()
+	spender := crypto.PubkeyToAddress(spenderKey.PublicKey)
+	claimer := common.HexToAddress("0x0000000000000000000000000000000000000011")
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000022")
+	minerA := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	minerB := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	spenderBal := new(big.Int).Mul(big.NewInt(10000), big.NewInt(vars.Ether))
+
+	genesis := olivetumTestGenesis(genesisTime, spender, spenderBal, claimer, big.NewInt(vars.Ether), 150, 50)
+	value := new(big.Int).Mul(big.NewInt(100), big.NewInt(vars.Ether))
+	_, blocksA, _ := core.GenerateChainWithGenesis(genesis, olivetumhash.NewFaker(), 3, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(minerA)
+		if i != 1 {
+			return
+		}
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(spender), recipient, value, vars.TxGas, new(big.Int), nil), gen.Signer(), spenderKey)
+		if err != nil {
+			t.Fatalf("sign tx: %v", err)
+		}
+		gen.AddTx(tx)
+	})
+	_, blocksB, _ := core.GenerateChainWithGenesis(genesis, olivetumhash.NewFaker(), 4, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(minerB)
+	})
+	// The index is off unless enabled.
+	if _, _, _, err := olivetumNewBlockchain(t, genesis).OlivetumDividendHolders(common.Address{}, 1); !errors.Is(err, core.ErrHolderIndexDisabled) {
+		t.Fatalf("disabled index: have %v want %v", err, core.ErrHolderIndexDisabled)
+	}
+	cacheConfig := core.DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.OlivetumHolderIndex = true
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), cacheConfig, genesis, nil, olivetumhash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("new chain: %v", err)
+	}
+	defer chain.Stop()
+	olivetumWaitIndexSeed(t, chain.OlivetumHolderIndexComplete)
+
+	indexed := func() map[common.Address]*big.Int {
+		addrs, entries, next, err := chain.OlivetumDividendHolders(common.Address{}, 1)
+		if err != nil {
+			t.Fatalf("holders: %v", err)
+		}
+		out := make(map[common.Address]*big.Int)
+		for next != nil {
+			out[addrs[0]] = new(big.Int).Add(entries[0].Held, entries[0].Pending)
+			addrs, entries, next, _ = chain.OlivetumDividendHolders(*next, 1)
+		}
+		if len(addrs) == 1 {
+			out[addrs[0]] = new(big.Int).Add(entries[0].Held, entries[0].Pending)
+		}
+		return out
+	}
+	if _, err := chain.InsertChain(blocksA); err != nil {
+		t.Fatalf("insert chain A: %v", err)
+	}
+	holders := indexed()
+	if len(holders) != 4 {
+		t.Fatalf("indexed holders on chain A: have %v want spender, recipient, miner and the seeded claimer", holders)
+	}
+	received := new(big.Int).Sub(value, new(big.Int).Div(new(big.Int).Mul(value, big.NewInt(150)), big.NewInt(10000)))
+	if have := holders[recipient]; have == nil || have.Cmp(received) != 0 {
+		t.Fatalf("recipient holding: have %v want %v", have, received)
+	}
+	if holders[minerA] == nil || holders[spender] == nil {
+		t.Fatalf("missing holders on chain A: %v", holders)
+	}
+
+	// The heavier chain B reorgs chain A out; its transfer and rewards must be
+	// rolled back.
+	if _, err := chain.InsertChain(blocksB); err != nil {
+		t.Fatalf("insert chain B: %v", err)
+	}
+	if chain.CurrentBlock().Hash() != blocksB[3].Hash() {
+		t.Fatalf("chain B not canonical")
+	}
+	holders = indexed()
+	if len(holders) != 3 || holders[minerB] == nil || holders[spender] == nil || holders[claimer] == nil {
+		t.Fatalf("indexed holders on chain B: have %v want spender, claimer and miner", holders)
+	}
+	if holders[spender].Cmp(spenderBal) != 0 {
+		t.Fatalf("spender holding on chain B: have %v want %v", holders[spender], spenderBal)
+	}
+}
+
+func TestOlivetumDividendHolderIndexSeed(t *testing.T) {
+	genesisTime := uint64(time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC).Unix())
+	spenderKey, _ := crypto.GenerateKey()
+	spender := crypto.PubkeyToAddress(spenderKey.PublicKey)
+	claimer := common.HexToAddress("0x0000000000000000000000000000000000000011")
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000022")
+	miner := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	spenderBal := new(big.Int).Mul(big.NewInt(10000), big.NewInt(vars.Ether))
+
+	genesis := olivetumTestGenesis(genesisTime, spender, spenderBal, claimer, big.NewInt(vars.Ether), 150, 50)
+	value := new(big.Int).Mul(big.NewInt(100), big.NewInt(vars.Ether))
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, olivetumhash.NewFaker(), 3, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(miner)
+		if i != 1 {
+			return
+		}
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(spender), recipient, value, vars.TxGas, new(big.Int), nil), gen.Signer(), spenderKey)
+		if err != nil {
+			t.Fatalf("sign tx: %v", err)
+		}
+		gen.AddTx(tx)
+	})
+	// Sync the chain without the index, then enable it: the accounts touched
+	// before are seeded from the state snapshot.
+	db := rawdb.NewMemoryDatabase()
+	cacheConfig := core.DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.Preimages = true
+	chain, err := core.NewBlockChain(db, cacheConfig, genesis, nil, olivetumhash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("new chain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("insert chain: %v", err)
+	}
+	chain.Stop()
+
+	cacheConfig.OlivetumHolderIndex = true
+	chain, err = core.NewBlockChain(db, cacheConfig, genesis, nil, olivetumhash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("reopen chain: %v", err)
+	}
+	defer chain.Stop()
+	olivetumWaitIndexSeed(t, chain.OlivetumHolderIndexComplete)
+
+	statedb, err := chain.State()
+	if err != nil {
+		t.Fatalf("state: %v", err)
+	}
+	addrs, entries, next, err := chain.OlivetumDividendHolders(common.Address{}, 10)
+	if err != nil {
+		t.Fatalf("holders: %v", err)
+	}
+	if next != nil || len(addrs) != 4 {
+		t.Fatalf("seeded holders: have %v want spender, claimer, recipient and miner", addrs)
+	}
+	for i, addr := range addrs {
+		holder := core.GetDividendHolder(statedb, addr, chain.CurrentBlock().Time)
+		if entries[i].Held.Cmp(holder.Held) != 0 || entries[i].Pending.Cmp(holder.Pending) != 0 {
+			t.Fatalf("seeded %v: have %v/%v want %v/%v", addr, entries[i].Held, entries[i].Pending, holder.Held, holder.Pending)
+		}
+	}
+}
+
+func TestOlivetumDividendHolderIndexKeepsLastChangedPosition(t *testing.T) {
+	genesisTime := uint64(time.Date(2024, time.March, 4, 10, 0, 0, 0, time.UTC).Unix())
+	spenderKey, _ := crypto.GenerateKey()
+	spender := crypto.PubkeyToAddress(spenderKey.PublicKey)
+	claimer := common.HexToAddress("0x0000000000000000000000000000000000000011")
+	recipient := common.HexToAddress("0x0000000000000000000000000000000000000022")
+	spenderBal := new(big.Int).Mul(big.NewInt(10000), big.NewInt(vars.Ether))
+
+	genesis := olivetumTestGenesis(genesisTime, spender, spenderBal, claimer, big.NewInt(vars.Ether), 0, 50)
+	value := new(big.Int).Mul(big.NewInt(100), big.NewInt(vars.Ether))
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, olivetumhash.NewFaker(), 3, func(i int, gen *core.BlockGen) {
+		if i != 0 {
+			return
+		}
+		tx, err := types.SignTx(types.NewTransaction(gen.TxNonce(spender), recipient, value, vars.TxGas, new(big.Int), nil), gen.Signer(), spenderKey)
+		if err != nil {
+			t.Fatalf("sign tx: %v", err)
+		}
+		gen.AddTx(tx)
+	})
+	cacheConfig := core.DefaultCacheConfigWithScheme(rawdb.HashScheme)
+	cacheConfig.OlivetumHolderIndex = true
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), cacheConfig, genesis, nil, olivetumhash.NewFaker(), vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("new chain: %v", err)
+	}
+	defer chain.Stop()
+	olivetumWaitIndexSeed(t, chain.OlivetumHolderIndexComplete)
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("insert chain: %v", err)
+	}
+	statedb, err := chain.State()
+	if err != nil {
+		t.Fatalf("state: %v", err)
+	}
+	received := statedb.GetBalance(recipient).ToBig()
+	addrs, entries, _, err := chain.OlivetumDividendHolders(recipient, 1)
+	if err != nil || len(addrs) != 1 || addrs[0] != recipient {
+		t.Fatalf("recipient not indexed: %v %v", addrs, err)
+	}
+	// The entry keeps the position of the block that credited the account.
+	if entries[0].Number != 1 || entries[0].Held.Sign() != 0 || entries[0].Pending.Cmp(received) != 0 {
+		t.Fatalf("indexed position: have %d %v/%v want 1 0/%v", entries[0].Number, entries[0].Held, entries[0].Pending, received)
+	}
+	// Once the credit matures, only the state reports it as held.
+	matured := core.GetDividendHolder(statedb, recipient, chain.CurrentBlock().Time+31*24*60*60)
+	if matured.Held.Cmp(received) != 0 || matured.Pending.Sign() != 0 {
+		t.Fatalf("matured position: have %v/%v want %v/0", matured.Held, matured.Pending, received)
+	}
+}
+
 func olivetumTestGenesis(ts uint64, spender common.Address, spenderBal *big.Int, claimer common.Address, claimerBal *big.Int, burnRate uint64, dividendRate uint64) *genesisT.Genesis {
 	cfg := &goethereum.ChainConfig{
 		ChainID:             big.NewInt(30216931),
@@ -206,6 +472,17 @@ func olivetumNewBlockchain(t *testing.T, genesis *genesisT.Genesis) *core.BlockC
 	return chain
 }
 
+// olivetumWaitIndexSeed waits until a node-side index has been seeded from the
+// state snapshot.
+func olivetumWaitIndexSeed(t *testing.T, complete func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(10 * time.Second); !complete(); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("index not seeded")
+		}
+	}
+}
+
 func olivetumBurnedNet(value *big.Int, burnRate uint64) *big.Int {
 	burn := new(big.Int).Mul(value, new(big.Int).SetUint64(burnRate))
 	burn.Div(burn, big.NewInt(10000))
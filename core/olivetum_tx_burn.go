@@ -0,0 +1,79 @@
+package core
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// OlivetumTxBurn is the burn caused by a transaction: the amount removed from
+// the supply and the share of the gross burn paid to the miner. TransferBurn is
+// the gross burn of the transferred value alone, which is charged to the
+// sender.
+type OlivetumTxBurn struct {
+	Burned         *big.Int
+	MinerBurnShare *big.Int
+	TransferBurn   *big.Int
+}
+
+// OlivetumTxBurns returns the burns of the transactions of a block: the burn
+// of the transferred value and of the successful batch transfer entries and,
+// after the economy fork, the burn of the miner's tip. rate is the burn rate of
+// the parent state; rate updates made by the block apply to the transactions
+// following them.
+func OlivetumTxBurns(block *types.Block, receipts types.Receipts, signer types.Signer, rate uint64) []OlivetumTxBurn {
+	var (
+		header  = block.Header()
+		economy = isEconomyForkActive(header.Number)
+		isBatch = params.BatchTransferFork.Active(header.Number)
+		burns   = make([]OlivetumTxBurn, 0, len(receipts))
+	)
+	for i, tx := range block.Transactions() {
+		if i >= len(receipts) {
+			break
+		}
+		var (
+			receipt = receipts[i]
+			entry   = OlivetumTxBurn{Burned: new(big.Int), MinerBurnShare: new(big.Int), TransferBurn: new(big.Int)}
+		)
+		add := func(amount *big.Int, transfer bool) {
+			burn, share := splitOlivetumBurn(amount, rate, header.Number)
+			if transfer {
+				entry.TransferBurn.Add(entry.TransferBurn, burn)
+			}
+			entry.Burned.Add(entry.Burned, burn.Sub(burn, share))
+			entry.MinerBurnShare.Add(entry.MinerBurnShare, share)
+		}
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			burns = append(burns, entry)
+			continue
+		}
+		if tx.Value().Sign() > 0 {
+			add(tx.Value(), true)
+		}
+		to := tx.To()
+		if to != nil && *to == params.BatchTransferContract && isBatch && receipt.Status == types.ReceiptStatusSuccessful {
+			entries, _ := params.DecodeBatchTransfer(tx.Data())
+			for _, e := range entries {
+				add(e.Amount, true)
+			}
+		}
+		// The tip is burned after execution, at the rate set by the transaction.
+		if to != nil && *to == BurnContract && from == BurnAdmin && receipt.Status == types.ReceiptStatusSuccessful {
+			if r, ok := DecodeBurnRate(tx.Data()); ok {
+				rate = r
+			}
+		}
+		if economy {
+			tip := tx.GasPrice()
+			if header.BaseFee != nil {
+				tip = tx.EffectiveGasTipValue(header.BaseFee)
+			}
+			add(new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), tip), false)
+		}
+		burns = append(burns, entry)
+	}
+	return burns
+}
@@ -68,6 +68,20 @@ func GetTxAllowance(s vm.StateDB, addr common.Address, now uint64) uint64 {
 	if !IsSession(now) {
 		limit = params.GetOffSessionTxRate()
 	}
+	return txAllowance(s, addr, now, limit)
+}
+
+// ReadTxAllowance is GetTxAllowance with the rate limits and the session
+// calendar stored in the state instead of the runtime ones.
+func ReadTxAllowance(s vm.StateDB, addr common.Address, now uint64) uint64 {
+	limit := ReadTxRateLimit(s)
+	if !ReadSessionSchedule(s).IsOpen(now) {
+		limit = ReadOffSessionTxRate(s)
+	}
+	return txAllowance(s, addr, now, limit)
+}
+
+func txAllowance(s vm.StateDB, addr common.Address, now uint64, limit uint64) uint64 {
 	epoch := loadTxRateEpoch(s)
 	u := GetTxRateUsage(s, addr)
 	if u.Epoch != epoch || now-u.Start >= uint64(time.Hour/time.Second) {
@@ -97,6 +111,10 @@ func IsTxRateLimitExempt(from common.Address, to common.Address, data []byte) bo
 		return from == params.OffSessionAdmin
 	case params.SessionTzContract:
 		return from == params.SessionTzAdmin
+	case params.SessionCalendarContract:
+		return from == params.SessionCalendarAdmin
+	case params.AddressListContract:
+		return from == params.AddressListAdmin
 	default:
 		return false
 	}
@@ -116,6 +134,16 @@ func LoadTxRateLimit(s vm.StateDB) uint64 {
 	return params.GetTxRateLimit()
 }
 
+// ReadTxRateLimit returns the stored in-session tx rate limit without updating
+// the runtime value, falling back to the default limit if none is stored.
+func ReadTxRateLimit(s vm.StateDB) uint64 {
+	stored := s.GetState(params.TxRateLimitContract, txRateLimitSlot).Big()
+	if stored.Sign() > 0 {
+		return stored.Uint64()
+	}
+	return params.TxRateLimitDefault
+}
+
 func SetTxRateLimit(s vm.StateDB, limit uint64) {
 	ensureTxRateAccount(s)
 	s.SetState(params.TxRateLimitContract, txRateLimitSlot, common.BigToHash(new(big.Int).SetUint64(limit)))
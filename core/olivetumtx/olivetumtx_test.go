@@ -0,0 +1,168 @@
+package olivetumtx
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+func TestPayloadRoundTrip(t *testing.T) {
+	burn, err := SetBurnRate(150)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate, ok := core.DecodeBurnRate(burn.Data); !ok || rate != 150 || burn.To != core.BurnContract {
+		t.Fatalf("burn rate: have %x to %v", burn.Data, burn.To)
+	}
+	if _, err := SetBurnRate(175); !errors.Is(err, ErrInvalidSetting) {
+		t.Fatalf("burn rate 175: have %v", err)
+	}
+	min := new(big.Int).Mul(params.MinTxAmountMin, big.NewInt(25))
+	call, err := SetMinTxAmount(min)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := params.DecodeMinTxAmount(call.Data); !ok || v.Cmp(min) != 0 || len(call.Data) != 8 {
+		t.Fatalf("min tx amount: have %x", call.Data)
+	}
+	if _, err := SetMinTxAmount(new(big.Int).Add(min, big.NewInt(1))); !errors.Is(err, ErrInvalidSetting) {
+		t.Fatalf("min tx amount off unit: have %v", err)
+	}
+	tz, err := SetSessionTzOffset(-3600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := params.DecodeSessionTzOffset(tz.Data); !ok || v != -3600 {
+		t.Fatalf("tz offset: have %x", tz.Data)
+	}
+	if _, err := SetSessionTzOffset(100_000); !errors.Is(err, ErrInvalidSetting) {
+		t.Fatalf("tz offset out of range: have %v", err)
+	}
+	if _, err := SetGasLimit(30_000_000); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SetGasLimit(30_500_000); !errors.Is(err, ErrInvalidSetting) {
+		t.Fatalf("gas limit off unit: have %v", err)
+	}
+	if _, err := AddSessionHoliday(2024, time.December, 25); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SetSessionHours(time.Monday, 14, 12); !errors.Is(err, ErrInvalidSetting) {
+		t.Fatalf("inverted session hours: have %v", err)
+	}
+	addr := common.HexToAddress("0x1234")
+	list, err := AddAddressClass(addr, params.AddressClassFrozen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(list.Data[2:], addr.Bytes()) {
+		t.Fatalf("address list: have %x", list.Data)
+	}
+	if _, err := AddAddressClass(addr, params.AddressClassFrozen|params.AddressClassMinAmountExempt); !errors.Is(err, ErrInvalidSetting) {
+		t.Fatalf("combined address class: have %v", err)
+	}
+	entries := []params.BatchTransferEntry{{To: addr, Amount: big.NewInt(vars.Ether)}, {To: common.HexToAddress("0x5678"), Amount: big.NewInt(2)}}
+	batch, err := BatchTransfer(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, ok := params.DecodeBatchTransfer(batch.Data)
+	if !ok || len(decoded) != 2 || decoded[0].To != addr || decoded[1].Amount.Int64() != 2 {
+		t.Fatalf("batch transfer: have %x", batch.Data)
+	}
+	if _, err := BatchTransfer(nil); !errors.Is(err, ErrInvalidSetting) {
+		t.Fatalf("empty batch transfer: have %v", err)
+	}
+	memo, err := TransferMemo([]byte("invoice 42"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m, ok := params.DecodeTransferMemo(memo); !ok || string(m) != "invoice 42" {
+		t.Fatalf("memo: have %x", memo)
+	}
+}
+
+var (
+	sessionOpen   = uint64(time.Date(2024, time.January, 1, 13, 0, 0, 0, time.UTC).Unix()) // Monday
+	sessionClosed = uint64(time.Date(2024, time.January, 7, 13, 0, 0, 0, time.UTC).Unix()) // Sunday
+)
+
+func testRules() *Rules {
+	return &Rules{
+		MinTxAmount:        big.NewInt(vars.Ether),
+		OffSessionMaxPerTx: new(big.Int).Mul(big.NewInt(10), big.NewInt(vars.Ether)),
+		Schedule:           core.SessionSchedule{Calendar: params.DefaultSessionCalendar()},
+		Time:               sessionOpen,
+		EconomyFork:        true,
+		MemoFork:           true,
+		BatchFork:          true,
+		CalendarFork:       true,
+		AddressListFork:    true,
+		Classes:            map[common.Address]uint8{},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	var (
+		from  = common.HexToAddress("0xaaaa")
+		to    = common.HexToAddress("0xbbbb")
+		ether = func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(vars.Ether)) }
+		zero  uint64
+	)
+	burn, _ := SetBurnRate(100)
+	claim := ClaimDividend()
+	batch, _ := BatchTransfer([]params.BatchTransferEntry{{To: to, Amount: big.NewInt(1)}})
+	tests := []struct {
+		name  string
+		tx    *Tx
+		setup func(r *Rules)
+		want  error
+	}{
+		{"transfer", &Tx{From: from, To: &to, Value: ether(2)}, nil, nil},
+		{"contract creation", &Tx{From: from, Value: ether(2)}, nil, core.ErrContractCreationDisabled},
+		{"self transfer", &Tx{From: from, To: &from, Value: ether(2)}, nil, core.ErrSelfTransfer},
+		{"unauthorized management", burn.Tx(from), nil, txpool.ErrManagementUnauthorized},
+		{"authorized management", burn.Tx(core.BurnAdmin), nil, nil},
+		{"claim", claim.Tx(from), nil, nil},
+		{"claim with data", &Tx{From: from, To: &claim.To, Value: new(big.Int), Data: []byte{1}}, nil, core.ErrTxDataNotAllowed},
+		{"claim with value", &Tx{From: from, To: &claim.To, Value: ether(2)}, nil, core.ErrTxValueNotAllowed},
+		{"frozen sender", &Tx{From: from, To: &to, Value: ether(2)}, func(r *Rules) { r.Classes[from] = params.AddressClassFrozen }, txpool.ErrAddressFrozen},
+		{"frozen recipient", &Tx{From: from, To: &to, Value: ether(2)}, func(r *Rules) { r.Classes[to] = params.AddressClassFrozen }, txpool.ErrRecipientFrozen},
+		{"under min amount", &Tx{From: from, To: &to, Value: big.NewInt(1)}, nil, txpool.ErrUnderMinAmount},
+		{"min amount exempt class", &Tx{From: from, To: &to, Value: big.NewInt(1)}, func(r *Rules) { r.Classes[to] = params.AddressClassMinAmountExempt }, nil},
+		{"batch under min amount", batch.Tx(from), nil, core.ErrBatchTransferAmount},
+		{"over off-session max", &Tx{From: from, To: &to, Value: ether(11)}, func(r *Rules) { r.Time = sessionClosed }, txpool.ErrOverMaxAmount},
+		{"off-session exempt", &Tx{From: from, To: &to, Value: ether(11)}, func(r *Rules) {
+			r.Time = sessionClosed
+			r.Classes[from] = params.AddressClassOffSessionExempt
+		}, nil},
+		{"over off-session budget", &Tx{From: from, To: &to, Value: ether(5)}, func(r *Rules) {
+			r.Time = sessionClosed
+			r.OffSessionBudget = ether(4)
+		}, core.ErrOverMaxOffSessionBudget},
+		{"rate limited", &Tx{From: from, To: &to, Value: ether(2)}, func(r *Rules) { r.TxRemaining = &zero }, core.ErrRateLimit},
+		{"rate limit exempt class", &Tx{From: from, To: &to, Value: ether(2)}, func(r *Rules) {
+			r.TxRemaining = &zero
+			r.Classes[from] = params.AddressClassRateLimitExempt
+		}, nil},
+		{"access list", &Tx{From: from, To: &to, Value: ether(2), AccessList: types.AccessList{{Address: to}}}, nil, core.ErrTxAccessListNotAllowed},
+	}
+	for _, tt := range tests {
+		r := testRules()
+		if tt.setup != nil {
+			tt.setup(r)
+		}
+		if err := r.Validate(tt.tx); !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+			t.Errorf("%s: have %v want %v", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package olivetumtx
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ErrInvalidSetting is returned for a setting the management contract would
+// reject.
+var ErrInvalidSetting = errors.New("invalid management setting")
+
+// Call is a zero-value transaction to one of the Olivetum management or
+// special-purpose contracts.
+type Call struct {
+	To   common.Address
+	Data []byte
+}
+
+// Tx returns the admission view of the call sent by from.
+func (c *Call) Tx(from common.Address) *Tx {
+	to := c.To
+	return &Tx{From: from, To: &to, Value: new(big.Int), Data: c.Data}
+}
+
+// indexOf returns the one-byte index payload selecting value in an option
+// list decoded by decode.
+func indexOf(value uint64, decode func([]byte) (uint64, bool)) ([]byte, bool) {
+	for i := 0; i < 256; i++ {
+		if v, ok := decode([]byte{byte(i)}); !ok {
+			break
+		} else if v == value {
+			return []byte{byte(i)}, true
+		}
+	}
+	return nil, false
+}
+
+// SetBurnRate returns the call setting the burn rate in basis points, sent by
+// the burn admin.
+func SetBurnRate(rate uint64) (*Call, error) {
+	data, ok := indexOf(rate, core.DecodeBurnRate)
+	if !ok {
+		return nil, fmt.Errorf("%w: burn rate %d", ErrInvalidSetting, rate)
+	}
+	return &Call{To: core.BurnContract, Data: data}, nil
+}
+
+// SetDividendRate returns the call setting the dividend rate in basis points
+// and starting a dividend round, sent by the dividend admin.
+func SetDividendRate(rate uint64) (*Call, error) {
+	data, ok := indexOf(rate, core.DecodeDividendRate)
+	if !ok {
+		return nil, fmt.Errorf("%w: dividend rate %d", ErrInvalidSetting, rate)
+	}
+	return &Call{To: core.DividendContract, Data: data}, nil
+}
+
+// ClaimDividend returns the call claiming the dividend of the sender.
+func ClaimDividend() *Call {
+	return &Call{To: core.DividendContract}
+}
+
+// SetGasLimit returns the call setting the block gas limit, a whole number of
+// millions up to 255 million.
+func SetGasLimit(limit uint64) (*Call, error) {
+	if limit == 0 || limit%1_000_000 != 0 || limit/1_000_000 > 255 {
+		return nil, fmt.Errorf("%w: gas limit %d", ErrInvalidSetting, limit)
+	}
+	return &Call{To: params.GasLimitContract, Data: []byte{byte(limit / 1_000_000)}}, nil
+}
+
+// SetBlockPeriod returns the call setting the block period in seconds.
+func SetBlockPeriod(period uint64) (*Call, error) {
+	return byteSetting(params.PeriodContract, "block period", period, params.DecodeBlockPeriod)
+}
+
+// SetTxRateLimit returns the call setting the in-session transactions per
+// hour of an account.
+func SetTxRateLimit(limit uint64) (*Call, error) {
+	return byteSetting(params.TxRateLimitContract, "tx rate limit", limit, params.DecodeTxRateLimit)
+}
+
+// SetOffSessionTxRate returns the call setting the off-session transactions
+// per hour of an account.
+func SetOffSessionTxRate(limit uint64) (*Call, error) {
+	return byteSetting(params.OffSessionTxRateContract, "off-session tx rate", limit, params.DecodeOffSessionTxRate)
+}
+
+func byteSetting(to common.Address, name string, value uint64, decode func([]byte) (uint64, bool)) (*Call, error) {
+	if value <= 255 {
+		data := []byte{byte(value)}
+		if v, ok := decode(data); ok && v == value {
+			return &Call{To: to, Data: data}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s %d", ErrInvalidSetting, name, value)
+}
+
+// SetMinTxAmount returns the call setting the minimum transfer amount in wei,
+// a multiple of params.MinTxAmountMin within the allowed bounds.
+func SetMinTxAmount(amount *big.Int) (*Call, error) {
+	return amountSetting(params.MinTxAmountContract, "min tx amount", amount, params.MinTxAmountMin, params.DecodeMinTxAmount)
+}
+
+// SetOffSessionMaxPerTx returns the call setting the off-session per-tx
+// maximum in wei, a multiple of params.OffSessionMaxPerTxMin within the
+// allowed bounds.
+func SetOffSessionMaxPerTx(amount *big.Int) (*Call, error) {
+	return amountSetting(params.OffSessionMaxPerTxContract, "off-session max per tx", amount, params.OffSessionMaxPerTxMin, params.DecodeOffSessionMaxPerTx)
+}
+
+// amountSetting encodes an amount as the 8-byte big-endian number of units.
+func amountSetting(to common.Address, name string, amount, unit *big.Int, decode func([]byte) (*big.Int, bool)) (*Call, error) {
+	units, rem := new(big.Int).QuoRem(amount, unit, new(big.Int))
+	if rem.Sign() == 0 && units.IsUint64() {
+		data := binary.BigEndian.AppendUint64(nil, units.Uint64())
+		if v, ok := decode(data); ok && v.Cmp(amount) == 0 {
+			return &Call{To: to, Data: data}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s %v", ErrInvalidSetting, name, amount)
+}
+
+// SetSessionTzOffset returns the call setting the session time offset from
+// UTC in seconds.
+func SetSessionTzOffset(offset int32) (*Call, error) {
+	data := binary.BigEndian.AppendUint32(nil, uint32(offset))
+	if _, ok := params.DecodeSessionTzOffset(data); !ok {
+		return nil, fmt.Errorf("%w: session tz offset %d", ErrInvalidSetting, offset)
+	}
+	return &Call{To: params.SessionTzContract, Data: data}, nil
+}
+
+// SetSessionHours returns the call setting the session hours [open, close) of
+// a weekday in local time. Zero hours close the weekday.
+func SetSessionHours(weekday time.Weekday, open, close uint8) (*Call, error) {
+	return sessionCalendarCall([]byte{params.SessionCalendarOpSetHours, byte(weekday), open, close})
+}
+
+// AddSessionHoliday returns the call closing the session on a local date.
+func AddSessionHoliday(year int, month time.Month, day int) (*Call, error) {
+	return sessionCalendarCall(holidayPayload(params.SessionCalendarOpAddHoliday, year, month, day))
+}
+
+// RemoveSessionHoliday returns the call reopening a closed local date.
+func RemoveSessionHoliday(year int, month time.Month, day int) (*Call, error) {
+	return sessionCalendarCall(holidayPayload(params.SessionCalendarOpRemoveHoliday, year, month, day))
+}
+
+// ResetSessionCalendar returns the call restoring the default session
+// calendar.
+func ResetSessionCalendar() *Call {
+	return &Call{To: params.SessionCalendarContract, Data: []byte{params.SessionCalendarOpReset}}
+}
+
+func holidayPayload(op byte, year int, month time.Month, day int) []byte {
+	if year < 0 || year > 0xffff {
+		return nil
+	}
+	return append(binary.BigEndian.AppendUint16([]byte{op}, uint16(year)), byte(month), byte(day))
+}
+
+func sessionCalendarCall(data []byte) (*Call, error) {
+	if _, ok := params.DecodeSessionCalendarUpdate(data); !ok {
+		return nil, fmt.Errorf("%w: session calendar update %x", ErrInvalidSetting, data)
+	}
+	return &Call{To: params.SessionCalendarContract, Data: data}, nil
+}
+
+// AddAddressClass returns the call adding an address to an address class.
+func AddAddressClass(addr common.Address, class uint8) (*Call, error) {
+	return addressListCall(params.AddressListOpAdd, addr, class)
+}
+
+// RemoveAddressClass returns the call removing an address from an address
+// class.
+func RemoveAddressClass(addr common.Address, class uint8) (*Call, error) {
+	return addressListCall(params.AddressListOpRemove, addr, class)
+}
+
+func addressListCall(op byte, addr common.Address, class uint8) (*Call, error) {
+	if !params.IsAddressClass(class) {
+		return nil, fmt.Errorf("%w: address class %#x", ErrInvalidSetting, class)
+	}
+	return &Call{To: params.AddressListContract, Data: append([]byte{op, class}, addr.Bytes()...)}, nil
+}
+
+// BatchTransfer returns the call paying out the given entries from the
+// sender. It needs the batch transfer fork.
+func BatchTransfer(entries []params.BatchTransferEntry) (*Call, error) {
+	if len(entries) == 0 || len(entries) > params.BatchTransferMaxRecipients {
+		return nil, fmt.Errorf("%w: %d batch transfer entries", ErrInvalidSetting, len(entries))
+	}
+	data := make([]byte, 0, len(entries)*params.BatchTransferEntryLength)
+	for i, e := range entries {
+		if e.Amount == nil || e.Amount.Sign() < 0 || e.Amount.BitLen() > 256 {
+			return nil, fmt.Errorf("%w: batch transfer entry %d amount %v", ErrInvalidSetting, i, e.Amount)
+		}
+		data = append(data, e.To.Bytes()...)
+		data = append(data, common.BigToHash(e.Amount).Bytes()...)
+	}
+	return &Call{To: params.BatchTransferContract, Data: data}, nil
+}
+
+// TransferMemo returns the payload of a plain transfer carrying a memo. It
+// needs the transfer memo fork.
+func TransferMemo(memo []byte) ([]byte, error) {
+	data := params.EncodeTransferMemo(memo)
+	if _, ok := params.DecodeTransferMemo(data); !ok {
+		return nil, fmt.Errorf("%w: memo of %d bytes", ErrInvalidSetting, len(memo))
+	}
+	return data, nil
+}
@@ -0,0 +1,150 @@
+// Package olivetumtx builds Olivetum transaction payloads and checks
+// transactions against the Olivetum admission rules without a node, so that
+// wallets can predict the errors the transaction pool and the state transition
+// would return.
+package olivetumtx
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/olivetumtypes"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tx is a transaction as seen by the admission rules.
+type Tx struct {
+	From       common.Address
+	To         *common.Address
+	Value      *big.Int
+	Data       []byte
+	AccessList types.AccessList
+}
+
+// NewTx returns the admission view of a signed transaction.
+func NewTx(signer types.Signer, tx *types.Transaction) (*Tx, error) {
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{From: from, To: tx.To(), Value: tx.Value(), Data: tx.Data(), AccessList: tx.AccessList()}, nil
+}
+
+// Rules is the chain configuration a transaction is checked against.
+type Rules struct {
+	MinTxAmount        *big.Int
+	OffSessionMaxPerTx *big.Int
+	Schedule           core.SessionSchedule
+
+	// Time is the timestamp the session is evaluated at. The transaction pool
+	// uses the time expected for the next block (core.PendingOlivetumTime).
+	Time uint64
+
+	// Forks active for the block including the transaction.
+	EconomyFork     bool
+	MemoFork        bool
+	BatchFork       bool
+	CalendarFork    bool
+	AddressListFork bool
+
+	// Classes holds the address list classes of the accounts involved
+	// (olivetum_getAddressClasses); missing accounts belong to no class.
+	Classes map[common.Address]uint8
+
+	// TxRemaining is the rate limit allowance of the sender (eth_getTxLimits),
+	// nil if unknown.
+	TxRemaining *uint64
+
+	// OffSessionBudget is the remaining off-session budget of the sender
+	// (eth_getOffSessionBudget), nil if unknown.
+	OffSessionBudget *big.Int
+}
+
+// NewRules returns the rules of a runtime configuration fetched from a node
+// (olivetum_getRuntimeConfig) for a transaction included in block number at
+// the given time. The fork flags are taken from the fork heights of this
+// process, which default to those of the Olivetum network.
+func NewRules(cfg *olivetumtypes.RuntimeConfig, number *big.Int, now uint64) (*Rules, error) {
+	if cfg.MinTxAmount == nil || cfg.OffSessionMaxPerTx == nil || cfg.SessionCalendar == nil {
+		return nil, errors.New("incomplete runtime config")
+	}
+	if len(cfg.SessionCalendar.Hours) != len(params.SessionCalendar{}.Hours) {
+		return nil, fmt.Errorf("session calendar has %d weekdays", len(cfg.SessionCalendar.Hours))
+	}
+	var cal params.SessionCalendar
+	for i, h := range cfg.SessionCalendar.Hours {
+		cal.Hours[i] = params.SessionHours{Open: h.Open, Close: h.Close}
+	}
+	for _, holiday := range cfg.SessionCalendar.Holidays {
+		date, err := time.Parse("2006-01-02", holiday)
+		if err != nil {
+			return nil, fmt.Errorf("invalid holiday %q: %v", holiday, err)
+		}
+		cal.Holidays = append(cal.Holidays, uint32(date.Unix()/(24*60*60)))
+	}
+	fork := params.GetEconomyForkBlock()
+	return &Rules{
+		MinTxAmount:        cfg.MinTxAmount.ToInt(),
+		OffSessionMaxPerTx: cfg.OffSessionMaxPerTx.ToInt(),
+		Schedule:           core.SessionSchedule{Calendar: cal, TzOffset: cfg.SessionTzOffsetSeconds},
+		Time:               now,
+		EconomyFork:        fork.Sign() > 0 && number.Cmp(fork) >= 0,
+		MemoFork:           params.TransferMemoFork.Active(number),
+		BatchFork:          params.BatchTransferFork.Active(number),
+		CalendarFork:       params.SessionCalendarFork.Active(number),
+		AddressListFork:    params.AddressListFork.Active(number),
+	}, nil
+}
+
+func (r *Rules) classes(addr common.Address) uint8 {
+	return r.Classes[addr]
+}
+
+// Validate checks a transaction against the rules in the order of the
+// transaction pool and returns the error the node would reject it with. The
+// errors are those of the core and txpool packages, to be matched with
+// errors.Is.
+func (r *Rules) Validate(tx *Tx) error {
+	rules := &core.OlivetumRules{
+		Time:               r.Time,
+		EconomyFork:        r.EconomyFork,
+		MemoFork:           r.MemoFork,
+		BatchFork:          r.BatchFork,
+		CalendarFork:       r.CalendarFork,
+		AddressListFork:    r.AddressListFork,
+		Session:            r.Schedule.IsOpen(r.Time),
+		MinTxAmount:        r.MinTxAmount,
+		OffSessionMaxPerTx: r.OffSessionMaxPerTx,
+		Classes:            r.classes,
+	}
+	if err := rules.CheckParties(tx.From, tx.To); err != nil {
+		return err
+	}
+	var (
+		to    = *tx.To
+		value = tx.Value
+	)
+	if value == nil {
+		value = new(big.Int)
+	}
+	batch, err := rules.CheckTransfer(tx.From, to, value, tx.Data, tx.AccessList)
+	if err != nil {
+		return err
+	}
+	amount := value
+	if batch != nil {
+		amount = core.BatchTransferTotal(batch)
+	}
+	if r.OffSessionBudget != nil && rules.OffSessionCharge(tx.From, amount).Cmp(r.OffSessionBudget) > 0 {
+		return core.ErrOverMaxOffSessionBudget
+	}
+	if r.TxRemaining != nil && *r.TxRemaining == 0 && !rules.RateLimitExempt(tx.From, to, tx.Data) {
+		return core.ErrRateLimit
+	}
+	return nil
+}
@@ -2,10 +2,14 @@ package rawdb
 
 import (
 	"encoding/binary"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 var olivetumPeriodPrefix = []byte("olivetum-period-")
@@ -53,3 +57,624 @@ func ReadOlivetumFinalizedHeight(db ethdb.KeyValueReader) (uint64, bool) {
 	}
 	return binary.BigEndian.Uint64(data), true
 }
+
+var olivetumMemoPrefix = []byte("olivetum-memo-")
+
+// OlivetumMemoEntry locates a transfer carrying a given memo.
+type OlivetumMemoEntry struct {
+	Number uint64
+	TxHash common.Hash
+}
+
+func olivetumMemoKey(memo []byte, number uint64, txHash common.Hash) []byte {
+	key := append(append([]byte{}, olivetumMemoPrefix...), crypto.Keccak256(memo)...)
+	key = binary.BigEndian.AppendUint64(key, number)
+	return append(key, txHash.Bytes()...)
+}
+
+// WriteOlivetumMemoEntry indexes a transfer memo by the block number and hash
+// of the carrying transaction.
+func WriteOlivetumMemoEntry(db ethdb.KeyValueWriter, memo []byte, number uint64, txHash common.Hash) {
+	if err := db.Put(olivetumMemoKey(memo, number, txHash), nil); err != nil {
+		log.Crit("Failed to store Olivetum transfer memo", "tx", txHash, "err", err)
+	}
+}
+
+// IterateOlivetumMemoEntries calls fn for the index entries of the memo in
+// ascending block order, starting at block from, until fn returns false.
+// Entries written by blocks that were later reorged out are included; callers
+// must check them against the transaction lookup index.
+func IterateOlivetumMemoEntries(db ethdb.Iteratee, memo []byte, from uint64, fn func(OlivetumMemoEntry) bool) {
+	prefix := append(append([]byte{}, olivetumMemoPrefix...), crypto.Keccak256(memo)...)
+	it := db.NewIterator(prefix, binary.BigEndian.AppendUint64(nil, from))
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8+common.HashLength {
+			continue
+		}
+		entry := OlivetumMemoEntry{
+			Number: binary.BigEndian.Uint64(key[len(prefix):]),
+			TxHash: common.BytesToHash(key[len(prefix)+8:]),
+		}
+		if !fn(entry) {
+			return
+		}
+	}
+}
+
+var olivetumPayoutLogsPrefix = []byte("olivetum-payout-logs-")
+
+func olivetumPayoutLogsKey(number uint64, hash common.Hash) []byte {
+	key := binary.BigEndian.AppendUint64(append([]byte{}, olivetumPayoutLogsPrefix...), number)
+	return append(key, hash.Bytes()...)
+}
+
+// WriteOlivetumPayoutLogs stores the logs emitted outside of transactions by
+// the automatic dividend payouts of a block.
+func WriteOlivetumPayoutLogs(db ethdb.KeyValueWriter, hash common.Hash, number uint64, logs []*types.Log) {
+	data, err := rlp.EncodeToBytes(logs)
+	if err != nil {
+		log.Crit("Failed to encode Olivetum payout logs", "err", err)
+	}
+	if err := db.Put(olivetumPayoutLogsKey(number, hash), data); err != nil {
+		log.Crit("Failed to store Olivetum payout logs", "hash", hash, "err", err)
+	}
+}
+
+// HasOlivetumPayoutLogs reports whether the automatic dividend payouts of a
+// block were recorded.
+func HasOlivetumPayoutLogs(db ethdb.KeyValueReader, hash common.Hash, number uint64) bool {
+	ok, _ := db.Has(olivetumPayoutLogsKey(number, hash))
+	return ok
+}
+
+// ReadOlivetumPayoutLogs loads the automatic dividend payout logs of a block.
+// Only the consensus fields and the block number and hash are set.
+func ReadOlivetumPayoutLogs(db ethdb.KeyValueReader, hash common.Hash, number uint64) []*types.Log {
+	data, err := db.Get(olivetumPayoutLogsKey(number, hash))
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	var logs []*types.Log
+	if err := rlp.DecodeBytes(data, &logs); err != nil {
+		log.Error("Invalid Olivetum payout logs", "hash", hash, "err", err)
+		return nil
+	}
+	for _, l := range logs {
+		l.BlockNumber = number
+		l.BlockHash = hash
+	}
+	return logs
+}
+
+var (
+	olivetumHolderPrefix     = []byte("olivetum-holder-")
+	olivetumHolderUndoPrefix = []byte("olivetum-holder-undo-")
+	olivetumHolderHeadKey    = []byte("olivetum-holder-head")
+)
+
+// OlivetumHolderEntry is the dividend position of an account recorded by the
+// holder index at the given block, the last one that changed it. Pending
+// holdings mature without a block touching the account, so the split between
+// held and pending goes stale; only the membership of the index is kept
+// current, and the position itself has to be read from the state.
+type OlivetumHolderEntry struct {
+	Held    *big.Int
+	Pending *big.Int
+	Number  uint64
+}
+
+// OlivetumHolderUndo restores the index entry of an account, or removes it if
+// Prev is nil, when the block that changed it is rolled back.
+type OlivetumHolderUndo struct {
+	Addr common.Address
+	Prev *OlivetumHolderEntry `rlp:"nil"`
+}
+
+func olivetumHolderKey(addr common.Address) []byte {
+	return append(append([]byte{}, olivetumHolderPrefix...), addr.Bytes()...)
+}
+
+func olivetumHolderUndoKey(number uint64, hash common.Hash) []byte {
+	key := binary.BigEndian.AppendUint64(append([]byte{}, olivetumHolderUndoPrefix...), number)
+	return append(key, hash.Bytes()...)
+}
+
+// ReadOlivetumHolder loads the holder index entry of an account.
+func ReadOlivetumHolder(db ethdb.KeyValueReader, addr common.Address) *OlivetumHolderEntry {
+	data, err := db.Get(olivetumHolderKey(addr))
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	entry := new(OlivetumHolderEntry)
+	if err := rlp.DecodeBytes(data, entry); err != nil {
+		log.Error("Invalid Olivetum holder entry", "addr", addr, "err", err)
+		return nil
+	}
+	return entry
+}
+
+// WriteOlivetumHolder stores the holder index entry of an account.
+func WriteOlivetumHolder(db ethdb.KeyValueWriter, addr common.Address, entry *OlivetumHolderEntry) {
+	data, err := rlp.EncodeToBytes(entry)
+	if err != nil {
+		log.Crit("Failed to encode Olivetum holder entry", "err", err)
+	}
+	if err := db.Put(olivetumHolderKey(addr), data); err != nil {
+		log.Crit("Failed to store Olivetum holder entry", "addr", addr, "err", err)
+	}
+}
+
+// DeleteOlivetumHolder removes an account from the holder index.
+func DeleteOlivetumHolder(db ethdb.KeyValueWriter, addr common.Address) {
+	if err := db.Delete(olivetumHolderKey(addr)); err != nil {
+		log.Crit("Failed to delete Olivetum holder entry", "addr", addr, "err", err)
+	}
+}
+
+// IterateOlivetumHolders calls fn for the holder index entries in address
+// order, starting at start, until fn returns false.
+func IterateOlivetumHolders(db ethdb.Iteratee, start common.Address, fn func(common.Address, *OlivetumHolderEntry) bool) {
+	it := db.NewIterator(olivetumHolderPrefix, start.Bytes())
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(olivetumHolderPrefix)+common.AddressLength {
+			continue
+		}
+		entry := new(OlivetumHolderEntry)
+		if err := rlp.DecodeBytes(it.Value(), entry); err != nil {
+			continue
+		}
+		if !fn(common.BytesToAddress(key[len(olivetumHolderPrefix):]), entry) {
+			return
+		}
+	}
+}
+
+// ReadOlivetumHolderUndo loads the rollback record of an indexed block.
+func ReadOlivetumHolderUndo(db ethdb.KeyValueReader, hash common.Hash, number uint64) ([]OlivetumHolderUndo, bool) {
+	data, err := db.Get(olivetumHolderUndoKey(number, hash))
+	if err != nil {
+		return nil, false
+	}
+	var undo []OlivetumHolderUndo
+	if err := rlp.DecodeBytes(data, &undo); err != nil {
+		log.Error("Invalid Olivetum holder undo record", "hash", hash, "err", err)
+		return nil, false
+	}
+	return undo, true
+}
+
+// WriteOlivetumHolderUndo stores the rollback record of an indexed block.
+func WriteOlivetumHolderUndo(db ethdb.KeyValueWriter, hash common.Hash, number uint64, undo []OlivetumHolderUndo) {
+	data, err := rlp.EncodeToBytes(undo)
+	if err != nil {
+		log.Crit("Failed to encode Olivetum holder undo record", "err", err)
+	}
+	if err := db.Put(olivetumHolderUndoKey(number, hash), data); err != nil {
+		log.Crit("Failed to store Olivetum holder undo record", "hash", hash, "err", err)
+	}
+}
+
+// DeleteOlivetumHolderUndos removes the rollback records of all blocks with the
+// given number.
+func DeleteOlivetumHolderUndos(db ethdb.KeyValueStore, number uint64) {
+	prefix := binary.BigEndian.AppendUint64(append([]byte{}, olivetumHolderUndoPrefix...), number)
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		if err := db.Delete(it.Key()); err != nil {
+			log.Crit("Failed to delete Olivetum holder undo record", "err", err)
+		}
+	}
+}
+
+// ReadOlivetumHolderIndexHead returns the last block applied to the holder
+// index.
+func ReadOlivetumHolderIndexHead(db ethdb.KeyValueReader) (common.Hash, uint64, bool) {
+	data, err := db.Get(olivetumHolderHeadKey)
+	if err != nil || len(data) != common.HashLength+8 {
+		return common.Hash{}, 0, false
+	}
+	return common.BytesToHash(data[:common.HashLength]), binary.BigEndian.Uint64(data[common.HashLength:]), true
+}
+
+// WriteOlivetumHolderIndexHead records the last block applied to the holder
+// index.
+func WriteOlivetumHolderIndexHead(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	data := binary.BigEndian.AppendUint64(append([]byte{}, hash.Bytes()...), number)
+	if err := db.Put(olivetumHolderHeadKey, data); err != nil {
+		log.Crit("Failed to store Olivetum holder index head", "err", err)
+	}
+}
+
+var olivetumIndexSeedPrefix = []byte("olivetum-seed-")
+
+// OlivetumIndexSeed is the progress of the one-time seeding of a node-side
+// index from the state snapshot. Next is the account hash to resume from and
+// Missing counts the accounts skipped for lack of a preimage.
+type OlivetumIndexSeed struct {
+	Next    common.Hash
+	Done    bool
+	Missing uint64
+}
+
+// ReadOlivetumIndexSeed loads the seeding progress of the named index, or nil
+// if seeding has not started.
+func ReadOlivetumIndexSeed(db ethdb.KeyValueReader, name string) *OlivetumIndexSeed {
+	data, err := db.Get(append(append([]byte{}, olivetumIndexSeedPrefix...), name...))
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	seed := new(OlivetumIndexSeed)
+	if err := rlp.DecodeBytes(data, seed); err != nil {
+		log.Error("Invalid Olivetum index seed progress", "index", name, "err", err)
+		return nil
+	}
+	return seed
+}
+
+// WriteOlivetumIndexSeed stores the seeding progress of the named index.
+func WriteOlivetumIndexSeed(db ethdb.KeyValueWriter, name string, seed *OlivetumIndexSeed) {
+	data, err := rlp.EncodeToBytes(seed)
+	if err != nil {
+		log.Crit("Failed to encode Olivetum index seed progress", "err", err)
+	}
+	if err := db.Put(append(append([]byte{}, olivetumIndexSeedPrefix...), name...), data); err != nil {
+		log.Crit("Failed to store Olivetum index seed progress", "index", name, "err", err)
+	}
+}
+
+var olivetumEconomyPrefix = []byte("olivetum-economy-")
+
+// OlivetumEconomyRecord holds the economy counters of a canonical block.
+type OlivetumEconomyRecord struct {
+	Minted          *big.Int
+	Burned          *big.Int
+	BurnedTransfers *big.Int
+	BurnedGas       *big.Int
+	BurnedRewards   *big.Int
+	MinerBurnShare  *big.Int
+	DividendsMinted *big.Int
+	BurnRate        uint64
+	DividendRate    uint64
+	Locked          *big.Int `rlp:"optional"`
+}
+
+func olivetumEconomyKey(number uint64) []byte {
+	return binary.BigEndian.AppendUint64(append([]byte{}, olivetumEconomyPrefix...), number)
+}
+
+// ReadOlivetumEconomyRecord loads the economy record of a canonical block.
+func ReadOlivetumEconomyRecord(db ethdb.KeyValueReader, number uint64) *OlivetumEconomyRecord {
+	data, err := db.Get(olivetumEconomyKey(number))
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	record := new(OlivetumEconomyRecord)
+	if err := rlp.DecodeBytes(data, record); err != nil {
+		log.Error("Invalid Olivetum economy record", "number", number, "err", err)
+		return nil
+	}
+	return record
+}
+
+// WriteOlivetumEconomyRecord stores the economy record of a canonical block.
+func WriteOlivetumEconomyRecord(db ethdb.KeyValueWriter, number uint64, record *OlivetumEconomyRecord) {
+	data, err := rlp.EncodeToBytes(record)
+	if err != nil {
+		log.Crit("Failed to encode Olivetum economy record", "err", err)
+	}
+	if err := db.Put(olivetumEconomyKey(number), data); err != nil {
+		log.Crit("Failed to store Olivetum economy record", "number", number, "err", err)
+	}
+}
+
+// DeleteOlivetumEconomyRecord removes the economy record of a block.
+func DeleteOlivetumEconomyRecord(db ethdb.KeyValueWriter, number uint64) {
+	if err := db.Delete(olivetumEconomyKey(number)); err != nil {
+		log.Crit("Failed to delete Olivetum economy record", "number", number, "err", err)
+	}
+}
+
+var (
+	olivetumRichBalancePrefix = []byte("olivetum-richbal-")
+	olivetumRichSortPrefix    = []byte("olivetum-richsort-")
+	olivetumRichUndoPrefix    = []byte("olivetum-richundo-")
+	olivetumRichHeadKey       = []byte("olivetum-richhead")
+)
+
+// OlivetumRichUndo restores the indexed balance of an account, or removes it
+// if Prev is nil, when the block that changed it is rolled back.
+type OlivetumRichUndo struct {
+	Addr common.Address
+	Prev *big.Int `rlp:"nil"`
+}
+
+func olivetumRichBalanceKey(addr common.Address) []byte {
+	return append(append([]byte{}, olivetumRichBalancePrefix...), addr.Bytes()...)
+}
+
+// olivetumRichSortKey orders accounts by descending balance, then address.
+func olivetumRichSortKey(addr common.Address, balance *big.Int) []byte {
+	var inv [32]byte
+	balance.FillBytes(inv[:])
+	for i := range inv {
+		inv[i] = ^inv[i]
+	}
+	key := append(append([]byte{}, olivetumRichSortPrefix...), inv[:]...)
+	return append(key, addr.Bytes()...)
+}
+
+func olivetumRichUndoKey(number uint64, hash common.Hash) []byte {
+	key := binary.BigEndian.AppendUint64(append([]byte{}, olivetumRichUndoPrefix...), number)
+	return append(key, hash.Bytes()...)
+}
+
+// ReadOlivetumRichBalance returns the indexed balance of an account, or nil
+// if it is not in the rich list index.
+func ReadOlivetumRichBalance(db ethdb.KeyValueReader, addr common.Address) *big.Int {
+	data, err := db.Get(olivetumRichBalanceKey(addr))
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	return new(big.Int).SetBytes(data)
+}
+
+// WriteOlivetumRichBalance moves an account to its new position in the rich
+// list index. prev is the currently indexed balance, nil if none.
+func WriteOlivetumRichBalance(db ethdb.KeyValueWriter, addr common.Address, prev, balance *big.Int) {
+	if prev != nil {
+		DeleteOlivetumRichBalance(db, addr, prev)
+	}
+	if err := db.Put(olivetumRichBalanceKey(addr), balance.Bytes()); err != nil {
+		log.Crit("Failed to store Olivetum rich list balance", "addr", addr, "err", err)
+	}
+	if err := db.Put(olivetumRichSortKey(addr, balance), nil); err != nil {
+		log.Crit("Failed to store Olivetum rich list entry", "addr", addr, "err", err)
+	}
+}
+
+// DeleteOlivetumRichBalance removes an account indexed with the given balance
+// from the rich list index.
+func DeleteOlivetumRichBalance(db ethdb.KeyValueWriter, addr common.Address, balance *big.Int) {
+	if err := db.Delete(olivetumRichBalanceKey(addr)); err != nil {
+		log.Crit("Failed to delete Olivetum rich list balance", "addr", addr, "err", err)
+	}
+	if err := db.Delete(olivetumRichSortKey(addr, balance)); err != nil {
+		log.Crit("Failed to delete Olivetum rich list entry", "addr", addr, "err", err)
+	}
+}
+
+// IterateOlivetumRichList calls fn for the indexed accounts in descending
+// balance order until fn returns false.
+func IterateOlivetumRichList(db ethdb.Iteratee, fn func(common.Address, *big.Int) bool) {
+	it := db.NewIterator(olivetumRichSortPrefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(olivetumRichSortPrefix)+32+common.AddressLength {
+			continue
+		}
+		var inv [32]byte
+		copy(inv[:], key[len(olivetumRichSortPrefix):])
+		for i := range inv {
+			inv[i] = ^inv[i]
+		}
+		if !fn(common.BytesToAddress(key[len(olivetumRichSortPrefix)+32:]), new(big.Int).SetBytes(inv[:])) {
+			return
+		}
+	}
+}
+
+// ReadOlivetumRichUndo loads the rollback record of a block applied to the
+// rich list index.
+func ReadOlivetumRichUndo(db ethdb.KeyValueReader, hash common.Hash, number uint64) ([]OlivetumRichUndo, bool) {
+	data, err := db.Get(olivetumRichUndoKey(number, hash))
+	if err != nil {
+		return nil, false
+	}
+	var undo []OlivetumRichUndo
+	if err := rlp.DecodeBytes(data, &undo); err != nil {
+		log.Error("Invalid Olivetum rich list undo record", "hash", hash, "err", err)
+		return nil, false
+	}
+	return undo, true
+}
+
+// WriteOlivetumRichUndo stores the rollback record of a block applied to the
+// rich list index.
+func WriteOlivetumRichUndo(db ethdb.KeyValueWriter, hash common.Hash, number uint64, undo []OlivetumRichUndo) {
+	data, err := rlp.EncodeToBytes(undo)
+	if err != nil {
+		log.Crit("Failed to encode Olivetum rich list undo record", "err", err)
+	}
+	if err := db.Put(olivetumRichUndoKey(number, hash), data); err != nil {
+		log.Crit("Failed to store Olivetum rich list undo record", "hash", hash, "err", err)
+	}
+}
+
+// DeleteOlivetumRichUndos removes the rich list rollback records of all blocks
+// with the given number.
+func DeleteOlivetumRichUndos(db ethdb.KeyValueStore, number uint64) {
+	prefix := binary.BigEndian.AppendUint64(append([]byte{}, olivetumRichUndoPrefix...), number)
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		if err := db.Delete(it.Key()); err != nil {
+			log.Crit("Failed to delete Olivetum rich list undo record", "err", err)
+		}
+	}
+}
+
+// ReadOlivetumRichIndexHead returns the last block applied to the rich list
+// index.
+func ReadOlivetumRichIndexHead(db ethdb.KeyValueReader) (common.Hash, uint64, bool) {
+	data, err := db.Get(olivetumRichHeadKey)
+	if err != nil || len(data) != common.HashLength+8 {
+		return common.Hash{}, 0, false
+	}
+	return common.BytesToHash(data[:common.HashLength]), binary.BigEndian.Uint64(data[common.HashLength:]), true
+}
+
+// WriteOlivetumRichIndexHead records the last block applied to the rich list
+// index.
+func WriteOlivetumRichIndexHead(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	data := binary.BigEndian.AppendUint64(append([]byte{}, hash.Bytes()...), number)
+	if err := db.Put(olivetumRichHeadKey, data); err != nil {
+		log.Crit("Failed to store Olivetum rich list index head", "err", err)
+	}
+}
+
+var (
+	olivetumAccountPrefix     = []byte("olivetum-acct-")
+	olivetumAccountUndoPrefix = []byte("olivetum-acctundo-")
+	olivetumAccountHeadKey    = []byte("olivetum-accthead")
+)
+
+// OlivetumAccountEconomy holds the economy figures of an account in a block.
+type OlivetumAccountEconomy struct {
+	BurnedOnSend     *big.Int // transfer burns charged to the account as sender
+	MinerBurnShare   *big.Int // share of transfer and gas burns paid to the account as miner
+	GasFees          *big.Int // gas fees paid by the account as sender
+	DividendsClaimed *big.Int // dividends paid to the account, claimed or distributed
+}
+
+func olivetumAccountKey(addr common.Address, number uint64) []byte {
+	key := append(append([]byte{}, olivetumAccountPrefix...), addr.Bytes()...)
+	return binary.BigEndian.AppendUint64(key, number)
+}
+
+func olivetumAccountUndoKey(number uint64, hash common.Hash) []byte {
+	key := binary.BigEndian.AppendUint64(append([]byte{}, olivetumAccountUndoPrefix...), number)
+	return append(key, hash.Bytes()...)
+}
+
+// ReadOlivetumAccountEconomy returns the economy figures of an account in the
+// canonical block with the given number, or nil if none are indexed.
+func ReadOlivetumAccountEconomy(db ethdb.KeyValueReader, addr common.Address, number uint64) *OlivetumAccountEconomy {
+	data, err := db.Get(olivetumAccountKey(addr, number))
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	entry := new(OlivetumAccountEconomy)
+	if err := rlp.DecodeBytes(data, entry); err != nil {
+		log.Error("Invalid Olivetum account economy entry", "addr", addr, "number", number, "err", err)
+		return nil
+	}
+	return entry
+}
+
+// WriteOlivetumAccountEconomy stores the economy figures of an account in the
+// canonical block with the given number.
+func WriteOlivetumAccountEconomy(db ethdb.KeyValueWriter, addr common.Address, number uint64, entry *OlivetumAccountEconomy) {
+	data, err := rlp.EncodeToBytes(entry)
+	if err != nil {
+		log.Crit("Failed to encode Olivetum account economy entry", "err", err)
+	}
+	if err := db.Put(olivetumAccountKey(addr, number), data); err != nil {
+		log.Crit("Failed to store Olivetum account economy entry", "addr", addr, "err", err)
+	}
+}
+
+// DeleteOlivetumAccountEconomy removes the economy figures of an account in the
+// block with the given number.
+func DeleteOlivetumAccountEconomy(db ethdb.KeyValueWriter, addr common.Address, number uint64) {
+	if err := db.Delete(olivetumAccountKey(addr, number)); err != nil {
+		log.Crit("Failed to delete Olivetum account economy entry", "addr", addr, "err", err)
+	}
+}
+
+// IterateOlivetumAccountEconomy calls fn in block order with the economy
+// figures of an account in the blocks from..to, until fn returns false.
+func IterateOlivetumAccountEconomy(db ethdb.Iteratee, addr common.Address, from, to uint64, fn func(uint64, *OlivetumAccountEconomy) bool) {
+	prefix := append(append([]byte{}, olivetumAccountPrefix...), addr.Bytes()...)
+	it := db.NewIterator(prefix, binary.BigEndian.AppendUint64(nil, from))
+	defer it.Release()
+
+	for it.Next() {
+		key := it.Key()
+		if len(key) != len(prefix)+8 {
+			continue
+		}
+		number := binary.BigEndian.Uint64(key[len(prefix):])
+		if number > to {
+			return
+		}
+		entry := new(OlivetumAccountEconomy)
+		if err := rlp.DecodeBytes(it.Value(), entry); err != nil {
+			log.Error("Invalid Olivetum account economy entry", "addr", addr, "number", number, "err", err)
+			continue
+		}
+		if !fn(number, entry) {
+			return
+		}
+	}
+}
+
+// ReadOlivetumAccountUndo loads the accounts indexed for a block of the
+// account economy index.
+func ReadOlivetumAccountUndo(db ethdb.KeyValueReader, hash common.Hash, number uint64) ([]common.Address, bool) {
+	data, err := db.Get(olivetumAccountUndoKey(number, hash))
+	if err != nil {
+		return nil, false
+	}
+	var undo []common.Address
+	if err := rlp.DecodeBytes(data, &undo); err != nil {
+		log.Error("Invalid Olivetum account undo record", "hash", hash, "err", err)
+		return nil, false
+	}
+	return undo, true
+}
+
+// WriteOlivetumAccountUndo stores the accounts indexed for a block of the
+// account economy index, so their entries can be removed on rollback.
+func WriteOlivetumAccountUndo(db ethdb.KeyValueWriter, hash common.Hash, number uint64, undo []common.Address) {
+	data, err := rlp.EncodeToBytes(undo)
+	if err != nil {
+		log.Crit("Failed to encode Olivetum account undo record", "err", err)
+	}
+	if err := db.Put(olivetumAccountUndoKey(number, hash), data); err != nil {
+		log.Crit("Failed to store Olivetum account undo record", "hash", hash, "err", err)
+	}
+}
+
+// DeleteOlivetumAccountUndos removes the account economy rollback records of
+// all blocks with the given number.
+func DeleteOlivetumAccountUndos(db ethdb.KeyValueStore, number uint64) {
+	prefix := binary.BigEndian.AppendUint64(append([]byte{}, olivetumAccountUndoPrefix...), number)
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		if err := db.Delete(it.Key()); err != nil {
+			log.Crit("Failed to delete Olivetum account undo record", "err", err)
+		}
+	}
+}
+
+// ReadOlivetumAccountIndexHead returns the last block applied to the account
+// economy index.
+func ReadOlivetumAccountIndexHead(db ethdb.KeyValueReader) (common.Hash, uint64, bool) {
+	data, err := db.Get(olivetumAccountHeadKey)
+	if err != nil || len(data) != common.HashLength+8 {
+		return common.Hash{}, 0, false
+	}
+	return common.BytesToHash(data[:common.HashLength]), binary.BigEndian.Uint64(data[common.HashLength:]), true
+}
+
+// WriteOlivetumAccountIndexHead records the last block applied to the account
+// economy index.
+func WriteOlivetumAccountIndexHead(db ethdb.KeyValueWriter, hash common.Hash, number uint64) {
+	data := binary.BigEndian.AppendUint64(append([]byte{}, hash.Bytes()...), number)
+	if err := db.Put(olivetumAccountHeadKey, data); err != nil {
+		log.Crit("Failed to store Olivetum account index head", "err", err)
+	}
+}
@@ -68,12 +68,7 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		gp          = new(GasPool).AddGas(block.GasLimit())
 	)
 
-	LoadBlockPeriod(statedb)
-	LoadMinTxAmount(statedb)
-	LoadTxRateLimit(statedb)
-	LoadOffSessionTxRate(statedb)
-	LoadOffSessionMaxPerTx(statedb)
-	LoadSessionTzOffset(statedb)
+	LoadOlivetumRuntime(statedb)
 
 	// Mutate the block and state according to any hard-fork specs
 	isDAOSupport := p.config.IsEnabled(p.config.GetEthashEIP779Transition, block.Number())
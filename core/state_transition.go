@@ -17,10 +17,10 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
-	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	cmath "github.com/ethereum/go-ethereum/common/math"
@@ -281,6 +281,47 @@ func (st *StateTransition) buyGas() error {
 	return nil
 }
 
+// burnTransfer charges the transfer burn on amount to the sender, credits the
+// miner share to the coinbase and returns the burned amount, which the caller
+// deducts from the value delivered to the recipient.
+func (st *StateTransition) burnTransfer(from common.Address, amount *big.Int, blockTimestamp uint64) *big.Int {
+	burnRate := GetBurnRate(st.state)
+	if burnRate == 0 {
+		return new(big.Int)
+	}
+	burn := new(big.Int).Mul(amount, big.NewInt(int64(burnRate)))
+	burn.Div(burn, big.NewInt(10000))
+	if burn.Sign() == 0 {
+		return burn
+	}
+	burnU256, _ := uint256.FromBig(burn)
+	st.state.SubBalance(from, burnU256)
+	burnedNet := new(big.Int).Set(burn)
+	minerShare := new(big.Int)
+	shareActive := true
+	if fork := params.GetBurnShareForkBlock(); fork != nil && fork.Sign() > 0 && st.evm.Context.BlockNumber != nil {
+		shareActive = st.evm.Context.BlockNumber.Cmp(fork) >= 0
+	}
+	if shareActive {
+		minerShare.Mul(burn, big.NewInt(int64(MinerBurnShareBps)))
+		minerShare.Div(minerShare, big.NewInt(10000))
+		if minerShare.Sign() > 0 {
+			minerShareU256, _ := uint256.FromBig(minerShare)
+			st.state.AddBalance(st.evm.Context.Coinbase, minerShareU256)
+			AddHolding(st.state, st.evm.Context.Coinbase, minerShare, blockTimestamp)
+			burnedNet.Sub(burnedNet, minerShare)
+		}
+	}
+	if burnedNet.Sign() > 0 && isEconomyForkActive(st.evm.Context.BlockNumber) {
+		AddTotalBurned(st.state, burnedNet)
+		AddTotalBurnedTransfers(st.state, burnedNet)
+		if minerShare.Sign() > 0 {
+			AddTotalMinerBurnShare(st.state, minerShare)
+		}
+	}
+	return burn
+}
+
 func (st *StateTransition) preCheck() error {
 	// Only check transactions that are not fake
 	msg := st.msg
@@ -306,14 +347,14 @@ func (st *StateTransition) preCheck() error {
 		}
 	}
 	if isOlivetum {
-		if msg.To == nil {
-			return fmt.Errorf("%w", ErrContractCreationDisabled)
-		}
-		if msg.From == *msg.To && !allowSelfTransfers {
-			return fmt.Errorf("%w", ErrSelfTransfer)
-		}
-		if msg.To != nil && !IsAuthorizedManagementTx(msg.From, *msg.To) {
-			return ErrUnauthorizedManagementTx
+		if err := st.olivetumRules().CheckParties(msg.From, msg.To); err != nil {
+			if errors.Is(err, ErrAddressFrozen) {
+				return fmt.Errorf("%w: address %v", err, msg.From.Hex())
+			}
+			if errors.Is(err, ErrRecipientFrozen) {
+				return fmt.Errorf("%w: address %v", err, msg.To.Hex())
+			}
+			return err
 		}
 	}
 	// Make sure that transaction gasFeeCap is greater than the baseFee (post london)
@@ -449,66 +490,29 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 		return nil, fmt.Errorf("%w", ErrContractCreationDisabled)
 	}
 
-	var blockTimestamp uint64
+	var (
+		blockTimestamp uint64
+		batch          []params.BatchTransferEntry
+	)
 	if isOlivetum {
-		blockTimestamp = uint64(st.evm.Context.Time)
-		if msg.To != nil {
-			if err := ValidateOlivetumTxPayload(msg.From, *msg.To, msg.Value, msg.Data, msg.AccessList, isEconomyForkActive(st.evm.Context.BlockNumber)); err != nil {
-				return nil, err
-			}
-		}
+		rules := st.olivetumRules()
+		blockTimestamp = rules.Time
 
-		if msg.Value.Sign() >= 0 {
-			min := params.GetMinTxAmount()
-			if msg.Value.Cmp(min) < 0 {
-				var exemptFrom, exemptTo bool
-				if isEconomyForkActive(st.evm.Context.BlockNumber) {
-					exemptFrom = params.IsMinTxAmountExemptSender(msg.From)
-					exemptTo = msg.To != nil && params.IsMinTxAmountExemptRecipient(*msg.To)
-				} else {
-					exemptFrom = params.IsMinTxAmountExempt(msg.From)
-					exemptTo = msg.To != nil && params.IsMinTxAmountExempt(*msg.To)
-				}
-				if !exemptFrom && !exemptTo {
-					return nil, fmt.Errorf("transaction value below minimum")
-				}
-			}
+		var err error
+		if batch, err = rules.CheckTransfer(msg.From, *msg.To, msg.Value, msg.Data, msg.AccessList); err != nil {
+			return nil, err
 		}
-
-		if !IsSession(blockTimestamp) {
-			maxPerTx := params.GetOffSessionMaxPerTx()
-			if msg.Value.Cmp(maxPerTx) > 0 {
-				return nil, ErrOverMaxOffSession
-			}
-			if msg.Value.Sign() > 0 && isEconomyForkActive(st.evm.Context.BlockNumber) {
-				if err := UpdateOffSessionBudget(st.state, msg.From, msg.Value, blockTimestamp); err != nil {
-					return nil, err
-				}
-			}
-		}
-
-		skipRateLimit := false
-		if msg.To != nil && IsTxRateLimitExempt(msg.From, *msg.To, msg.Data) {
-			skipRateLimit = true
+		amount := msg.Value
+		if batch != nil {
+			amount = BatchTransferTotal(batch)
 		}
-		if msg.From == params.TxRateLimitAdmin && !isEconomyForkActive(st.evm.Context.BlockNumber) {
-			skipRateLimit = true
+		if err := rules.ChargeOffSessionBudget(st.state, msg.From, amount); err != nil {
+			return nil, err
 		}
-		if !skipRateLimit {
-			epoch := loadTxRateEpoch(st.state)
-			usage := GetTxRateUsage(st.state, msg.From)
-			if usage.Epoch != epoch || blockTimestamp-usage.Start >= uint64(time.Hour/time.Second) {
-				usage = TxRateUsage{Start: blockTimestamp, Epoch: epoch}
-			}
-			limit := params.GetTxRateLimit()
-			if !IsSession(blockTimestamp) {
-				limit = params.GetOffSessionTxRate()
-			}
-			if usage.Count >= limit {
-				return nil, ErrRateLimit
+		if !rules.RateLimitExempt(msg.From, *msg.To, msg.Data) {
+			if err := rules.ChargeTxRate(st.state, msg.From); err != nil {
+				return nil, err
 			}
-			usage.Count++
-			SetTxRateUsage(st.state, msg.From, usage)
 		}
 	}
 
@@ -517,6 +521,9 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	if err != nil {
 		return nil, err
 	}
+	if batch != nil {
+		gas += BatchTransferGas(len(batch))
+	}
 	if st.gasRemaining < gas {
 		return nil, fmt.Errorf("%w: have %d, want %d", ErrIntrinsicGas, st.gasRemaining, gas)
 	}
@@ -530,41 +537,18 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 	if !value.IsZero() && !st.evm.Context.CanTransfer(st.state, msg.From, value) {
 		return nil, fmt.Errorf("%w: address %v", ErrInsufficientFundsForTransfer, msg.From.Hex())
 	}
+	if batch != nil {
+		total, overflow := uint256.FromBig(BatchTransferTotal(batch))
+		if overflow || !st.evm.Context.CanTransfer(st.state, msg.From, total) {
+			return nil, fmt.Errorf("%w: address %v", ErrInsufficientFundsForTransfer, msg.From.Hex())
+		}
+	}
 
 	if isOlivetum && msg.Value.Sign() > 0 {
 		RemoveHolding(st.state, msg.From, msg.Value, blockTimestamp)
-		burnRate := GetBurnRate(st.state)
-		if burnRate > 0 {
-			burn := new(big.Int).Mul(msg.Value, big.NewInt(int64(burnRate)))
-			burn.Div(burn, big.NewInt(10000))
-			if burn.Sign() > 0 {
-				burnU256, _ := uint256.FromBig(burn)
-				st.state.SubBalance(msg.From, burnU256)
-				burnedNet := new(big.Int).Set(burn)
-				minerShare := new(big.Int)
-				shareActive := true
-				if fork := params.GetBurnShareForkBlock(); fork != nil && fork.Sign() > 0 && st.evm.Context.BlockNumber != nil {
-					shareActive = st.evm.Context.BlockNumber.Cmp(fork) >= 0
-				}
-				if shareActive {
-					minerShare.Mul(burn, big.NewInt(int64(MinerBurnShareBps)))
-					minerShare.Div(minerShare, big.NewInt(10000))
-					if minerShare.Sign() > 0 {
-						minerShareU256, _ := uint256.FromBig(minerShare)
-						st.state.AddBalance(st.evm.Context.Coinbase, minerShareU256)
-						AddHolding(st.state, st.evm.Context.Coinbase, minerShare, blockTimestamp)
-						burnedNet.Sub(burnedNet, minerShare)
-					}
-				}
-				if burnedNet.Sign() > 0 && isEconomyForkActive(st.evm.Context.BlockNumber) {
-					AddTotalBurned(st.state, burnedNet)
-					AddTotalBurnedTransfers(st.state, burnedNet)
-					if minerShare.Sign() > 0 {
-						AddTotalMinerBurnShare(st.state, minerShare)
-					}
-				}
-				value.Sub(value, burnU256)
-			}
+		if burn := st.burnTransfer(msg.From, msg.Value, blockTimestamp); burn.Sign() > 0 {
+			burnU256, _ := uint256.FromBig(burn)
+			value.Sub(value, burnU256)
 		}
 	}
 
@@ -597,6 +581,9 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 			if vmerr == nil && msg.To != nil {
 				AddHolding(st.state, *msg.To, value.ToBig(), blockTimestamp)
 			}
+			if vmerr == nil && batch != nil {
+				st.executeBatchTransfer(msg.From, batch, blockTimestamp)
+			}
 
 			if msg.To != nil && *msg.To == BurnContract && msg.From == BurnAdmin {
 				if msg.Value.Sign() != 0 {
@@ -695,6 +682,20 @@ func (st *StateTransition) TransitionDb() (*ExecutionResult, error) {
 					vmerr = vm.ErrExecutionReverted
 				}
 			}
+			if msg.To != nil && *msg.To == params.SessionCalendarContract && msg.From == params.SessionCalendarAdmin && params.SessionCalendarFork.Active(st.evm.Context.BlockNumber) {
+				if msg.Value.Sign() != 0 {
+					vmerr = vm.ErrExecutionReverted
+				} else if upd, ok := params.DecodeSessionCalendarUpdate(msg.Data); !ok || !ApplySessionCalendarUpdate(st.state, upd) {
+					vmerr = vm.ErrExecutionReverted
+				}
+			}
+			if msg.To != nil && *msg.To == params.AddressListContract && msg.From == params.AddressListAdmin && params.AddressListFork.Active(st.evm.Context.BlockNumber) {
+				if msg.Value.Sign() != 0 {
+					vmerr = vm.ErrExecutionReverted
+				} else if upd, ok := params.DecodeAddressListUpdate(msg.Data); !ok || !ApplyAddressListUpdate(st.state, upd) {
+					vmerr = vm.ErrExecutionReverted
+				}
+			}
 		}
 	}
 
@@ -795,6 +796,12 @@ func (st *StateTransition) blobGasUsed() uint64 {
 	return uint64(len(st.msg.BlobHashes) * vars.BlobTxBlobGasPerBlob)
 }
 
+// olivetumRules returns the Olivetum rules of the block being processed.
+func (st *StateTransition) olivetumRules() *OlivetumRules {
+	classes := func(addr common.Address) uint8 { return GetAddressClasses(st.state, addr) }
+	return NewOlivetumRules(st.evm.Context.BlockNumber, st.evm.Context.Time, classes)
+}
+
 func isEconomyForkActive(blockNumber *big.Int) bool {
 	fork := params.GetEconomyForkBlock()
 	return fork.Sign() > 0 && blockNumber != nil && blockNumber.Cmp(fork) >= 0
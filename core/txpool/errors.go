@@ -52,7 +52,7 @@ var (
 
 	// ErrUnderMinAmount is returned if a transaction's value is below the
 	// configured minimum amount.
-	ErrUnderMinAmount = errors.New("transaction value below minimum")
+	ErrUnderMinAmount = corepkg.ErrUnderMinTxAmount
 
 	// ErrOversizedData is returned if the input data of a transaction is greater
 	// than some meaningful limit a user might use. This is not a consensus error
@@ -88,11 +88,16 @@ var (
 	ErrTxAccessListNotAllowed = corepkg.ErrTxAccessListNotAllowed
 	ErrTxValueNotAllowed      = corepkg.ErrTxValueNotAllowed
 
+	// ErrAddressFrozen and ErrRecipientFrozen are returned if the sender or the
+	// recipient of a transaction is in the frozen address class.
+	ErrAddressFrozen   = corepkg.ErrAddressFrozen
+	ErrRecipientFrozen = corepkg.ErrRecipientFrozen
+
 	// ErrDividendNotEligible is returned if a dividend claim transaction is not
 	// eligible at the current time (no active round, outside window, or already claimed).
-	ErrDividendNotEligible = errors.New("dividend claim not eligible")
+	ErrDividendNotEligible = corepkg.ErrDividendNotEligible
 
 	// ErrDividendRoundTooSoon is returned when a new dividend round is triggered
 	// before the cooldown completes.
-	ErrDividendRoundTooSoon = errors.New("dividend round still cooling down")
+	ErrDividendRoundTooSoon = corepkg.ErrDividendRoundTooSoon
 )
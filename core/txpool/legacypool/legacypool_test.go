@@ -1289,6 +1289,53 @@ func TestAllowedTxSize(t *testing.T) {
 }
 
 // Tests that if transactions start being capped, transactions are also removed from 'all'
+// Tests that clearing the pool drops pending and queued transactions alike and
+// releases their accounts, so that they can be added again.
+func TestClear(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	other, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000))
+	testAddBalance(pool, crypto.PubkeyToAddress(other.PublicKey), big.NewInt(1000000))
+
+	txs := types.Transactions{
+		transaction(0, 100000, key),
+		transaction(1, 100000, key),
+		transaction(5, 100000, key),
+		transaction(3, 100000, other),
+	}
+	for i, err := range pool.addRemotesSync(txs) {
+		if err != nil {
+			t.Fatalf("tx %d: failed to add: %v", i, err)
+		}
+	}
+	if pending, queued := pool.Stats(); pending != 2 || queued != 2 {
+		t.Fatalf("pool stats: have %d pending %d queued, want 2 and 2", pending, queued)
+	}
+	pool.Clear()
+	if pending, queued := pool.Stats(); pending != 0 || queued != 0 {
+		t.Fatalf("cleared pool stats: have %d pending %d queued, want none", pending, queued)
+	}
+	if pool.Has(txs[0].Hash()) {
+		t.Fatalf("cleared transaction still tracked")
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+	// The accounts were released, so they can be reserved again
+	for i, err := range pool.addRemotesSync(txs[:1]) {
+		if err != nil {
+			t.Fatalf("tx %d: failed to add after clearing: %v", i, err)
+		}
+	}
+	if pending, _ := pool.Stats(); pending != 1 {
+		t.Fatalf("pending after re-adding: have %d want 1", pending)
+	}
+}
+
 func TestCapClearsFromAll(t *testing.T) {
 	t.Parallel()
 
@@ -1,15 +1,19 @@
 package txpool
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
 )
 
 func TestTxPoolRejectsCalldataForRegularTransfersAfterFork(t *testing.T) {
@@ -73,3 +77,42 @@ func TestTxPoolRejectsCalldataForDividendClaimsAfterFork(t *testing.T) {
 		t.Fatalf("expected ErrTxDataNotAllowed, got %v", err)
 	}
 }
+
+func TestTxPoolChargesBatchTotalOnlyAfterFork(t *testing.T) {
+	cfg := newOlivetumConfig(t)
+	signer := types.LatestSigner(cfg)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	statedb.SetBalance(from, uint256.NewInt(10))
+
+	// A payload that decodes as a batch moving 1000 to a single recipient.
+	data := make([]byte, params.BatchTransferEntryLength)
+	data[common.AddressLength-1] = 0x02
+	big.NewInt(1000).FillBytes(data[common.AddressLength:])
+
+	tx := types.MustSignNewTx(key, signer, &types.LegacyTx{Nonce: 0, To: &params.BatchTransferContract, Value: big.NewInt(1), Gas: 100000, GasPrice: big.NewInt(0), Data: data})
+	opts := &ValidationOptionsWithState{
+		State:               statedb,
+		UsedAndLeftSlots:    func(common.Address) (int, int) { return 0, 1 },
+		ExistingExpenditure: func(common.Address) *big.Int { return new(big.Int) },
+		ExistingCost:        func(common.Address, uint64) *big.Int { return nil },
+	}
+	// Before the batch fork the payload is a memo and only the value is charged.
+	opts.OlivetumRules = &core.OlivetumRules{Number: big.NewInt(1), MemoFork: true}
+	if err := ValidateTransactionWithState(tx, signer, opts); err != nil {
+		t.Fatalf("pre-fork transfer rejected: %v", err)
+	}
+	if got := opts.OlivetumRules.TransferValue(tx.To(), tx.Value(), tx.Data()); got.Cmp(tx.Value()) != 0 {
+		t.Fatalf("pre-fork transfer value: have %v, want %v", got, tx.Value())
+	}
+	// Once the fork is active the batch total is charged.
+	opts.OlivetumRules = &core.OlivetumRules{Number: big.NewInt(1), MemoFork: true, BatchFork: true}
+	if err := ValidateTransactionWithState(tx, signer, opts); !errors.Is(err, core.ErrInsufficientFunds) {
+		t.Fatalf("expected ErrInsufficientFunds, got %v", err)
+	}
+	if got := opts.OlivetumRules.TransferValue(tx.To(), tx.Value(), tx.Data()); got.Cmp(big.NewInt(1000)) != 0 {
+		t.Fatalf("batch transfer value: have %v, want 1000", got)
+	}
+}
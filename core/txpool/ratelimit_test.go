@@ -46,6 +46,8 @@ func (d *dummySubPool) Reset(oldHead, newHead *types.Header) {}
 
 func (d *dummySubPool) SetGasTip(tip *big.Int) {}
 
+func (d *dummySubPool) Clear() {}
+
 func (d *dummySubPool) Has(hash common.Hash) bool { return d.seen[hash] }
 
 func (d *dummySubPool) Get(common.Hash) *types.Transaction { return nil }
@@ -116,6 +116,9 @@ type SubPool interface {
 	// transaction, and drops all transactions below this threshold.
 	SetGasTip(tip *big.Int)
 
+	// Clear removes all tracked transactions from the subpool.
+	Clear()
+
 	// Has returns an indicator whether subpool has a transaction cached with the
 	// given hash.
 	Has(hash common.Hash) bool
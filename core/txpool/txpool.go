@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
-	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -93,10 +92,8 @@ type TxPool struct {
 
 	sync chan chan error // Testing / simulator channel to block until internal reset is done
 
-	rateLock      sync.Mutex
-	rateEpoch     uint64
-	rateCounters  map[common.Address]core.TxRateUsage
-	lastPoolNonce map[common.Address]uint64
+	rateLock  sync.Mutex
+	admitting map[common.Address]uint64 // Rate limited transactions admitted but not yet in a subpool
 
 	isOlivetum bool
 }
@@ -131,9 +128,7 @@ func New(gasTip uint64, chain BlockChain, subpools []SubPool) (*TxPool, error) {
 			return nil, err
 		}
 		pool.state = statedb
-		pool.rateEpoch = core.GetTxRateEpoch(statedb)
-		pool.rateCounters = make(map[common.Address]core.TxRateUsage)
-		pool.lastPoolNonce = make(map[common.Address]uint64)
+		pool.admitting = make(map[common.Address]uint64)
 	}
 	for i, subpool := range subpools {
 		if err := subpool.Init(gasTip, head, pool.reserver(i, subpool)); err != nil {
@@ -268,13 +263,6 @@ func (p *TxPool) loop(head *types.Header, chain BlockChain) {
 						p.stateLock.Lock()
 						p.state = statedb
 						p.stateLock.Unlock()
-						epoch := core.GetTxRateEpoch(statedb)
-						p.rateLock.Lock()
-						if epoch != p.rateEpoch {
-							p.rateEpoch = epoch
-							p.rateCounters = make(map[common.Address]core.TxRateUsage)
-						}
-						p.rateLock.Unlock()
 					}
 				}
 				// Busy marker injected, start a new subpool reset
@@ -343,6 +331,13 @@ func (p *TxPool) SetGasTip(tip *big.Int) {
 	}
 }
 
+// Clear removes all tracked transactions from the pools.
+func (p *TxPool) Clear() {
+	for _, subpool := range p.subpools {
+		subpool.Clear()
+	}
+}
+
 // Has returns an indicator whether the pool has a transaction cached with the
 // given hash.
 func (p *TxPool) Has(hash common.Hash) bool {
@@ -419,218 +414,166 @@ func (p *TxPool) Add(txs []*types.Transaction, local bool, sync bool) []error {
 		errsets[i] = p.subpools[i].Add(txsets[i], local, sync)
 	}
 	for i, split := range splits {
+		if admitted[i] != (common.Address{}) {
+			p.releaseAdmission(admitted[i])
+		}
 		if errs[i] != nil {
-			if admitted[i] != (common.Address{}) {
-				p.rollbackAdmission(admitted[i])
-				admitted[i] = common.Address{}
-			}
 			continue
 		}
 		// If the transaction was rejected by all subpools, mark it unsupported
 		if split == -1 {
 			errs[i] = core.ErrTxTypeNotSupported
-			if admitted[i] != (common.Address{}) {
-				p.rollbackAdmission(admitted[i])
-				admitted[i] = common.Address{}
-			}
 			continue
 		}
 		// Find which subpool handled it and pull in the corresponding error
 		errs[i] = errsets[split][0]
-		if errs[i] != nil && admitted[i] != (common.Address{}) {
-			p.rollbackAdmission(admitted[i])
-			admitted[i] = common.Address{}
-		}
 		errsets[split] = errsets[split][1:]
 	}
 	return errs
 }
 
+// applyOlivetumGuards checks the stateful Olivetum rules of a transaction
+// against the rules of the next block, counting the transactions of the sender
+// already in the pool. If the transaction counts against the rate limit of the
+// sender, admitted is set to the sender until the subpools added it.
 func (p *TxPool) applyOlivetumGuards(tx *types.Transaction, from common.Address, admitted *common.Address) error {
 	to := tx.To()
-	if to != nil && !core.IsAuthorizedManagementTx(from, *to) {
-		return ErrManagementUnauthorized
+	if to == nil {
+		return core.ErrContractCreationDisabled
 	}
 	head := p.chain.CurrentBlock()
 	if head == nil {
 		return errors.New("txpool head unavailable")
 	}
-	if err := p.applyOffSessionBudget(tx, from, head); err != nil {
+	rules := core.PendingOlivetumRules(head)
+	if !rules.IsAuthorizedManagementTx(from, *to) {
+		return ErrManagementUnauthorized
+	}
+	if err := p.checkOffSessionBudget(rules, tx, from); err != nil {
 		return err
 	}
-	if to != nil && *to == core.DividendContract {
-		if err := p.checkDividendTx(tx, from, head); err != nil {
+	if *to == core.DividendContract {
+		if err := p.checkDividendCall(rules, tx, from); err != nil {
 			return err
 		}
 	}
-	if p.shouldSkipRateLimit(tx, from, head) {
+	if rules.RateLimitExempt(from, *to, tx.Data()) {
 		return nil
 	}
-	return p.applyTxRateLimit(from, head, admitted)
+	return p.applyTxRateLimit(rules, tx, from, admitted)
 }
 
-func (p *TxPool) applyOffSessionBudget(tx *types.Transaction, from common.Address, head *types.Header) error {
-	if tx.Value().Sign() == 0 || head == nil || head.Number == nil {
+// checkOffSessionBudget rejects a transaction if, together with the other
+// transactions of the sender in the pool, it exceeds the off-session budget.
+func (p *TxPool) checkOffSessionBudget(rules *core.OlivetumRules, tx *types.Transaction, from common.Address) error {
+	amount := rules.OffSessionCharge(from, rules.TransferValue(tx.To(), tx.Value(), tx.Data()))
+	if amount.Sign() == 0 || rules.OffSessionMaxPerTx.Sign() == 0 {
 		return nil
 	}
-	if core.IsSession(head.Time) {
-		return nil
-	}
-	fork := params.GetEconomyForkBlock()
-	if fork.Sign() == 0 {
-		return nil
-	}
-	nextBlock := new(big.Int).Add(head.Number, big.NewInt(1))
-	if nextBlock.Cmp(fork) < 0 {
-		return nil
-	}
-
 	p.stateLock.RLock()
-	state := p.state
-	p.stateLock.RUnlock()
-	if state == nil {
+	defer p.stateLock.RUnlock()
+
+	if p.state == nil {
 		return errors.New("txpool state unavailable")
 	}
-
-	limit := params.GetOffSessionMaxPerTx()
-	if limit.Sign() == 0 {
-		return nil
+	total := core.GetOffSessionBudgetSpent(p.state, from, rules.Time)
+	for _, ptx := range p.contentFrom(from, tx.Nonce()) {
+		total.Add(total, rules.OffSessionCharge(from, rules.TransferValue(ptx.To(), ptx.Value(), ptx.Data())))
 	}
-
-	total := core.GetOffSessionBudgetSpent(state, from, head.Time)
-	for _, subpool := range p.subpools {
-		pending, queued := subpool.ContentFrom(from)
-		for _, tx := range pending {
-			if tx.Value().Sign() > 0 {
-				total.Add(total, tx.Value())
-			}
-		}
-		for _, tx := range queued {
-			if tx.Value().Sign() > 0 {
-				total.Add(total, tx.Value())
-			}
-		}
-	}
-	total.Add(total, tx.Value())
-	if total.Cmp(limit) > 0 {
+	total.Add(total, amount)
+	if total.Cmp(rules.OffSessionMaxPerTx) > 0 {
 		return ErrOverMaxOffSessionBudget
 	}
 	return nil
 }
 
-func (p *TxPool) checkDividendTx(tx *types.Transaction, from common.Address, head *types.Header) error {
-	if tx.Value().Sign() != 0 {
-		return ErrDividendNotEligible
-	}
+// checkDividendCall rejects dividend claims and rounds the state transition
+// would revert, including a second claim or round of the sender while the
+// first one is in the pool. The call is checked against a copy of the pool
+// state, so that concurrent checks only share the read lock.
+func (p *TxPool) checkDividendCall(rules *core.OlivetumRules, tx *types.Transaction, from common.Address) error {
 	p.stateLock.RLock()
-	defer p.stateLock.RUnlock()
-
 	if p.state == nil {
+		p.stateLock.RUnlock()
 		return errors.New("txpool state unavailable")
 	}
-	if len(tx.Data()) == 0 {
-		ds := core.GetDividendStatus(p.state, from)
-		now := head.Time
-		if ds.Rate == 0 || now < ds.Start || now-ds.Start > ds.Window || ds.Claimed {
-			return ErrDividendNotEligible
-		}
+	statedb := p.state.Copy()
+	p.stateLock.RUnlock()
+
+	if err := rules.CheckDividendCall(statedb, from, tx.Value(), tx.Data()); err != nil {
+		return err
+	}
+	if len(tx.Data()) > 1 {
 		return nil
 	}
-	if len(tx.Data()) == 1 {
-		if from != core.DividendAdmin {
-			return ErrManagementUnauthorized
+	for _, ptx := range p.contentFrom(from, tx.Nonce()) {
+		if to := ptx.To(); to == nil || *to != core.DividendContract || len(ptx.Data()) != len(tx.Data()) {
+			continue
 		}
-		ok := core.CanTriggerDividend(p.state, head.Time)
-		if !ok {
-			return ErrDividendRoundTooSoon
+		if len(tx.Data()) == 0 {
+			return ErrDividendNotEligible
 		}
+		return ErrDividendRoundTooSoon
 	}
 	return nil
 }
 
-func (p *TxPool) applyTxRateLimit(from common.Address, head *types.Header, admitted *common.Address) error {
-	now := head.Time
+// applyTxRateLimit rejects a transaction if the sender has no hourly allowance
+// left for it besides its other rate limited transactions in the pool.
+func (p *TxPool) applyTxRateLimit(rules *core.OlivetumRules, tx *types.Transaction, from common.Address, admitted *common.Address) error {
 	p.stateLock.RLock()
-	state := p.state
-	var allowance uint64
-	var stateNonce uint64
-	if state != nil {
-		allowance = core.GetTxAllowance(state, from, now)
-		stateNonce = state.GetNonce(from)
-	}
-	p.stateLock.RUnlock()
-	if state == nil {
+	if p.state == nil {
+		p.stateLock.RUnlock()
 		return errors.New("txpool state unavailable")
 	}
-	poolNonce := p.poolNonce(from)
-	var poolPending uint64
-	if poolNonce > stateNonce {
-		poolPending = poolNonce - stateNonce
-	}
+	allowance := rules.TxAllowance(p.state, from)
+	p.stateLock.RUnlock()
 
+	var pending uint64
+	for _, ptx := range p.contentFrom(from, tx.Nonce()) {
+		if to := ptx.To(); to == nil || !rules.RateLimitExempt(from, *to, ptx.Data()) {
+			pending++
+		}
+	}
 	p.rateLock.Lock()
 	defer p.rateLock.Unlock()
 
-	infl := p.rateCounters[from]
-	if infl.Epoch != p.rateEpoch || now-infl.Start >= uint64(time.Hour/time.Second) {
-		infl = core.TxRateUsage{Start: now, Epoch: p.rateEpoch}
-	}
-	last := p.lastPoolNonce[from]
-	if poolNonce > last {
-		advance := poolNonce - last
-		if advance >= infl.Count {
-			infl.Count = 0
-		} else {
-			infl.Count -= advance
-		}
-		p.lastPoolNonce[from] = poolNonce
-	}
-	effectivePending := poolPending + infl.Count
-	if allowance <= effectivePending {
+	if allowance <= pending+p.admitting[from] {
 		return ErrRateLimit
 	}
-	infl.Count++
-	p.rateCounters[from] = infl
+	p.admitting[from]++
 	*admitted = from
 	return nil
 }
 
-func (p *TxPool) rollbackAdmission(addr common.Address) {
+// releaseAdmission drops a transaction admitted by applyTxRateLimit once the
+// subpools either hold it or rejected it.
+func (p *TxPool) releaseAdmission(addr common.Address) {
 	p.rateLock.Lock()
 	defer p.rateLock.Unlock()
 
-	if infl, ok := p.rateCounters[addr]; ok && infl.Count > 0 {
-		infl.Count--
-		p.rateCounters[addr] = infl
+	if n := p.admitting[addr]; n > 1 {
+		p.admitting[addr] = n - 1
+	} else {
+		delete(p.admitting, addr)
 	}
 }
 
-func (p *TxPool) poolNonce(addr common.Address) uint64 {
-	var nonce uint64
+// contentFrom returns the pending and queued transactions of addr in the
+// subpools, except the one with the given nonce a new transaction replaces.
+func (p *TxPool) contentFrom(addr common.Address, nonce uint64) []*types.Transaction {
+	var txs []*types.Transaction
 	for _, subpool := range p.subpools {
-		if next := subpool.Nonce(addr); nonce < next {
-			nonce = next
-		}
-	}
-	return nonce
-}
-
-func (p *TxPool) shouldSkipRateLimit(tx *types.Transaction, from common.Address, head *types.Header) bool {
-	to := tx.To()
-	if to == nil {
-		return false
-	}
-	fork := params.GetEconomyForkBlock()
-	if fork.Sign() == 0 {
-		return from == params.TxRateLimitAdmin
-	}
-	if head != nil && head.Number != nil {
-		nextBlock := new(big.Int).Add(head.Number, big.NewInt(1))
-		if nextBlock.Cmp(fork) < 0 && from == params.TxRateLimitAdmin {
-			return true
+		pending, queued := subpool.ContentFrom(addr)
+		for _, list := range [][]*types.Transaction{pending, queued} {
+			for _, tx := range list {
+				if tx.Nonce() != nonce {
+					txs = append(txs, tx)
+				}
+			}
 		}
 	}
-	return core.IsTxRateLimitExempt(from, *to, tx.Data())
+	return txs
 }
 
 // Pending retrieves all currently processable transactions, grouped by origin
@@ -664,7 +607,13 @@ func (p *TxPool) Nonce(addr common.Address) uint64 {
 	// Since (for now) accounts are unique to subpools, only one pool will have
 	// (at max) a non-state nonce. To avoid stateful lookups, just return the
 	// highest nonce for now.
-	return p.poolNonce(addr)
+	var nonce uint64
+	for _, subpool := range p.subpools {
+		if next := subpool.Nonce(addr); nonce < next {
+			nonce = next
+		}
+	}
+	return nonce
 }
 
 // Stats retrieves the current pool stats, namely the number of pending and the
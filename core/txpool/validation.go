@@ -106,45 +106,14 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	if err != nil {
 		return ErrInvalidSender
 	}
+	var batch []params.BatchTransferEntry
 	if isOlivetum {
-		nextFork := false
-		if fork := params.GetEconomyForkBlock(); fork.Sign() > 0 && head.Number != nil {
-			next := new(big.Int).Add(head.Number, big.NewInt(1))
-			nextFork = next.Cmp(fork) >= 0
-		}
-
-		if tx.To() == nil {
-			return core.ErrContractCreationDisabled
-		}
-		if to := tx.To(); to != nil && *to == sender {
-			return core.ErrSelfTransfer
-		}
-		if to := tx.To(); to != nil && !core.IsAuthorizedManagementTx(sender, *to) {
-			return ErrManagementUnauthorized
-		}
-		if to := tx.To(); to != nil {
-			if err := core.ValidateOlivetumTxPayload(sender, *to, tx.Value(), tx.Data(), tx.AccessList(), nextFork); err != nil {
-				return err
-			}
-		}
-		if tx.Value().Sign() >= 0 && tx.Value().Cmp(params.GetMinTxAmount()) < 0 {
-			var exemptFrom, exemptTo bool
-			if nextFork {
-				exemptFrom = params.IsMinTxAmountExemptSender(sender)
-				exemptTo = tx.To() != nil && params.IsMinTxAmountExemptRecipient(*tx.To())
-			} else {
-				exemptFrom = params.IsMinTxAmountExempt(sender)
-				exemptTo = tx.To() != nil && params.IsMinTxAmountExempt(*tx.To())
-			}
-			if !exemptFrom && !exemptTo {
-				return ErrUnderMinAmount
-			}
+		rules := core.PendingOlivetumRules(head)
+		if err := rules.CheckParties(sender, tx.To()); err != nil {
+			return err
 		}
-		if !core.IsSession(head.Time) {
-			maxPerTx := params.GetOffSessionMaxPerTx()
-			if tx.Value().Cmp(maxPerTx) > 0 {
-				return ErrOverMaxAmount
-			}
+		if batch, err = rules.CheckTransfer(sender, *tx.To(), tx.Value(), tx.Data(), tx.AccessList()); err != nil {
+			return err
 		}
 	}
 	// Ensure the transaction has more gas than the bare minimum needed to cover
@@ -153,6 +122,9 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 	if err != nil {
 		return err
 	}
+	if batch != nil {
+		intrGas += core.BatchTransferGas(len(batch))
+	}
 	if tx.Gas() < intrGas {
 		return fmt.Errorf("%w: gas %v, minimum needed %v", core.ErrIntrinsicGas, tx.Gas(), intrGas)
 	}
@@ -220,6 +192,10 @@ func validateBlobSidecar(hashes []common.Hash, sidecar *types.BlobTxSidecar) err
 type ValidationOptionsWithState struct {
 	State *state.StateDB // State database to check nonces and balances against
 
+	// OlivetumRules are the Olivetum rules the transaction is admitted under,
+	// nil on other chains.
+	OlivetumRules *core.OlivetumRules
+
 	// FirstNonceGap is an optional callback to retrieve the first nonce gap in
 	// the list of pooled transactions of a specific account. If this method is
 	// set, nonce gaps will be checked and forbidden. If this method is not set,
@@ -268,6 +244,10 @@ func ValidateTransactionWithState(tx *types.Transaction, signer types.Signer, op
 		balance = opts.State.GetBalance(from).ToBig()
 		cost    = tx.Cost()
 	)
+	if rules := opts.OlivetumRules; rules != nil && tx.To() != nil && rules.IsBatch(*tx.To()) {
+		// Batch transfers move their payload total rather than the tx value.
+		cost.Add(cost, rules.TransferValue(tx.To(), tx.Value(), tx.Data()))
+	}
 	if balance.Cmp(cost) < 0 {
 		return fmt.Errorf("%w: balance %v, tx cost %v, overshot %v", core.ErrInsufficientFunds, balance, cost, new(big.Int).Sub(cost, balance))
 	}
@@ -245,6 +245,12 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			Preimages:           config.Preimages,
 			StateHistory:        config.StateHistory,
 			StateScheme:         scheme,
+
+			OlivetumAccountIndex: config.OlivetumAccountIndex,
+			OlivetumHolderIndex:  config.OlivetumHolderIndex,
+			OlivetumRichIndex:    config.OlivetumRichIndex,
+
+			OlivetumSupplyExclude: config.OlivetumSupplyExclude,
 		}
 	)
 	// Override the chain config with provided settings.
@@ -235,6 +235,24 @@ type Config struct {
 
 	// OverrideVerkle (TODO: remove after the fork)
 	OverrideVerkle *uint64 `toml:",omitempty"`
+
+	// OlivetumSupplyExclude lists the accounts whose balances are not counted
+	// as circulating supply on Olivetum. If empty, the system accounts returned
+	// by core.DefaultSupplyExcluded are used. The economy records keep the
+	// locked balance of the accounts listed when each block was recorded.
+	OlivetumSupplyExclude []common.Address `toml:",omitempty"`
+
+	// OlivetumAccountIndex enables the per-address index of burns, gas fees,
+	// miner burn shares and dividend claims served by olivetum_getAccountEconomy.
+	OlivetumAccountIndex bool `toml:",omitempty"`
+
+	// OlivetumHolderIndex enables the index of dividend holders served by
+	// olivetum_getDividendHolders.
+	OlivetumHolderIndex bool `toml:",omitempty"`
+
+	// OlivetumRichIndex enables the index of accounts by balance served by
+	// olivetum_getRichList.
+	OlivetumRichIndex bool `toml:",omitempty"`
 }
 
 // CreateConsensusEngine creates a consensus engine for the given chain configuration.
@@ -247,6 +265,10 @@ func CreateConsensusEngine(stack *node.Node, ethashConfig *ethash.Config, clique
 		} else {
 			params.SetRewardForkBlock(nil)
 		}
+		if ethashConfig.PowMode == ethash.ModeFake {
+			log.Warn("Olivetumhash used in fake mode")
+			return olivetumhash.NewFaker()
+		}
 		return olivetumhash.New(olivetumhashConfig)
 	}
 	if cliqueConfig != nil {
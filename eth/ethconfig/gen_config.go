@@ -73,6 +73,10 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		OverrideShanghai           *uint64                        `toml:",omitempty"`
 		OverrideCancun             *uint64                        `toml:",omitempty"`
 		OverrideVerkle             *uint64                        `toml:",omitempty"`
+		OlivetumSupplyExclude      []common.Address               `toml:",omitempty"`
+		OlivetumAccountIndex       bool                           `toml:",omitempty"`
+		OlivetumHolderIndex        bool                           `toml:",omitempty"`
+		OlivetumRichIndex          bool                           `toml:",omitempty"`
 	}
 	var enc Config
 	enc.Genesis = c.Genesis
@@ -129,6 +133,10 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.OverrideShanghai = c.OverrideShanghai
 	enc.OverrideCancun = c.OverrideCancun
 	enc.OverrideVerkle = c.OverrideVerkle
+	enc.OlivetumSupplyExclude = c.OlivetumSupplyExclude
+	enc.OlivetumAccountIndex = c.OlivetumAccountIndex
+	enc.OlivetumHolderIndex = c.OlivetumHolderIndex
+	enc.OlivetumRichIndex = c.OlivetumRichIndex
 	return &enc, nil
 }
 
@@ -189,6 +197,10 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		OverrideShanghai           *uint64                        `toml:",omitempty"`
 		OverrideCancun             *uint64                        `toml:",omitempty"`
 		OverrideVerkle             *uint64                        `toml:",omitempty"`
+		OlivetumSupplyExclude      []common.Address               `toml:",omitempty"`
+		OlivetumAccountIndex       *bool                          `toml:",omitempty"`
+		OlivetumHolderIndex        *bool                          `toml:",omitempty"`
+		OlivetumRichIndex          *bool                          `toml:",omitempty"`
 	}
 	var dec Config
 	if err := unmarshal(&dec); err != nil {
@@ -356,5 +368,17 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.OverrideVerkle != nil {
 		c.OverrideVerkle = dec.OverrideVerkle
 	}
+	if dec.OlivetumSupplyExclude != nil {
+		c.OlivetumSupplyExclude = dec.OlivetumSupplyExclude
+	}
+	if dec.OlivetumAccountIndex != nil {
+		c.OlivetumAccountIndex = *dec.OlivetumAccountIndex
+	}
+	if dec.OlivetumHolderIndex != nil {
+		c.OlivetumHolderIndex = *dec.OlivetumHolderIndex
+	}
+	if dec.OlivetumRichIndex != nil {
+		c.OlivetumRichIndex = *dec.OlivetumRichIndex
+	}
 	return nil
 }
@@ -0,0 +1,74 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// OlivetumAccountEconomy holds the economy figures of an account summed over a
+// block range.
+type OlivetumAccountEconomy struct {
+	Address          common.Address `json:"address"`
+	FromBlock        hexutil.Uint64 `json:"fromBlock"`
+	ToBlock          hexutil.Uint64 `json:"toBlock"`
+	IndexedHead      hexutil.Uint64 `json:"indexedHead"`
+	Blocks           hexutil.Uint64 `json:"blocks"`
+	BurnedOnSend     *hexutil.Big   `json:"burnedOnSend"`
+	MinerBurnShare   *hexutil.Big   `json:"minerBurnShare"`
+	GasFees          *hexutil.Big   `json:"gasFees"`
+	DividendsClaimed *hexutil.Big   `json:"dividendsClaimed"`
+}
+
+// GetAccountEconomy sums the transfer burns charged to an account as sender,
+// the burn shares it earned as miner, the gas fees it paid and the dividends
+// paid to it from fromBlock to toBlock. It needs the account economy index
+// (--olivetum.accountindex), which covers the blocks imported while enabled;
+// blocks is the number of blocks in the range with figures for the account.
+func (api *OlivetumAPI) GetAccountEconomy(ctx context.Context, address common.Address, fromBlock, toBlock rpc.BlockNumber) (*OlivetumAccountEconomy, error) {
+	if !params.IsOlivetumConfig(api.eth.blockchain.Config()) {
+		return nil, fmt.Errorf("not an Olivetum chain")
+	}
+	head := api.eth.blockchain.CurrentBlock().Number.Uint64()
+	resolve := func(n rpc.BlockNumber) (uint64, error) {
+		switch {
+		case n == rpc.EarliestBlockNumber:
+			return 0, nil
+		case n < 0:
+			return head, nil
+		case uint64(n) > head:
+			return 0, fmt.Errorf("block #%d not found", n)
+		}
+		return uint64(n), nil
+	}
+	from, err := resolve(fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := resolve(toBlock)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, fmt.Errorf("fromBlock %d after toBlock %d", from, to)
+	}
+	total, blocks, indexed, err := api.eth.blockchain.OlivetumAccountEconomy(address, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &OlivetumAccountEconomy{
+		Address:          address,
+		FromBlock:        hexutil.Uint64(from),
+		ToBlock:          hexutil.Uint64(to),
+		IndexedHead:      hexutil.Uint64(indexed),
+		Blocks:           hexutil.Uint64(blocks),
+		BurnedOnSend:     (*hexutil.Big)(total.BurnedOnSend),
+		MinerBurnShare:   (*hexutil.Big)(total.MinerBurnShare),
+		GasFees:          (*hexutil.Big)(total.GasFees),
+		DividendsClaimed: (*hexutil.Big)(total.DividendsClaimed),
+	}, nil
+}
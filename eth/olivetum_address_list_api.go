@@ -0,0 +1,88 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// olivetumAddressClasses maps the RPC names of the address classes to their
+// flags.
+var olivetumAddressClasses = map[string]uint8{
+	"minAmount":  params.AddressClassMinAmountExempt,
+	"rateLimit":  params.AddressClassRateLimitExempt,
+	"offSession": params.AddressClassOffSessionExempt,
+	"frozen":     params.AddressClassFrozen,
+}
+
+// OlivetumAddressClasses reports the administrator-managed classes of an
+// address.
+type OlivetumAddressClasses struct {
+	Address          common.Address `json:"address"`
+	MinAmountExempt  bool           `json:"minAmountExempt"`
+	RateLimitExempt  bool           `json:"rateLimitExempt"`
+	OffSessionExempt bool           `json:"offSessionExempt"`
+	Frozen           bool           `json:"frozen"`
+}
+
+// GetAddressClasses returns the address classes of addr at the given block
+// (latest if omitted).
+func (api *OlivetumAPI) GetAddressClasses(ctx context.Context, addr common.Address, blockNrOrHash *rpc.BlockNumberOrHash) (*OlivetumAddressClasses, error) {
+	statedb, _, err := api.olivetumState(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	flags := core.GetAddressClasses(statedb, addr)
+	return &OlivetumAddressClasses{
+		Address:          addr,
+		MinAmountExempt:  flags&params.AddressClassMinAmountExempt != 0,
+		RateLimitExempt:  flags&params.AddressClassRateLimitExempt != 0,
+		OffSessionExempt: flags&params.AddressClassOffSessionExempt != 0,
+		Frozen:           flags&params.AddressClassFrozen != 0,
+	}, nil
+}
+
+// GetAddressList returns the addresses in the named class ("minAmount",
+// "rateLimit", "offSession" or "frozen") at the given block (latest if
+// omitted).
+func (api *OlivetumAPI) GetAddressList(ctx context.Context, class string, blockNrOrHash *rpc.BlockNumberOrHash) ([]common.Address, error) {
+	flag, ok := olivetumAddressClasses[class]
+	if !ok {
+		return nil, fmt.Errorf("unknown address class %q", class)
+	}
+	statedb, _, err := api.olivetumState(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	list := core.ListAddresses(statedb, flag)
+	if list == nil {
+		list = []common.Address{}
+	}
+	return list, nil
+}
+
+// olivetumState resolves the state and the header of the given block (latest
+// if omitted).
+func (api *OlivetumAPI) olivetumState(ctx context.Context, blockNrOrHash *rpc.BlockNumberOrHash) (*state.StateDB, *types.Header, error) {
+	if !params.IsOlivetumConfig(api.eth.blockchain.Config()) {
+		return nil, nil, fmt.Errorf("not an Olivetum chain")
+	}
+	bnh := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bnh = *blockNrOrHash
+	}
+	statedb, header, err := api.eth.APIBackend.StateAndHeaderByNumberOrHash(ctx, bnh)
+	if err != nil {
+		return nil, nil, err
+	}
+	if statedb == nil || header == nil {
+		return nil, nil, fmt.Errorf("block not found")
+	}
+	return statedb, header, nil
+}
@@ -4,41 +4,37 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/olivetumtypes"
 	"github.com/ethereum/go-ethereum/params"
 )
 
-// OlivetumRuntimeConfig exposes the currently active runtime parameters.
-type OlivetumRuntimeConfig struct {
-	BlockPeriod            uint64       `json:"blockPeriod"`
-	GasLimit               uint64       `json:"gasLimit"`
-	MinTxAmount            *hexutil.Big `json:"minTxAmount"`
-	TxRateLimit            uint64       `json:"txRateLimit"`
-	OffSessionTxRate       uint64       `json:"offSessionTxRate"`
-	OffSessionMaxPerTx     *hexutil.Big `json:"offSessionMaxPerTx"`
-	SessionTzOffsetSeconds int32        `json:"sessionTzOffsetSeconds"`
-	BurnRate               uint64       `json:"burnRate"`
-	DividendRate           uint64       `json:"dividendRate"`
-}
-
-// OlivetumSupply exposes supply-related stats.
-type OlivetumSupply struct {
-	TotalMinted  *hexutil.Big `json:"totalMinted"`
-	MaxSupply    *hexutil.Big `json:"maxSupply"`
-	Remaining    *hexutil.Big `json:"remaining"`
-	BurnRate     uint64       `json:"burnRate"`
-	DividendRate uint64       `json:"dividendRate"`
-	Burned       *hexutil.Big `json:"burned"`
-	Dividends    *hexutil.Big `json:"dividendsMinted"`
-	NetBurned    *hexutil.Big `json:"netBurnedAfterDividends"`
+func newOlivetumSessionCalendar(cal params.SessionCalendar) *olivetumtypes.SessionCalendar {
+	out := &olivetumtypes.SessionCalendar{
+		Hours:    make([]olivetumtypes.SessionHours, len(cal.Hours)),
+		Holidays: make([]string, 0, len(cal.Holidays)),
+	}
+	for i, h := range cal.Hours {
+		out.Hours[i] = olivetumtypes.SessionHours{Open: h.Open, Close: h.Close}
+	}
+	for _, day := range cal.Holidays {
+		out.Holidays = append(out.Holidays, time.Unix(int64(day)*24*60*60, 0).UTC().Format("2006-01-02"))
+	}
+	return out
 }
 
 // OlivetumAPI exposes chain-specific helper RPCs (read-only, non-consensus).
 type OlivetumAPI struct {
 	eth *Ethereum
+
+	allocOnce sync.Once
+	alloc     *big.Int // Balance allocated in the genesis
+	allocErr  error
 }
 
 // NewOlivetumAPI wires an OlivetumAPI instance.
@@ -46,8 +42,22 @@ func NewOlivetumAPI(eth *Ethereum) *OlivetumAPI {
 	return &OlivetumAPI{eth: eth}
 }
 
+// genesisAlloc returns the balance allocated in the genesis, read from the
+// stored genesis specification on first use.
+func (api *OlivetumAPI) genesisAlloc() (*big.Int, error) {
+	api.allocOnce.Do(func() {
+		genesis, err := core.ReadGenesis(api.eth.ChainDb())
+		if err != nil {
+			api.allocErr = fmt.Errorf("genesis allocation unavailable: %w", err)
+			return
+		}
+		api.alloc = core.GenesisAllocBalance(genesis)
+	})
+	return api.alloc, api.allocErr
+}
+
 // GetRuntimeConfig returns the current Olivetum runtime configuration.
-func (api *OlivetumAPI) GetRuntimeConfig(ctx context.Context) (*OlivetumRuntimeConfig, error) {
+func (api *OlivetumAPI) GetRuntimeConfig(ctx context.Context) (*olivetumtypes.RuntimeConfig, error) {
 	if !params.IsOlivetumConfig(api.eth.blockchain.Config()) {
 		return nil, fmt.Errorf("not an Olivetum chain")
 	}
@@ -55,7 +65,7 @@ func (api *OlivetumAPI) GetRuntimeConfig(ctx context.Context) (*OlivetumRuntimeC
 	if err != nil {
 		return nil, err
 	}
-	return &OlivetumRuntimeConfig{
+	return &olivetumtypes.RuntimeConfig{
 		BlockPeriod:            params.GetBlockPeriod(),
 		GasLimit:               params.GetGasLimit(),
 		MinTxAmount:            (*hexutil.Big)(params.GetMinTxAmount()),
@@ -63,6 +73,7 @@ func (api *OlivetumAPI) GetRuntimeConfig(ctx context.Context) (*OlivetumRuntimeC
 		OffSessionTxRate:       params.GetOffSessionTxRate(),
 		OffSessionMaxPerTx:     (*hexutil.Big)(params.GetOffSessionMaxPerTx()),
 		SessionTzOffsetSeconds: params.GetSessionTzOffsetSeconds(),
+		SessionCalendar:        newOlivetumSessionCalendar(params.GetSessionCalendar()),
 		BurnRate:               core.GetBurnRate(state),
 		DividendRate:           core.GetDividendRate(state),
 	}, nil
@@ -73,8 +84,9 @@ func (api *OlivetumAPI) GetFinalizedHeight(ctx context.Context) hexutil.Uint64 {
 	return hexutil.Uint64(api.eth.blockchain.FinalizedHeight())
 }
 
-// GetSupply returns the minted supply stats.
-func (api *OlivetumAPI) GetSupply(ctx context.Context) (*OlivetumSupply, error) {
+// GetSupply returns the minted supply stats and the circulating supply, which
+// leaves out the balances of the excluded system accounts.
+func (api *OlivetumAPI) GetSupply(ctx context.Context) (*olivetumtypes.Supply, error) {
 	if !params.IsOlivetumConfig(api.eth.blockchain.Config()) {
 		return nil, fmt.Errorf("not an Olivetum chain")
 	}
@@ -89,20 +101,34 @@ func (api *OlivetumAPI) GetSupply(ctx context.Context) (*OlivetumSupply, error)
 		remaining = new(big.Int)
 	}
 	burned := core.GetTotalBurned(state)
+	burnedRewards := core.GetTotalBurnedRewards(state)
 	dividends := core.GetTotalDividendsMinted(state)
 	netBurned := new(big.Int).Sub(new(big.Int).Set(burned), dividends)
 	if netBurned.Sign() < 0 {
 		netBurned = new(big.Int)
 	}
-	return &OlivetumSupply{
-		TotalMinted:  (*hexutil.Big)(totalMinted),
-		MaxSupply:    (*hexutil.Big)(maxSupply),
-		Remaining:    (*hexutil.Big)(remaining),
-		BurnRate:     core.GetBurnRate(state),
-		DividendRate: core.GetDividendRate(state),
-		Burned:       (*hexutil.Big)(burned),
-		Dividends:    (*hexutil.Big)(dividends),
-		NetBurned:    (*hexutil.Big)(netBurned),
+	alloc, err := api.genesisAlloc()
+	if err != nil {
+		return nil, err
+	}
+	var (
+		excluded = api.eth.supplyExcluded()
+		record   = core.EconomyRecord(state, excluded)
+	)
+	return &olivetumtypes.Supply{
+		TotalMinted:   (*hexutil.Big)(totalMinted),
+		MaxSupply:     (*hexutil.Big)(maxSupply),
+		Remaining:     (*hexutil.Big)(remaining),
+		BurnRate:      core.GetBurnRate(state),
+		DividendRate:  core.GetDividendRate(state),
+		Burned:        (*hexutil.Big)(burned),
+		BurnedRewards: (*hexutil.Big)(burnedRewards),
+		Dividends:     (*hexutil.Big)(dividends),
+		NetBurned:     (*hexutil.Big)(netBurned),
+		Outstanding:   (*hexutil.Big)(core.OutstandingSupply(alloc, record)),
+		Locked:        (*hexutil.Big)(record.Locked),
+		Circulating:   (*hexutil.Big)(core.CirculatingSupply(alloc, record)),
+		Excluded:      excluded,
 	}, nil
 }
 
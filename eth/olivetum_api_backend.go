@@ -0,0 +1,24 @@
+package eth
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/eth/olivetumtypes"
+)
+
+// OlivetumSupply returns the supply stats of the current head (olivetum_getSupply).
+func (b *EthAPIBackend) OlivetumSupply(ctx context.Context) (*olivetumtypes.Supply, error) {
+	return NewOlivetumAPI(b.eth).GetSupply(ctx)
+}
+
+// OlivetumRuntimeConfig returns the active runtime configuration
+// (olivetum_getRuntimeConfig).
+func (b *EthAPIBackend) OlivetumRuntimeConfig(ctx context.Context) (*olivetumtypes.RuntimeConfig, error) {
+	return NewOlivetumAPI(b.eth).GetRuntimeConfig(ctx)
+}
+
+// OlivetumFinalizedHeight returns the finalized height watermark
+// (olivetum_getFinalizedHeight).
+func (b *EthAPIBackend) OlivetumFinalizedHeight() uint64 {
+	return b.eth.blockchain.FinalizedHeight()
+}
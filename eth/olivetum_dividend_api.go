@@ -0,0 +1,60 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// OlivetumDividendPayout is an automatic dividend payout to a holder.
+type OlivetumDividendPayout struct {
+	Holder      common.Address `json:"holder"`
+	Amount      *hexutil.Big   `json:"amount"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+}
+
+// GetDividendPayouts returns the automatic dividend payouts made when
+// finalizing the given block. The payouts belong to no transaction and have
+// no receipt: they are not covered by the logs bloom of the block and are not
+// returned by eth_getLogs or log subscriptions, so this is the only way to
+// list them. They are recorded by the node when it executes the block; an
+// error is returned for blocks it did not execute, e.g. after a snap sync.
+func (api *OlivetumAPI) GetDividendPayouts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*OlivetumDividendPayout, error) {
+	if !params.IsOlivetumConfig(api.eth.blockchain.Config()) {
+		return nil, fmt.Errorf("not an Olivetum chain")
+	}
+	block, err := api.eth.APIBackend.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block not found")
+	}
+	out := make([]*OlivetumDividendPayout, 0)
+	if !params.DividendAutoFork.Active(block.Number()) {
+		return out, nil
+	}
+	logs, ok := api.eth.blockchain.OlivetumPayouts(block.Hash(), block.NumberU64())
+	if !ok {
+		return nil, fmt.Errorf("dividend payouts of block %d were not recorded by this node", block.NumberU64())
+	}
+	for _, l := range logs {
+		if len(l.Topics) < 2 || l.Topics[0] != core.DividendClaimedTopic {
+			continue
+		}
+		out = append(out, &OlivetumDividendPayout{
+			Holder:      common.BytesToAddress(l.Topics[1].Bytes()),
+			Amount:      (*hexutil.Big)(new(big.Int).SetBytes(l.Data)),
+			BlockHash:   block.Hash(),
+			BlockNumber: hexutil.Uint64(block.NumberU64()),
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,124 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// OlivetumDividendSnapshotMaxAccounts is the maximum number of accounts
+// iterated by a single dividend snapshot call.
+const OlivetumDividendSnapshotMaxAccounts = 4096
+
+// OlivetumDividendSnapshotArgs selects the output of a dividend snapshot.
+type OlivetumDividendSnapshotArgs struct {
+	Format string        `json:"format"` // "json" (default) or "csv"
+	All    bool          `json:"all"`    // include holders that are not eligible
+	Start  hexutil.Bytes `json:"start"`  // account hash to start at, "next" of the previous page
+	Limit  uint64        `json:"limit"`  // accounts to iterate, at most OlivetumDividendSnapshotMaxAccounts
+}
+
+// OlivetumDividendSnapshotCSV is a page of a dividend snapshot exported as CSV.
+type OlivetumDividendSnapshotCSV struct {
+	CSV  string        `json:"csv"`
+	Next hexutil.Bytes `json:"next,omitempty"`
+}
+
+// OlivetumDividendSnapshot lists the dividend holders of a block.
+type OlivetumDividendSnapshot struct {
+	Block            hexutil.Uint64           `json:"block"`
+	Hash             common.Hash              `json:"hash"`
+	Time             hexutil.Uint64           `json:"time"`
+	Round            core.DividendRound       `json:"round"`
+	Holders          []OlivetumDividendHolder `json:"holders"`
+	TotalEligible    *hexutil.Big             `json:"totalEligible"`
+	TotalRewards     *hexutil.Big             `json:"totalRewards"`
+	MissingPreimages hexutil.Uint64           `json:"missingPreimages"`
+	Next             hexutil.Bytes            `json:"next,omitempty"`
+}
+
+// OlivetumDividendHolder is the dividend position of an account.
+type OlivetumDividendHolder struct {
+	Address      common.Address `json:"address"`
+	Balance      *hexutil.Big   `json:"balance"`
+	HoldingSince hexutil.Uint64 `json:"holdingSince"`
+	Held         *hexutil.Big   `json:"held"`
+	Pending      *hexutil.Big   `json:"pending"`
+	Eligible     bool           `json:"eligible"`
+	Reward       *hexutil.Big   `json:"reward"`
+	Claimed      bool           `json:"claimed"`
+}
+
+// OlivetumDividendSnapshot lists the accounts eligible for the current dividend
+// round at the time of the given block (latest if omitted), with their eligible
+// amounts, projected rewards and claim status. The result is a JSON object or,
+// with format "csv", an object holding the CSV export. Addresses are recovered
+// from the preimage store.
+//
+// A call iterates at most limit accounts of the state in hash order, starting
+// at start. The totals cover those accounts only; if accounts remain, next is
+// the start of the following page.
+func (api *DebugAPI) OlivetumDividendSnapshot(ctx context.Context, blockNrOrHash *rpc.BlockNumberOrHash, args *OlivetumDividendSnapshotArgs) (interface{}, error) {
+	if !params.IsOlivetumConfig(api.eth.blockchain.Config()) {
+		return nil, fmt.Errorf("not an Olivetum chain")
+	}
+	if args == nil {
+		args = new(OlivetumDividendSnapshotArgs)
+	}
+	if args.Format != "" && args.Format != "json" && args.Format != "csv" {
+		return nil, fmt.Errorf("unknown format %q", args.Format)
+	}
+	bnh := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bnh = *blockNrOrHash
+	}
+	statedb, header, err := api.eth.APIBackend.StateAndHeaderByNumberOrHash(ctx, bnh)
+	if err != nil {
+		return nil, err
+	}
+	if statedb == nil || header == nil {
+		return nil, fmt.Errorf("block not found")
+	}
+	limit := args.Limit
+	if limit == 0 || limit > OlivetumDividendSnapshotMaxAccounts {
+		limit = OlivetumDividendSnapshotMaxAccounts
+	}
+	snap := core.TakeDividendSnapshotRange(statedb, header, args.All, args.Start, limit)
+	if args.Format == "csv" {
+		var buf bytes.Buffer
+		if err := snap.WriteCSV(&buf); err != nil {
+			return nil, err
+		}
+		return &OlivetumDividendSnapshotCSV{CSV: buf.String(), Next: snap.Next}, nil
+	}
+	out := &OlivetumDividendSnapshot{
+		Block:            hexutil.Uint64(snap.Block),
+		Hash:             snap.Hash,
+		Time:             hexutil.Uint64(snap.Time),
+		Round:            snap.Round,
+		Holders:          make([]OlivetumDividendHolder, 0, len(snap.Holders)),
+		TotalEligible:    (*hexutil.Big)(snap.TotalEligible),
+		TotalRewards:     (*hexutil.Big)(snap.TotalRewards),
+		MissingPreimages: hexutil.Uint64(snap.MissingPreimages),
+		Next:             snap.Next,
+	}
+	for _, h := range snap.Holders {
+		out.Holders = append(out.Holders, OlivetumDividendHolder{
+			Address:      h.Address,
+			Balance:      (*hexutil.Big)(h.Balance),
+			HoldingSince: hexutil.Uint64(h.HoldingSince),
+			Held:         (*hexutil.Big)(h.Held),
+			Pending:      (*hexutil.Big)(h.Pending),
+			Eligible:     h.Eligible,
+			Reward:       (*hexutil.Big)(h.Reward),
+			Claimed:      h.Claimed,
+		})
+	}
+	return out, nil
+}
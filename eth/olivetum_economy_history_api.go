@@ -0,0 +1,115 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const maxEconomyHistorySamples = 1000
+
+// OlivetumEconomySample holds the economy counters at a sampled block.
+type OlivetumEconomySample struct {
+	Block             hexutil.Uint64 `json:"block"`
+	TotalMinted       *hexutil.Big   `json:"totalMinted"`
+	Burned            *hexutil.Big   `json:"burned"`
+	BurnedTransfers   *hexutil.Big   `json:"burnedTransfers"`
+	BurnedGas         *hexutil.Big   `json:"burnedGas"`
+	BurnedRewards     *hexutil.Big   `json:"burnedRewards"`
+	MinerBurnShare    *hexutil.Big   `json:"minerBurnShare"`
+	DividendsMinted   *hexutil.Big   `json:"dividendsMinted"`
+	BurnRate          uint64         `json:"burnRate"`
+	DividendRate      uint64         `json:"dividendRate"`
+	OutstandingSupply *hexutil.Big   `json:"outstandingSupply"`
+	Locked            *hexutil.Big   `json:"locked,omitempty"`
+	CirculatingSupply *hexutil.Big   `json:"circulatingSupply"`
+}
+
+// GetEconomyHistory returns the economy counters every step blocks (1 if
+// omitted) from fromBlock to toBlock, at most 1000 samples. Samples come from
+// the economy records written as blocks become canonical; blocks without a
+// record, such as those synced before the records were kept, are read from
+// state, and the call fails if that state is unavailable.
+func (api *OlivetumAPI) GetEconomyHistory(ctx context.Context, fromBlock, toBlock rpc.BlockNumber, step *hexutil.Uint64) ([]*OlivetumEconomySample, error) {
+	if !params.IsOlivetumConfig(api.eth.blockchain.Config()) {
+		return nil, fmt.Errorf("not an Olivetum chain")
+	}
+	head := api.eth.blockchain.CurrentBlock().Number.Uint64()
+	resolve := func(n rpc.BlockNumber) (uint64, error) {
+		switch {
+		case n == rpc.EarliestBlockNumber:
+			return 0, nil
+		case n < 0:
+			return head, nil
+		case uint64(n) > head:
+			return 0, fmt.Errorf("block #%d not found", n)
+		}
+		return uint64(n), nil
+	}
+	from, err := resolve(fromBlock)
+	if err != nil {
+		return nil, err
+	}
+	to, err := resolve(toBlock)
+	if err != nil {
+		return nil, err
+	}
+	if from > to {
+		return nil, fmt.Errorf("fromBlock %d after toBlock %d", from, to)
+	}
+	interval := uint64(1)
+	if step != nil {
+		interval = uint64(*step)
+	}
+	if interval == 0 {
+		return nil, fmt.Errorf("step must be positive")
+	}
+	if (to-from)/interval >= maxEconomyHistorySamples {
+		return nil, fmt.Errorf("range exceeds %d samples", maxEconomyHistorySamples)
+	}
+	alloc, err := api.genesisAlloc()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*OlivetumEconomySample, 0)
+	for n := from; n <= to; n += interval {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		record := rawdb.ReadOlivetumEconomyRecord(api.eth.ChainDb(), n)
+		if record == nil {
+			statedb, _, err := api.eth.APIBackend.StateAndHeaderByNumber(ctx, rpc.BlockNumber(n))
+			if err != nil {
+				return nil, fmt.Errorf("economy record of block #%d unavailable: %w", n, err)
+			}
+			if statedb == nil {
+				return nil, fmt.Errorf("economy record of block #%d unavailable", n)
+			}
+			record = core.EconomyRecord(statedb, api.eth.supplyExcluded())
+		}
+		out = append(out, &OlivetumEconomySample{
+			Block:             hexutil.Uint64(n),
+			TotalMinted:       (*hexutil.Big)(record.Minted),
+			Burned:            (*hexutil.Big)(record.Burned),
+			BurnedTransfers:   (*hexutil.Big)(record.BurnedTransfers),
+			BurnedGas:         (*hexutil.Big)(record.BurnedGas),
+			BurnedRewards:     (*hexutil.Big)(record.BurnedRewards),
+			MinerBurnShare:    (*hexutil.Big)(record.MinerBurnShare),
+			DividendsMinted:   (*hexutil.Big)(record.DividendsMinted),
+			BurnRate:          record.BurnRate,
+			DividendRate:      record.DividendRate,
+			OutstandingSupply: (*hexutil.Big)(core.OutstandingSupply(alloc, record)),
+			Locked:            (*hexutil.Big)(record.Locked),
+			CirculatingSupply: (*hexutil.Big)(core.CirculatingSupply(alloc, record)),
+		})
+		if to-n < interval {
+			break
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,95 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	defaultDividendHoldersLimit = 100
+	maxDividendHoldersLimit     = 1000
+)
+
+// OlivetumDividendHolderEntry is an account of the dividend holder index.
+type OlivetumDividendHolderEntry struct {
+	Address     common.Address `json:"address"`
+	Held        *hexutil.Big   `json:"held"`
+	Pending     *hexutil.Big   `json:"pending"`
+	LastChanged hexutil.Uint64 `json:"lastChanged"`
+}
+
+// OlivetumDividendHolders is a page of the dividend holder index. Complete is
+// false while the index is still being seeded from the state snapshot, or if
+// seeding had to skip accounts it could not resolve.
+type OlivetumDividendHolders struct {
+	Block    hexutil.Uint64                `json:"block"`
+	Hash     common.Hash                   `json:"hash"`
+	Holders  []OlivetumDividendHolderEntry `json:"holders"`
+	Next     *common.Address               `json:"next"`
+	Complete bool                          `json:"complete"`
+}
+
+// GetDividendHolders pages through the dividend holder index in address order,
+// starting at cursor (the next field of the previous page). At most limit
+// entries are returned (100 by default, 1000 max). Held and pending amounts
+// are read from the state of the given block (latest if omitted); accounts
+// without holdings at that block are skipped. The index lists the accounts
+// holding at the head, so accounts that left since the block are missing. The
+// index is only kept with --olivetum.holderindex.
+func (api *OlivetumAPI) GetDividendHolders(ctx context.Context, cursor *common.Address, limit *hexutil.Uint, blockNrOrHash *rpc.BlockNumberOrHash) (*OlivetumDividendHolders, error) {
+	if !params.IsOlivetumConfig(api.eth.blockchain.Config()) {
+		return nil, fmt.Errorf("not an Olivetum chain")
+	}
+	n := defaultDividendHoldersLimit
+	if limit != nil {
+		n = int(*limit)
+	}
+	if n <= 0 || n > maxDividendHoldersLimit {
+		return nil, fmt.Errorf("limit must be 1 to %d", maxDividendHoldersLimit)
+	}
+	bnh := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bnh = *blockNrOrHash
+	}
+	statedb, header, err := api.eth.APIBackend.StateAndHeaderByNumberOrHash(ctx, bnh)
+	if err != nil {
+		return nil, err
+	}
+	if statedb == nil || header == nil {
+		return nil, fmt.Errorf("block not found")
+	}
+	var start common.Address
+	if cursor != nil {
+		start = *cursor
+	}
+	addrs, entries, next, err := api.eth.blockchain.OlivetumDividendHolders(start, n)
+	if err != nil {
+		return nil, err
+	}
+	out := &OlivetumDividendHolders{
+		Block:    hexutil.Uint64(header.Number.Uint64()),
+		Hash:     header.Hash(),
+		Holders:  make([]OlivetumDividendHolderEntry, 0, len(addrs)),
+		Next:     next,
+		Complete: api.eth.blockchain.OlivetumHolderIndexComplete(),
+	}
+	for i, addr := range addrs {
+		holder := core.GetDividendHolder(statedb, addr, header.Time)
+		if holder.Held.Sign() == 0 && holder.Pending.Sign() == 0 {
+			continue
+		}
+		out.Holders = append(out.Holders, OlivetumDividendHolderEntry{
+			Address:     addr,
+			Held:        (*hexutil.Big)(holder.Held),
+			Pending:     (*hexutil.Big)(holder.Pending),
+			LastChanged: hexutil.Uint64(entries[i].Number),
+		})
+	}
+	return out, nil
+}
@@ -0,0 +1,104 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	defaultMemoTransfersLimit = 100
+	maxMemoTransfersLimit     = 1000
+)
+
+// OlivetumMemoTransfer is a canonical transfer carrying a memo.
+type OlivetumMemoTransfer struct {
+	Hash             common.Hash     `json:"hash"`
+	BlockHash        common.Hash     `json:"blockHash"`
+	BlockNumber      hexutil.Uint64  `json:"blockNumber"`
+	TransactionIndex hexutil.Uint64  `json:"transactionIndex"`
+	From             common.Address  `json:"from"`
+	To               *common.Address `json:"to"`
+	Value            *hexutil.Big    `json:"value"`
+	Memo             hexutil.Bytes   `json:"memo"`
+}
+
+// GetTransfersByMemo returns the canonical transfers carrying the given memo
+// (without the magic prefix), in block order starting at fromBlock (genesis if
+// omitted). At most limit results are returned (100 by default, 1000 max). An
+// error is returned if fromBlock is below the oldest block whose transactions
+// are indexed (see --history.transactions) or while indexing is in progress.
+func (api *OlivetumAPI) GetTransfersByMemo(ctx context.Context, memo hexutil.Bytes, fromBlock *hexutil.Uint64, limit *hexutil.Uint) ([]*OlivetumMemoTransfer, error) {
+	config := api.eth.blockchain.Config()
+	if !params.IsOlivetumConfig(config) {
+		return nil, fmt.Errorf("not an Olivetum chain")
+	}
+	if len(memo) == 0 || len(memo) > params.TransferMemoMaxLength {
+		return nil, fmt.Errorf("memo must be 1 to %d bytes", params.TransferMemoMaxLength)
+	}
+	n := defaultMemoTransfersLimit
+	if limit != nil {
+		n = int(*limit)
+	}
+	if n <= 0 || n > maxMemoTransfersLimit {
+		return nil, fmt.Errorf("limit must be 1 to %d", maxMemoTransfersLimit)
+	}
+	var from uint64
+	if fromBlock != nil {
+		from = uint64(*fromBlock)
+	}
+	// The memo index is resolved through the transaction lookups, so blocks
+	// whose lookups are pruned or not indexed yet would silently be missing.
+	db := api.eth.ChainDb()
+	if progress, err := api.eth.blockchain.TxIndexProgress(); err == nil && !progress.Done() {
+		return nil, errors.New("transaction indexing is in progress")
+	}
+	if tail := rawdb.ReadTxIndexTail(db); tail != nil && from < *tail {
+		return nil, fmt.Errorf("transactions before block %d are not indexed", *tail)
+	}
+	var (
+		out  = make([]*OlivetumMemoTransfer, 0)
+		seen = make(map[common.Hash]struct{})
+	)
+	rawdb.IterateOlivetumMemoEntries(db, memo, from, func(entry rawdb.OlivetumMemoEntry) bool {
+		if err := ctx.Err(); err != nil {
+			return false
+		}
+		if _, ok := seen[entry.TxHash]; ok {
+			return true
+		}
+		// Skip entries left behind by blocks that were reorged out.
+		tx, blockHash, number, index := rawdb.ReadTransaction(db, entry.TxHash)
+		if tx == nil || number != entry.Number {
+			return true
+		}
+		header := api.eth.blockchain.GetHeader(blockHash, number)
+		if header == nil {
+			return true
+		}
+		seen[entry.TxHash] = struct{}{}
+		sender, _ := types.Sender(types.MakeSigner(config, new(big.Int).SetUint64(number), header.Time), tx)
+		out = append(out, &OlivetumMemoTransfer{
+			Hash:             tx.Hash(),
+			BlockHash:        blockHash,
+			BlockNumber:      hexutil.Uint64(number),
+			TransactionIndex: hexutil.Uint64(index),
+			From:             sender,
+			To:               tx.To(),
+			Value:            (*hexutil.Big)(tx.Value()),
+			Memo:             append(hexutil.Bytes{}, memo...),
+		})
+		return len(out) < n
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,91 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/olivetumhash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const maxRewardScheduleEras = 64
+
+// OlivetumRewardEra is a reward era with its projected issuance.
+type OlivetumRewardEra struct {
+	Era        int             `json:"era"`
+	StartBlock hexutil.Uint64  `json:"startBlock"`
+	EndBlock   *hexutil.Uint64 `json:"endBlock"`
+	Reward     *hexutil.Big    `json:"reward"`
+	Burn       *hexutil.Big    `json:"burn"`
+	NetReward  *hexutil.Big    `json:"netReward"`
+	MintedEnd  *hexutil.Big    `json:"mintedAtEnd"`
+	Exhausted  bool            `json:"exhausted"`
+	Historical bool            `json:"historical"`
+}
+
+// OlivetumRewardSchedule is the projected reward schedule.
+type OlivetumRewardSchedule struct {
+	Head            hexutil.Uint64      `json:"head"`
+	TotalMinted     *hexutil.Big        `json:"totalMinted"`
+	MaxSupply       *hexutil.Big        `json:"maxSupply"`
+	BurnRate        uint64              `json:"burnRate"`
+	Eras            []OlivetumRewardEra `json:"eras"`
+	ExhaustionBlock *hexutil.Uint64     `json:"exhaustionBlock"`
+}
+
+// GetRewardSchedule returns up to count reward eras (all by default, 64 max),
+// starting with the era of fromBlock (genesis if omitted): the gross block
+// reward, the burn at the current burn rate and the projected total minted at
+// the end of each era, along with the block at which MaxSupply is projected to
+// run out. The projection starts from the minted total at the head.
+func (api *OlivetumAPI) GetRewardSchedule(ctx context.Context, fromBlock *hexutil.Uint64, count *hexutil.Uint) (*OlivetumRewardSchedule, error) {
+	if !params.IsOlivetumConfig(api.eth.blockchain.Config()) {
+		return nil, fmt.Errorf("not an Olivetum chain")
+	}
+	n := maxRewardScheduleEras
+	if count != nil {
+		n = int(*count)
+	}
+	if n <= 0 || n > maxRewardScheduleEras {
+		return nil, fmt.Errorf("count must be 1 to %d", maxRewardScheduleEras)
+	}
+	var from uint64
+	if fromBlock != nil {
+		from = uint64(*fromBlock)
+	}
+	head := api.eth.blockchain.CurrentBlock()
+	statedb, err := api.eth.blockchain.StateAt(head.Root)
+	if err != nil {
+		return nil, err
+	}
+	schedule := olivetumhash.ProjectRewardSchedule(head.Number.Uint64(), core.GetTotalMinted(statedb), core.GetBurnRate(statedb), from, n)
+	out := &OlivetumRewardSchedule{
+		Head:        hexutil.Uint64(schedule.Head),
+		TotalMinted: (*hexutil.Big)(schedule.Minted),
+		MaxSupply:   (*hexutil.Big)(schedule.MaxSupply),
+		BurnRate:    schedule.BurnRate,
+		Eras:        make([]OlivetumRewardEra, 0, len(schedule.Eras)),
+	}
+	if schedule.Exhaustion != nil {
+		out.ExhaustionBlock = (*hexutil.Uint64)(schedule.Exhaustion)
+	}
+	for _, era := range schedule.Eras {
+		entry := OlivetumRewardEra{
+			Era:        era.Index,
+			StartBlock: hexutil.Uint64(era.Start),
+			Reward:     (*hexutil.Big)(era.Reward),
+			Burn:       (*hexutil.Big)(era.Burn),
+			NetReward:  (*hexutil.Big)(era.Net),
+			MintedEnd:  (*hexutil.Big)(era.MintedEnd),
+			Exhausted:  era.Exhausted,
+			Historical: era.Historical,
+		}
+		if era.End != nil {
+			entry.EndBlock = (*hexutil.Uint64)(era.End)
+		}
+		out.Eras = append(out.Eras, entry)
+	}
+	return out, nil
+}
@@ -0,0 +1,91 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	defaultRichListLimit = 100
+	maxRichListLimit     = 1000
+)
+
+// OlivetumRichListAccount is an account of the rich list.
+type OlivetumRichListAccount struct {
+	Address common.Address `json:"address"`
+	Balance *hexutil.Big   `json:"balance"`
+	Locked  bool           `json:"locked"`
+}
+
+// OlivetumRichList lists the accounts with the largest balances at a block.
+// Complete is false if seeding the index had to skip accounts it could not
+// resolve.
+type OlivetumRichList struct {
+	Block    hexutil.Uint64            `json:"block"`
+	Hash     common.Hash               `json:"hash"`
+	Accounts []OlivetumRichListAccount `json:"accounts"`
+	Complete bool                      `json:"complete"`
+}
+
+// supplyExcluded returns the accounts left out of the circulating supply.
+func (s *Ethereum) supplyExcluded() []common.Address {
+	return s.blockchain.OlivetumSupplyExcluded()
+}
+
+// GetRichList returns the accounts with the largest balances at the given block
+// (latest if omitted), at most limit of them (100 by default, 1000 max).
+// Accounts excluded from the circulating supply are flagged as locked. The
+// index is seeded once from the state snapshot and then follows the accounts
+// touched by transfers, rewards and payouts; it is not served until seeding has
+// finished, and blocks older than its rollback window are not served. The index
+// is only kept with --olivetum.richindex.
+func (api *OlivetumAPI) GetRichList(ctx context.Context, limit *hexutil.Uint, blockNrOrHash *rpc.BlockNumberOrHash) (*OlivetumRichList, error) {
+	if !params.IsOlivetumConfig(api.eth.blockchain.Config()) {
+		return nil, fmt.Errorf("not an Olivetum chain")
+	}
+	n := defaultRichListLimit
+	if limit != nil {
+		n = int(*limit)
+	}
+	if n <= 0 || n > maxRichListLimit {
+		return nil, fmt.Errorf("limit must be 1 to %d", maxRichListLimit)
+	}
+	bnh := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bnh = *blockNrOrHash
+	}
+	header, err := api.eth.APIBackend.HeaderByNumberOrHash(ctx, bnh)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block not found")
+	}
+	addrs, balances, err := api.eth.blockchain.OlivetumRichList(n, header)
+	if err != nil {
+		return nil, err
+	}
+	locked := make(map[common.Address]bool)
+	for _, addr := range api.eth.supplyExcluded() {
+		locked[addr] = true
+	}
+	out := &OlivetumRichList{
+		Block:    hexutil.Uint64(header.Number.Uint64()),
+		Hash:     header.Hash(),
+		Accounts: make([]OlivetumRichListAccount, len(addrs)),
+		Complete: api.eth.blockchain.OlivetumRichListComplete(),
+	}
+	for i, addr := range addrs {
+		out.Accounts[i] = OlivetumRichListAccount{
+			Address: addr,
+			Balance: (*hexutil.Big)(balances[i]),
+			Locked:  locked[addr],
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,125 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// chainHeadChanSize is the size of channel listening to ChainHeadEvent.
+	chainHeadChanSize = 10
+)
+
+// OlivetumSessionInfo describes the session state at a block timestamp along
+// with the limits that apply to transactions in that period.
+type OlivetumSessionInfo struct {
+	BlockNumber      hexutil.Uint64 `json:"blockNumber"`
+	Timestamp        hexutil.Uint64 `json:"timestamp"`
+	Session          bool           `json:"session"`
+	PeriodStart      hexutil.Uint64 `json:"periodStart"`
+	PeriodEnd        hexutil.Uint64 `json:"periodEnd"`
+	NextSessionOpen  hexutil.Uint64 `json:"nextSessionOpen"`
+	NextSessionClose hexutil.Uint64 `json:"nextSessionClose"`
+	TxRateLimit      uint64         `json:"txRateLimit"`
+	MinTxAmount      *hexutil.Big   `json:"minTxAmount"`
+	MaxPerTx         *hexutil.Big   `json:"maxPerTx,omitempty"`
+	BudgetWindow     hexutil.Uint64 `json:"offSessionBudgetWindow"`
+}
+
+// newOlivetumSessionInfo evaluates the session schedule stored in the given
+// state at the header's timestamp.
+func newOlivetumSessionInfo(state vm.StateDB, header *types.Header) *OlivetumSessionInfo {
+	schedule := core.ReadSessionSchedule(state)
+	period := schedule.Period(header.Time)
+	following := schedule.Period(period.End)
+
+	info := &OlivetumSessionInfo{
+		BlockNumber:  hexutil.Uint64(header.Number.Uint64()),
+		Timestamp:    hexutil.Uint64(header.Time),
+		Session:      period.Open,
+		PeriodStart:  hexutil.Uint64(period.Start),
+		PeriodEnd:    hexutil.Uint64(period.End),
+		MinTxAmount:  (*hexutil.Big)(core.ReadMinTxAmount(state)),
+		BudgetWindow: hexutil.Uint64(schedule.BudgetWindow(header.Time)),
+	}
+	if period.Open {
+		info.NextSessionClose = hexutil.Uint64(period.End)
+		info.NextSessionOpen = hexutil.Uint64(following.End)
+		info.TxRateLimit = core.ReadTxRateLimit(state)
+	} else {
+		info.NextSessionOpen = hexutil.Uint64(period.End)
+		info.NextSessionClose = hexutil.Uint64(following.End)
+		info.TxRateLimit = core.ReadOffSessionTxRate(state)
+		info.MaxPerTx = (*hexutil.Big)(core.ReadOffSessionMaxPerTx(state))
+	}
+	return info
+}
+
+// GetSessionInfo returns the session state at the given block (latest if
+// omitted): whether its timestamp is in session, the current and next
+// boundaries, the effective tx rate and per-tx limits, and the off-session
+// budget window key.
+func (api *OlivetumAPI) GetSessionInfo(ctx context.Context, blockNrOrHash *rpc.BlockNumberOrHash) (*OlivetumSessionInfo, error) {
+	state, header, err := api.olivetumState(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return newOlivetumSessionInfo(state, header), nil
+}
+
+// Session sends a notification with the new session info each time the chain
+// head crosses a session boundary.
+func (api *OlivetumAPI) Session(ctx context.Context) (*rpc.Subscription, error) {
+	if !params.IsOlivetumConfig(api.eth.blockchain.Config()) {
+		return nil, fmt.Errorf("not an Olivetum chain")
+	}
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		heads := make(chan core.ChainHeadEvent, chainHeadChanSize)
+		headSub := api.eth.BlockChain().SubscribeChainHeadEvent(heads)
+		defer headSub.Unsubscribe()
+
+		var last *OlivetumSessionInfo
+		if head := api.eth.BlockChain().CurrentBlock(); head != nil {
+			if state, err := api.eth.BlockChain().StateAt(head.Root); err == nil {
+				last = newOlivetumSessionInfo(state, head)
+			}
+		}
+		for {
+			select {
+			case ev := <-heads:
+				header := ev.Block.Header()
+				state, err := api.eth.BlockChain().StateAt(header.Root)
+				if err != nil {
+					continue
+				}
+				info := newOlivetumSessionInfo(state, header)
+				if last != nil && info.Session == last.Session && info.PeriodStart == last.PeriodStart {
+					last = info
+					continue
+				}
+				last = info
+				notifier.Notify(rpcSub.ID, info)
+			case <-rpcSub.Err():
+				return
+			case <-headSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
@@ -0,0 +1,94 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestOlivetumSessionInfoBoundaries(t *testing.T) {
+	oldCal := params.GetSessionCalendar()
+	oldTz := params.GetSessionTzOffsetSeconds()
+	t.Cleanup(func() {
+		params.SetSessionCalendar(oldCal)
+		params.SetSessionTzOffsetSeconds(oldTz)
+	})
+	params.SetSessionCalendar(params.DefaultSessionCalendar())
+	params.SetSessionTzOffsetSeconds(0)
+
+	oldRate := params.GetOffSessionTxRate()
+	t.Cleanup(func() { params.SetOffSessionTxRate(oldRate) })
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	core.SetOffSessionTxRate(statedb, 3)
+	// Keep the runtime value distinct to make sure the state is consulted.
+	params.SetOffSessionTxRate(7)
+
+	// Sunday 10:00 UTC: closed until Monday 12:00, which is open until 24:00.
+	ts := uint64(time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC).Unix())
+	info := newOlivetumSessionInfo(statedb, &types.Header{Number: big.NewInt(5), Time: ts})
+
+	sunday := uint64(time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC).Unix())
+	mondayNoon := uint64(time.Date(2024, time.March, 4, 12, 0, 0, 0, time.UTC).Unix())
+	tuesday := uint64(time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC).Unix())
+	if info.Session {
+		t.Fatalf("expected off-session")
+	}
+	if uint64(info.PeriodStart) != sunday || uint64(info.PeriodEnd) != mondayNoon {
+		t.Fatalf("period mismatch: [%d, %d)", info.PeriodStart, info.PeriodEnd)
+	}
+	if uint64(info.NextSessionOpen) != mondayNoon || uint64(info.NextSessionClose) != tuesday {
+		t.Fatalf("next session mismatch: open %d close %d", info.NextSessionOpen, info.NextSessionClose)
+	}
+	if info.TxRateLimit != 3 {
+		t.Fatalf("tx rate mismatch: got %d want 3", info.TxRateLimit)
+	}
+	if info.MaxPerTx == nil {
+		t.Fatalf("expected off-session per-tx maximum")
+	}
+	if uint64(info.BudgetWindow) != sunday {
+		t.Fatalf("budget window mismatch: got %d want %d", info.BudgetWindow, sunday)
+	}
+}
+
+func TestOlivetumSessionInfoUnconfiguredDefaults(t *testing.T) {
+	oldTz := params.GetSessionTzOffsetSeconds()
+	oldMin := params.GetMinTxAmount()
+	oldRate := params.GetOffSessionTxRate()
+	oldMax := params.GetOffSessionMaxPerTx()
+	t.Cleanup(func() {
+		params.SetSessionTzOffsetSeconds(oldTz)
+		params.SetMinTxAmount(oldMin)
+		params.SetOffSessionTxRate(oldRate)
+		params.SetOffSessionMaxPerTx(oldMax)
+	})
+	// Runtime values of a head that configured the parameters must not leak
+	// into blocks whose state never did.
+	params.SetSessionTzOffsetSeconds(3600)
+	params.SetMinTxAmount(big.NewInt(1))
+	params.SetOffSessionTxRate(7)
+	params.SetOffSessionMaxPerTx(big.NewInt(1))
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	ts := uint64(time.Date(2024, time.March, 3, 10, 0, 0, 0, time.UTC).Unix())
+	info := newOlivetumSessionInfo(statedb, &types.Header{Number: big.NewInt(5), Time: ts})
+
+	if sunday := uint64(time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC).Unix()); uint64(info.PeriodStart) != sunday {
+		t.Fatalf("period start mismatch: got %d want %d", info.PeriodStart, sunday)
+	}
+	if info.TxRateLimit != params.OffSessionTxRateDefault {
+		t.Fatalf("tx rate mismatch: got %d want %d", info.TxRateLimit, params.OffSessionTxRateDefault)
+	}
+	if info.MinTxAmount.ToInt().Cmp(params.MinTxAmountDefault) != 0 {
+		t.Fatalf("min tx amount mismatch: got %v want %v", info.MinTxAmount, params.MinTxAmountDefault)
+	}
+	if info.MaxPerTx.ToInt().Cmp(params.OffSessionMaxPerTxDefault) != 0 {
+		t.Fatalf("max per tx mismatch: got %v want %v", info.MaxPerTx, params.OffSessionMaxPerTxDefault)
+	}
+}
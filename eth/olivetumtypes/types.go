@@ -0,0 +1,113 @@
+// Package olivetumtypes holds the result types of the Olivetum RPC methods,
+// shared by the node and by ethclient/olivetumclient.
+package olivetumtypes
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// RuntimeConfig exposes the currently active runtime parameters
+// (olivetum_getRuntimeConfig).
+type RuntimeConfig struct {
+	BlockPeriod            uint64           `json:"blockPeriod"`
+	GasLimit               uint64           `json:"gasLimit"`
+	MinTxAmount            *hexutil.Big     `json:"minTxAmount"`
+	TxRateLimit            uint64           `json:"txRateLimit"`
+	OffSessionTxRate       uint64           `json:"offSessionTxRate"`
+	OffSessionMaxPerTx     *hexutil.Big     `json:"offSessionMaxPerTx"`
+	SessionTzOffsetSeconds int32            `json:"sessionTzOffsetSeconds"`
+	SessionCalendar        *SessionCalendar `json:"sessionCalendar"`
+	BurnRate               uint64           `json:"burnRate"`
+	DividendRate           uint64           `json:"dividendRate"`
+}
+
+// SessionCalendar exposes the weekly session hours (indexed by weekday,
+// Sunday first) and the closed dates in local time.
+type SessionCalendar struct {
+	Hours    []SessionHours `json:"hours"`
+	Holidays []string       `json:"holidays"`
+}
+
+// SessionHours is the open interval [open, close) of a weekday in local hours.
+// Equal values mean the weekday is closed.
+type SessionHours struct {
+	Open  uint8 `json:"open"`
+	Close uint8 `json:"close"`
+}
+
+// Supply exposes supply-related stats (olivetum_getSupply).
+type Supply struct {
+	TotalMinted   *hexutil.Big `json:"totalMinted"`
+	MaxSupply     *hexutil.Big `json:"maxSupply"`
+	Remaining     *hexutil.Big `json:"remaining"`
+	BurnRate      uint64       `json:"burnRate"`
+	DividendRate  uint64       `json:"dividendRate"`
+	Burned        *hexutil.Big `json:"burned"`
+	BurnedRewards *hexutil.Big `json:"burnedRewards"`
+	Dividends     *hexutil.Big `json:"dividendsMinted"`
+	NetBurned     *hexutil.Big `json:"netBurnedAfterDividends"`
+
+	Outstanding *hexutil.Big     `json:"outstanding"`
+	Locked      *hexutil.Big     `json:"locked"`
+	Circulating *hexutil.Big     `json:"circulating"`
+	Excluded    []common.Address `json:"excluded"`
+}
+
+// TxLimits exposes the transaction limits of an account (eth_getTxLimits).
+type TxLimits struct {
+	Session     bool           `json:"session"`
+	TxRemaining hexutil.Uint64 `json:"txRemaining"`
+	Min         *hexutil.Big   `json:"min"`
+	MaxPerTx    *hexutil.Big   `json:"maxPerTx,omitempty"`
+}
+
+// DividendStatus exposes the dividend round and the claim status of an
+// account (eth_getDividendStatus).
+type DividendStatus struct {
+	Rate       hexutil.Uint64 `json:"rate"`
+	Start      hexutil.Uint64 `json:"start"`
+	Qualify    hexutil.Uint64 `json:"qualify"`
+	Window     hexutil.Uint64 `json:"window"`
+	Claimed    bool           `json:"claimed"`
+	AutoPayout bool           `json:"autoPayout"` // paid out automatically, no claim needed
+}
+
+// DividendView exposes the dividend position of an account
+// (eth_getDividendView).
+type DividendView struct {
+	EligibleNow *hexutil.Big `json:"eligibleNow"`
+	Pending     *hexutil.Big `json:"pending"`
+}
+
+// OffSessionBudget exposes the off-session spending budget of an account
+// (eth_getOffSessionBudget).
+type OffSessionBudget struct {
+	Session        bool           `json:"session"`
+	Enforced       bool           `json:"enforced"`
+	Limit          *hexutil.Big   `json:"limit"`
+	SpentConfirmed *hexutil.Big   `json:"spentConfirmed"`
+	SpentPending   *hexutil.Big   `json:"spentPending"`
+	SpentTotal     *hexutil.Big   `json:"spentTotal"`
+	Remaining      *hexutil.Big   `json:"remaining"`
+	WindowStart    hexutil.Uint64 `json:"windowStart"`
+	WindowEnd      hexutil.Uint64 `json:"windowEnd"`
+	ResetIn        hexutil.Uint64 `json:"resetIn"`
+}
+
+// EconomyStats exposes the economy counters (eth_getEconomyStats).
+type EconomyStats struct {
+	TotalMinted             *hexutil.Big `json:"totalMinted"`
+	MaxSupply               *hexutil.Big `json:"maxSupply"`
+	Remaining               *hexutil.Big `json:"remaining"`
+	BurnRate                uint64       `json:"burnRate"`
+	DividendRate            uint64       `json:"dividendRate"`
+	Burned                  *hexutil.Big `json:"burned"`
+	BurnedTransfers         *hexutil.Big `json:"burnedTransfers"`
+	BurnedGas               *hexutil.Big `json:"burnedGas"`
+	BurnedRewards           *hexutil.Big `json:"burnedRewards"`
+	MinerBurnShare          *hexutil.Big `json:"minerBurnShare"`
+	GrossBurnCharged        *hexutil.Big `json:"grossBurnCharged"`
+	DividendsMinted         *hexutil.Big `json:"dividendsMinted"`
+	NetBurnedAfterDividends *hexutil.Big `json:"netBurnedAfterDividends"`
+}
@@ -1186,6 +1186,7 @@ var allRPCMethods = []string{
 	"debug_intermediateRoots",
 	"debug_memStats",
 	"debug_mutexProfile",
+	"debug_olivetumDividendSnapshot",
 	"debug_preimage",
 	"debug_printBlock",
 	"debug_seedHash",
@@ -0,0 +1,123 @@
+// Package olivetumclient provides an RPC client for the Olivetum-specific APIs.
+package olivetumclient
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/eth/olivetumtypes"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Client is a wrapper around rpc.Client that implements the Olivetum RPC
+// methods of the olivetum namespace and the Olivetum additions to the eth
+// namespace.
+//
+// If you want to use the standardized Ethereum RPC functionality, use ethclient.Client instead.
+type Client struct {
+	c *rpc.Client
+}
+
+// New creates a client that uses the given RPC client.
+func New(c *rpc.Client) *Client {
+	return &Client{c}
+}
+
+// RuntimeConfig returns the runtime parameters in effect at the head block.
+func (oc *Client) RuntimeConfig(ctx context.Context) (*olivetumtypes.RuntimeConfig, error) {
+	var result olivetumtypes.RuntimeConfig
+	if err := oc.c.CallContext(ctx, &result, "olivetum_getRuntimeConfig"); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Supply returns the minted, burned and circulating supply at the head block.
+func (oc *Client) Supply(ctx context.Context) (*olivetumtypes.Supply, error) {
+	var result olivetumtypes.Supply
+	if err := oc.c.CallContext(ctx, &result, "olivetum_getSupply"); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// NetworkHashrate returns the network hashrate in H/s, averaged over the given
+// number of recent blocks. Zero uses the node's default window.
+func (oc *Client) NetworkHashrate(ctx context.Context, blocks uint64) (*big.Int, error) {
+	var result hexutil.Big
+	var err error
+	if blocks == 0 {
+		err = oc.c.CallContext(ctx, &result, "olivetum_getNetworkHashrate")
+	} else {
+		err = oc.c.CallContext(ctx, &result, "olivetum_getNetworkHashrate", hexutil.Uint64(blocks))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&result), nil
+}
+
+// FinalizedHeight returns the finalized height watermark of the node.
+func (oc *Client) FinalizedHeight(ctx context.Context) (uint64, error) {
+	var result hexutil.Uint64
+	err := oc.c.CallContext(ctx, &result, "olivetum_getFinalizedHeight")
+	return uint64(result), err
+}
+
+// TxAllowance returns the number of transactions the account may still send in
+// the current rate limit epoch.
+func (oc *Client) TxAllowance(ctx context.Context, account common.Address) (uint64, error) {
+	var result hexutil.Uint64
+	err := oc.c.CallContext(ctx, &result, "eth_getTxAllowance", account)
+	return uint64(result), err
+}
+
+// TxLimits returns the transaction limits applying to the account.
+func (oc *Client) TxLimits(ctx context.Context, account common.Address) (*olivetumtypes.TxLimits, error) {
+	var result olivetumtypes.TxLimits
+	if err := oc.c.CallContext(ctx, &result, "eth_getTxLimits", account); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DividendStatus returns the current dividend round and whether the account
+// has claimed in it.
+func (oc *Client) DividendStatus(ctx context.Context, account common.Address) (*olivetumtypes.DividendStatus, error) {
+	var result olivetumtypes.DividendStatus
+	if err := oc.c.CallContext(ctx, &result, "eth_getDividendStatus", account); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DividendView returns the eligible and pending dividend holdings of the
+// account.
+func (oc *Client) DividendView(ctx context.Context, account common.Address) (*olivetumtypes.DividendView, error) {
+	var result olivetumtypes.DividendView
+	if err := oc.c.CallContext(ctx, &result, "eth_getDividendView", account); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// OffSessionBudget returns the off-session spending budget of the account,
+// including its transactions pending in the node's pool.
+func (oc *Client) OffSessionBudget(ctx context.Context, account common.Address) (*olivetumtypes.OffSessionBudget, error) {
+	var result olivetumtypes.OffSessionBudget
+	if err := oc.c.CallContext(ctx, &result, "eth_getOffSessionBudget", account); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// EconomyStats returns the mint, burn and dividend counters at the head block.
+func (oc *Client) EconomyStats(ctx context.Context) (*olivetumtypes.EconomyStats, error) {
+	var result olivetumtypes.EconomyStats
+	if err := oc.c.CallContext(ctx, &result, "eth_getEconomyStats"); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,136 @@
+package olivetumclient
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+var (
+	testKey, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr    = crypto.PubkeyToAddress(testKey.PublicKey)
+	testBalance = new(big.Int).Mul(big.NewInt(1000), big.NewInt(vars.Ether))
+)
+
+func newTestBackend(t *testing.T) *node.Node {
+	cfg := &goethereum.ChainConfig{
+		ChainID:             big.NewInt(30216931),
+		HomesteadBlock:      big.NewInt(0),
+		EIP150Block:         big.NewInt(0),
+		EIP155Block:         big.NewInt(0),
+		EIP158Block:         big.NewInt(0),
+		ByzantiumBlock:      big.NewInt(0),
+		ConstantinopleBlock: big.NewInt(0),
+		PetersburgBlock:     big.NewInt(0),
+		IstanbulBlock:       big.NewInt(0),
+		BerlinBlock:         big.NewInt(0),
+	}
+	params.ApplyOlivetumDefaults(cfg)
+	genesis := &genesisT.Genesis{
+		Config:     cfg,
+		GasLimit:   15_000_000,
+		Difficulty: big.NewInt(1),
+		Alloc: genesisT.GenesisAlloc{
+			testAddr: {Balance: testBalance},
+			core.BurnContract: {Balance: new(big.Int), Storage: map[common.Hash]common.Hash{
+				{}: common.BigToHash(big.NewInt(150)),
+			}},
+		},
+	}
+	n, err := node.New(&node.Config{})
+	if err != nil {
+		t.Fatalf("can't create new node: %v", err)
+	}
+	if _, err := eth.New(n, &ethconfig.Config{Genesis: genesis}); err != nil {
+		t.Fatalf("can't create new ethereum service: %v", err)
+	}
+	if err := n.Start(); err != nil {
+		t.Fatalf("can't start test node: %v", err)
+	}
+	t.Cleanup(func() { n.Close() })
+	return n
+}
+
+func TestOlivetumClient(t *testing.T) {
+	backend := newTestBackend(t)
+	client := backend.Attach()
+	defer client.Close()
+
+	var (
+		ctx = context.Background()
+		oc  = New(client)
+	)
+	runtime, err := oc.RuntimeConfig(ctx)
+	if err != nil {
+		t.Fatalf("RuntimeConfig: %v", err)
+	}
+	if runtime.BurnRate != 150 || runtime.SessionCalendar == nil || len(runtime.SessionCalendar.Hours) != 7 {
+		t.Fatalf("RuntimeConfig: have %+v", runtime)
+	}
+	if runtime.MinTxAmount.ToInt().Cmp(params.GetMinTxAmount()) != 0 {
+		t.Fatalf("RuntimeConfig min tx amount: have %v want %v", runtime.MinTxAmount, params.GetMinTxAmount())
+	}
+	supply, err := oc.Supply(ctx)
+	if err != nil {
+		t.Fatalf("Supply: %v", err)
+	}
+	if supply.MaxSupply.ToInt().Cmp(params.MaxSupply()) != 0 || supply.BurnRate != 150 || len(supply.Excluded) == 0 {
+		t.Fatalf("Supply: have %+v", supply)
+	}
+	if supply.Outstanding.ToInt().Cmp(testBalance) != 0 {
+		t.Fatalf("Supply outstanding: have %v want %v", supply.Outstanding, testBalance)
+	}
+	hashrate, err := oc.NetworkHashrate(ctx, 10)
+	if err != nil || hashrate.Sign() != 0 {
+		t.Fatalf("NetworkHashrate: have %v, %v want 0", hashrate, err)
+	}
+	if height, err := oc.FinalizedHeight(ctx); err != nil || height != 0 {
+		t.Fatalf("FinalizedHeight: have %d, %v want 0", height, err)
+	}
+	allowance, err := oc.TxAllowance(ctx, testAddr)
+	if err != nil {
+		t.Fatalf("TxAllowance: %v", err)
+	}
+	limits, err := oc.TxLimits(ctx, testAddr)
+	if err != nil {
+		t.Fatalf("TxLimits: %v", err)
+	}
+	if uint64(limits.TxRemaining) != allowance || limits.Min.ToInt().Cmp(params.GetMinTxAmount()) != 0 {
+		t.Fatalf("TxLimits: have %+v, allowance %d", limits, allowance)
+	}
+	if _, err := oc.DividendStatus(ctx, testAddr); err != nil {
+		t.Fatalf("DividendStatus: %v", err)
+	}
+	view, err := oc.DividendView(ctx, testAddr)
+	if err != nil {
+		t.Fatalf("DividendView: %v", err)
+	}
+	if view.EligibleNow == nil || view.Pending == nil {
+		t.Fatalf("DividendView: have %+v", view)
+	}
+	budget, err := oc.OffSessionBudget(ctx, testAddr)
+	if err != nil {
+		t.Fatalf("OffSessionBudget: %v", err)
+	}
+	if budget.Limit.ToInt().Cmp(params.GetOffSessionMaxPerTx()) != 0 || budget.SpentTotal.ToInt().Sign() != 0 {
+		t.Fatalf("OffSessionBudget: have %+v", budget)
+	}
+	stats, err := oc.EconomyStats(ctx)
+	if err != nil {
+		t.Fatalf("EconomyStats: %v", err)
+	}
+	if stats.BurnRate != 150 || stats.MaxSupply.ToInt().Cmp(params.MaxSupply()) != 0 || stats.Burned.ToInt().Sign() != 0 {
+		t.Fatalf("EconomyStats: have %+v", stats)
+	}
+}
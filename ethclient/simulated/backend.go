@@ -58,12 +58,22 @@ type simClient struct {
 	*ethclient.Client
 }
 
+// blockProducer drives block production of the simulated chain. It is the
+// simulated beacon for the dev chain and an instant sealer for Olivetum chains.
+type blockProducer interface {
+	Commit() common.Hash
+	Rollback()
+	Fork(parentHash common.Hash) error
+	AdjustTime(adjustment time.Duration) error
+	Stop() error
+}
+
 // Backend is a simulated blockchain. You can use it to test your contracts or
 // other code that interacts with the Ethereum chain.
 type Backend struct {
-	eth    *eth.Ethereum
-	beacon *catalyst.SimulatedBeacon
-	client simClient
+	eth      *eth.Ethereum
+	producer blockProducer
+	client   simClient
 }
 
 // NewBackend creates a new simulated blockchain that can be used as a backend for
@@ -118,6 +128,21 @@ func newWithNode(stack *node.Node, conf *eth.Config, blockPeriod uint64) (*Backe
 	if err := stack.Start(); err != nil {
 		return nil, err
 	}
+	client := simClient{ethclient.NewClient(stack.Attach())}
+
+	// Olivetum chains are proof-of-work, so they are sealed by the faker
+	// engine instead of the simulated beacon
+	if params.IsOlivetumConfig(backend.BlockChain().Config()) {
+		sealer, err := newOlivetumSealer(backend)
+		if err != nil {
+			return nil, err
+		}
+		return &Backend{
+			eth:      backend,
+			producer: sealer,
+			client:   client,
+		}, nil
+	}
 	// Set up the simulated beacon
 	beacon, err := catalyst.NewSimulatedBeacon(blockPeriod, backend)
 	if err != nil {
@@ -128,9 +153,9 @@ func newWithNode(stack *node.Node, conf *eth.Config, blockPeriod uint64) (*Backe
 		return nil, err
 	}
 	return &Backend{
-		eth:    backend,
-		beacon: beacon,
-		client: simClient{ethclient.NewClient(stack.Attach())},
+		eth:      backend,
+		producer: beacon,
+		client:   client,
 	}, nil
 }
 
@@ -141,9 +166,9 @@ func (n *Backend) Close() error {
 		n.client.Close()
 		n.client = simClient{}
 	}
-	if n.beacon != nil {
-		err := n.beacon.Stop()
-		n.beacon = nil
+	if n.producer != nil {
+		err := n.producer.Stop()
+		n.producer = nil
 		return err
 	}
 	return nil
@@ -151,12 +176,12 @@ func (n *Backend) Close() error {
 
 // Commit seals a block and moves the chain forward to a new empty block.
 func (n *Backend) Commit() common.Hash {
-	return n.beacon.Commit()
+	return n.producer.Commit()
 }
 
 // Rollback removes all pending transactions, reverting to the last committed state.
 func (n *Backend) Rollback() {
-	n.beacon.Rollback()
+	n.producer.Rollback()
 }
 
 // Fork creates a side-chain that can be used to simulate reorgs.
@@ -172,13 +197,13 @@ func (n *Backend) Rollback() {
 // There is a % chance that the side chain becomes canonical at the same length
 // to simulate live network behavior.
 func (n *Backend) Fork(parentHash common.Hash) error {
-	return n.beacon.Fork(parentHash)
+	return n.producer.Fork(parentHash)
 }
 
 // AdjustTime changes the block timestamp and creates a new block.
 // It can only be called on empty blocks.
 func (n *Backend) AdjustTime(adjustment time.Duration) error {
-	return n.beacon.AdjustTime(adjustment)
+	return n.producer.AdjustTime(adjustment)
 }
 
 // Client returns a client that accesses the simulated chain.
@@ -0,0 +1,310 @@
+package simulated
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/olivetumtx"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+)
+
+var (
+	errNotOlivetum = errors.New("simulated chain does not run the Olivetum rules")
+	errNoAdminKey  = errors.New("no Olivetum administrator key configured")
+
+	// olivetumChainID is the chain ID that enables the Olivetum rules.
+	olivetumChainID = big.NewInt(30216931)
+)
+
+// OlivetumConfig configures a simulated chain running the Olivetum rules.
+type OlivetumConfig struct {
+	// AdminKey signs management transactions. The genesis makes its address
+	// the administrator of all management contracts. If nil, the compiled-in
+	// administrators are kept and the management helpers are unavailable.
+	AdminKey *ecdsa.PrivateKey
+
+	// GenesisTime is the timestamp of the genesis block, zero meaning the
+	// current time. Dividend rounds only start at timestamps close to the wall
+	// clock, so scenarios spanning the holding period need a genesis in the
+	// past.
+	GenesisTime uint64
+
+	// Runtime holds the runtime parameters used while the management
+	// contracts store none. Unset fields take the defaults of a fresh node.
+	Runtime *core.OlivetumRuntime
+}
+
+// NewOlivetumBackend creates a simulated blockchain running the Olivetum rules.
+// Blocks are sealed instantly by the olivetumhash faker, either on Commit or at
+// chosen timestamps through CommitAt and the session helpers.
+func NewOlivetumBackend(alloc genesisT.GenesisAlloc, config OlivetumConfig, options ...func(nodeConf *node.Config, ethConf *ethconfig.Config)) *Backend {
+	options = append([]func(nodeConf *node.Config, ethConf *ethconfig.Config){withOlivetum(config)}, options...)
+	sim := NewBackend(alloc, options...)
+
+	sealer := sim.producer.(*olivetumSealer)
+	sealer.adminKey = config.AdminKey
+	if config.Runtime != nil {
+		statedb, err := sim.eth.BlockChain().State()
+		if err != nil {
+			panic(err) // this should never happen
+		}
+		core.ApplyOlivetumRuntime(statedb, config.Runtime)
+	}
+	return sim
+}
+
+// withOlivetum swaps the dev genesis for an Olivetum one sealed by the
+// olivetumhash faker, storing the administrator of the management contracts if
+// configured.
+func withOlivetum(config OlivetumConfig) func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+	return func(nodeConf *node.Config, ethConf *ethconfig.Config) {
+		chainConfig := &goethereum.ChainConfig{
+			ChainID:             new(big.Int).Set(olivetumChainID),
+			HomesteadBlock:      big.NewInt(0),
+			EIP150Block:         big.NewInt(0),
+			EIP155Block:         big.NewInt(0),
+			EIP158Block:         big.NewInt(0),
+			ByzantiumBlock:      big.NewInt(0),
+			ConstantinopleBlock: big.NewInt(0),
+			PetersburgBlock:     big.NewInt(0),
+			IstanbulBlock:       big.NewInt(0),
+			BerlinBlock:         big.NewInt(0),
+		}
+		params.ApplyOlivetumDefaults(chainConfig)
+
+		timestamp := config.GenesisTime
+		if timestamp == 0 {
+			timestamp = uint64(time.Now().Unix())
+		}
+		ethConf.Genesis.Config = chainConfig
+		ethConf.Genesis.Timestamp = timestamp
+		ethConf.Genesis.Difficulty = big.NewInt(1)
+		if config.AdminKey != nil {
+			alloc := make(genesisT.GenesisAlloc, len(ethConf.Genesis.Alloc)+1)
+			for addr, account := range ethConf.Genesis.Alloc {
+				alloc[addr] = account
+			}
+			core.AllocManagementAdmin(alloc, crypto.PubkeyToAddress(config.AdminKey.PublicKey))
+			ethConf.Genesis.Alloc = alloc
+		}
+		ethConf.Ethash.PowMode = ethash.ModeFake
+	}
+}
+
+// olivetumSealer produces the blocks of an Olivetum chain, mining them
+// instantly with the faker engine.
+type olivetumSealer struct {
+	eth      *eth.Ethereum
+	adminKey *ecdsa.PrivateKey
+}
+
+func newOlivetumSealer(backend *eth.Ethereum) (*olivetumSealer, error) {
+	// Start from the defaults rather than whatever runtime parameters an
+	// earlier chain of this process left behind
+	statedb, err := backend.BlockChain().State()
+	if err != nil {
+		return nil, err
+	}
+	core.ApplyOlivetumRuntime(statedb, nil)
+	return &olivetumSealer{eth: backend}, nil
+}
+
+// seal mines the pending transactions into a new head block. The timestamp is
+// raised to the minimum block interval after the parent if needed.
+func (s *olivetumSealer) seal(timestamp uint64) error {
+	if err := s.eth.TxPool().Sync(); err != nil {
+		return err
+	}
+	var (
+		chain       = s.eth.BlockChain()
+		coinbase, _ = s.eth.Etherbase()
+	)
+	block, err := s.eth.Miner().SealingBlock(chain.CurrentBlock().Hash(), timestamp, coinbase)
+	if err != nil {
+		return err
+	}
+	results := make(chan *types.Block, 1)
+	if err := s.eth.Engine().Seal(chain, block, results, nil); err != nil {
+		return err
+	}
+	_, err = chain.InsertChain(types.Blocks{<-results})
+	return err
+}
+
+// Commit seals a block at the current time.
+func (s *olivetumSealer) Commit() common.Hash {
+	if err := s.seal(uint64(time.Now().Unix())); err != nil {
+		log.Warn("Error performing sealing work", "err", err)
+	}
+	return s.eth.BlockChain().CurrentBlock().Hash()
+}
+
+// Rollback removes all pending transactions.
+func (s *olivetumSealer) Rollback() {
+	s.eth.TxPool().Clear()
+}
+
+// Fork sets the head to the provided hash.
+func (s *olivetumSealer) Fork(parentHash common.Hash) error {
+	if len(s.eth.TxPool().Pending(txpool.PendingFilter{})) != 0 {
+		return errors.New("pending block dirty")
+	}
+	parent := s.eth.BlockChain().GetBlockByHash(parentHash)
+	if parent == nil {
+		return errors.New("parent not found")
+	}
+	return s.eth.BlockChain().SetHead(parent.NumberU64())
+}
+
+// AdjustTime seals an empty block the given duration after the head.
+func (s *olivetumSealer) AdjustTime(adjustment time.Duration) error {
+	if len(s.eth.TxPool().Pending(txpool.PendingFilter{})) != 0 {
+		return errors.New("could not adjust time on non-empty block")
+	}
+	return s.seal(s.eth.BlockChain().CurrentBlock().Time + uint64(adjustment/time.Second))
+}
+
+// Stop does nothing, blocks are only sealed on request.
+func (s *olivetumSealer) Stop() error {
+	return nil
+}
+
+func (n *Backend) olivetumSealer() (*olivetumSealer, error) {
+	sealer, ok := n.producer.(*olivetumSealer)
+	if !ok {
+		return nil, errNotOlivetum
+	}
+	return sealer, nil
+}
+
+// CommitAt seals the pending transactions into a block with the given
+// timestamp, which is raised to the minimum block interval after the head if
+// needed. It is only available on Olivetum chains.
+func (n *Backend) CommitAt(timestamp uint64) (common.Hash, error) {
+	sealer, err := n.olivetumSealer()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := sealer.seal(timestamp); err != nil {
+		return common.Hash{}, err
+	}
+	return n.eth.BlockChain().CurrentBlock().Hash(), nil
+}
+
+// AdvanceToSession seals an empty block at the next minute at which the
+// trading session of the chain is open.
+func (n *Backend) AdvanceToSession() error {
+	return n.advanceToSession(true)
+}
+
+// AdvanceToOffSession seals an empty block at the next minute at which the
+// trading session of the chain is closed.
+func (n *Backend) AdvanceToOffSession() error {
+	return n.advanceToSession(false)
+}
+
+func (n *Backend) advanceToSession(open bool) error {
+	sealer, err := n.olivetumSealer()
+	if err != nil {
+		return err
+	}
+	if len(n.eth.TxPool().Pending(txpool.PendingFilter{})) != 0 {
+		return errors.New("could not adjust time on non-empty block")
+	}
+	head := n.eth.BlockChain().CurrentBlock()
+	statedb, err := n.eth.BlockChain().StateAt(head.Root)
+	if err != nil {
+		return err
+	}
+	// Search from one block period after the head, so the engine keeps the
+	// chosen timestamp, up to two weeks ahead to pass holidays
+	var (
+		schedule = core.ReadSessionSchedule(statedb)
+		start    = head.Time + core.ReadBlockPeriod(statedb)
+		limit    = start + 14*24*60*60
+	)
+	for ts := (start + 59) / 60 * 60; ts < limit; ts += 60 {
+		if schedule.IsOpen(ts) == open {
+			return sealer.seal(ts)
+		}
+	}
+	return errors.New("no session change within two weeks")
+}
+
+// SendManagementTx signs the management call with the administrator key, seals
+// it into a new block at the current time and returns its receipt. An error is
+// returned if the call was rejected or reverted.
+func (n *Backend) SendManagementTx(call *olivetumtx.Call) (*types.Receipt, error) {
+	sealer, err := n.olivetumSealer()
+	if err != nil {
+		return nil, err
+	}
+	if sealer.adminKey == nil {
+		return nil, errNoAdminKey
+	}
+	var (
+		ctx   = context.Background()
+		admin = crypto.PubkeyToAddress(sealer.adminKey.PublicKey)
+	)
+	nonce, err := n.client.PendingNonceAt(ctx, admin)
+	if err != nil {
+		return nil, err
+	}
+	gas, err := n.client.EstimateGas(ctx, ethereum.CallMsg{From: admin, To: &call.To, Data: call.Data})
+	if err != nil {
+		return nil, err
+	}
+	tx, err := types.SignNewTx(sealer.adminKey, types.LatestSignerForChainID(olivetumChainID), &types.LegacyTx{
+		Nonce:    nonce,
+		To:       &call.To,
+		Gas:      gas,
+		GasPrice: new(big.Int),
+		Data:     call.Data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := n.client.SendTransaction(ctx, tx); err != nil {
+		return nil, err
+	}
+	if err := sealer.seal(uint64(time.Now().Unix())); err != nil {
+		return nil, err
+	}
+	receipt, err := n.client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("management transaction %s reverted", tx.Hash())
+	}
+	return receipt, nil
+}
+
+// TriggerDividend starts a dividend round at the given rate in basis points.
+// Rounds are at least the dividend interval apart and start at the current
+// time, so earlier rounds have to lie in the past.
+func (n *Backend) TriggerDividend(rate uint64) error {
+	call, err := olivetumtx.SetDividendRate(rate)
+	if err != nil {
+		return err
+	}
+	_, err = n.SendManagementTx(call)
+	return err
+}
@@ -0,0 +1,260 @@
+package simulated
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/olivetumtx"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+func newOlivetumTestBackend(t *testing.T, config OlivetumConfig) *Backend {
+	t.Helper()
+	sim := NewOlivetumBackend(genesisT.GenesisAlloc{
+		testAddr: {Balance: new(big.Int).Mul(big.NewInt(1_000_000), big.NewInt(vars.Ether))},
+	}, config)
+	t.Cleanup(func() { sim.Close() })
+	return sim
+}
+
+func sendOlivetumTransfer(t *testing.T, sim *Backend, to common.Address, value *big.Int) (*types.Transaction, error) {
+	t.Helper()
+	client := sim.Client()
+	nonce, err := client.PendingNonceAt(context.Background(), testAddr)
+	if err != nil {
+		t.Fatalf("failed to get nonce: %v", err)
+	}
+	tx := types.MustSignNewTx(testKey, types.LatestSignerForChainID(olivetumChainID), &types.LegacyTx{
+		Nonce:    nonce,
+		To:       &to,
+		Value:    value,
+		Gas:      vars.TxGas,
+		GasPrice: new(big.Int),
+	})
+	return tx, client.SendTransaction(context.Background(), tx)
+}
+
+func TestOlivetumBackendTransfers(t *testing.T) {
+	sim := newOlivetumTestBackend(t, OlivetumConfig{})
+	client := sim.Client()
+	ctx := context.Background()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil || chainID.Cmp(olivetumChainID) != 0 {
+		t.Fatalf("chain id mismatch: have %v, want %v (err %v)", chainID, olivetumChainID, err)
+	}
+	// The pool enforces the Olivetum minimum transfer amount
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	if _, err := sendOlivetumTransfer(t, sim, to, big.NewInt(1)); err == nil {
+		t.Fatal("transfer below the minimum amount accepted")
+	}
+	// Transfers are burnt at the default rate
+	value := new(big.Int).Mul(big.NewInt(100), big.NewInt(vars.Ether))
+	tx, err := sendOlivetumTransfer(t, sim, to, value)
+	if err != nil {
+		t.Fatalf("failed to send transfer: %v", err)
+	}
+	sim.Commit()
+
+	receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+	if err != nil || receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("transfer not executed: %v (err %v)", receipt, err)
+	}
+	statedb, err := sim.eth.BlockChain().State()
+	if err != nil {
+		t.Fatalf("failed to get state: %v", err)
+	}
+	burnt := new(big.Int).Div(new(big.Int).Mul(value, new(big.Int).SetUint64(core.GetBurnRate(statedb))), big.NewInt(10000))
+	balance, err := client.BalanceAt(ctx, to, nil)
+	if err != nil {
+		t.Fatalf("failed to get balance: %v", err)
+	}
+	if want := new(big.Int).Sub(value, burnt); balance.Cmp(want) != 0 {
+		t.Errorf("recipient balance mismatch: have %v, want %v", balance, want)
+	}
+}
+
+func TestOlivetumBackendSessions(t *testing.T) {
+	sim := newOlivetumTestBackend(t, OlivetumConfig{})
+	client := sim.Client()
+
+	for _, open := range []bool{false, true, false} {
+		var err error
+		if open {
+			err = sim.AdvanceToSession()
+		} else {
+			err = sim.AdvanceToOffSession()
+		}
+		if err != nil {
+			t.Fatalf("failed to advance to session %v: %v", open, err)
+		}
+		head, err := client.HeaderByNumber(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("failed to get head: %v", err)
+		}
+		if core.IsSession(head.Time) != open {
+			t.Errorf("block %d at %d: session open %v, want %v", head.Number, head.Time, !open, open)
+		}
+	}
+}
+
+func TestOlivetumSessionSubscription(t *testing.T) {
+	sim := newOlivetumTestBackend(t, OlivetumConfig{})
+	if err := sim.AdvanceToOffSession(); err != nil {
+		t.Fatalf("failed to advance off session: %v", err)
+	}
+	sessions := make(chan *eth.OlivetumSessionInfo)
+	sub, err := sim.client.Client.Client().Subscribe(context.Background(), "olivetum", sessions, "session")
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	for _, open := range []bool{true, false, true} {
+		var err error
+		if open {
+			err = sim.AdvanceToSession()
+		} else {
+			err = sim.AdvanceToOffSession()
+		}
+		if err != nil {
+			t.Fatalf("failed to advance to session %v: %v", open, err)
+		}
+		head := sim.eth.BlockChain().CurrentBlock()
+		select {
+		case info := <-sessions:
+			if info.Session != open || uint64(info.BlockNumber) != head.Number.Uint64() {
+				t.Fatalf("notification mismatch: have session %v at block %d, want %v at %d", info.Session, info.BlockNumber, open, head.Number)
+			}
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no notification for session %v", open)
+		}
+	}
+	// A block in the same session period is not notified
+	head := sim.eth.BlockChain().CurrentBlock()
+	if _, err := sim.CommitAt(head.Time + 60); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	select {
+	case info := <-sessions:
+		t.Fatalf("unexpected notification at block %d", info.BlockNumber)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestOlivetumBackendRuntime(t *testing.T) {
+	limit := math.HexOrDecimal64(1)
+	sim := newOlivetumTestBackend(t, OlivetumConfig{
+		Runtime: &core.OlivetumRuntime{TxRateLimit: &limit},
+	})
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	value := new(big.Int).Mul(big.NewInt(10), big.NewInt(vars.Ether))
+
+	if err := sim.AdvanceToSession(); err != nil {
+		t.Fatalf("failed to advance to session: %v", err)
+	}
+	if _, err := sendOlivetumTransfer(t, sim, to, value); err != nil {
+		t.Fatalf("failed to send first transfer: %v", err)
+	}
+	if _, err := sendOlivetumTransfer(t, sim, to, value); err == nil {
+		t.Fatal("transfer above the configured rate limit accepted")
+	}
+}
+
+func TestOlivetumBackendManagement(t *testing.T) {
+	adminKey, _ := crypto.GenerateKey()
+	sim := newOlivetumTestBackend(t, OlivetumConfig{AdminKey: adminKey})
+
+	if params.MinTxAmountAdmin != crypto.PubkeyToAddress(adminKey.PublicKey) {
+		t.Fatalf("administrator not replaced")
+	}
+	call, err := olivetumtx.SetMinTxAmount(big.NewInt(vars.Ether))
+	if err != nil {
+		t.Fatalf("failed to build call: %v", err)
+	}
+	if _, err := sim.SendManagementTx(call); err != nil {
+		t.Fatalf("management transaction failed: %v", err)
+	}
+	if have := params.GetMinTxAmount(); have.Cmp(big.NewInt(vars.Ether)) != 0 {
+		t.Errorf("minimum amount mismatch: have %v, want %v", have, vars.Ether)
+	}
+	sim.Close()
+
+	// Later chains of the process run under their own administrator, or the
+	// compiled-in one without a key
+	otherKey, _ := crypto.GenerateKey()
+	other := newOlivetumTestBackend(t, OlivetumConfig{AdminKey: otherKey})
+	if _, err := other.SendManagementTx(call); err != nil {
+		t.Fatalf("management transaction of the second chain failed: %v", err)
+	}
+	other.Close()
+
+	newOlivetumTestBackend(t, OlivetumConfig{})
+	if params.MinTxAmountAdmin == crypto.PubkeyToAddress(otherKey.PublicKey) {
+		t.Errorf("administrator carried over to a chain without one")
+	}
+}
+
+func TestOlivetumBackendRollback(t *testing.T) {
+	sim := newOlivetumTestBackend(t, OlivetumConfig{})
+	client := sim.Client()
+	ctx := context.Background()
+
+	if err := sim.AdvanceToSession(); err != nil {
+		t.Fatalf("failed to advance to session: %v", err)
+	}
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	tx, err := sendOlivetumTransfer(t, sim, to, new(big.Int).Mul(big.NewInt(10), big.NewInt(vars.Ether)))
+	if err != nil {
+		t.Fatalf("failed to send transfer: %v", err)
+	}
+	sim.Rollback()
+	sim.Commit()
+
+	if _, err := client.TransactionReceipt(ctx, tx.Hash()); err == nil {
+		t.Errorf("rolled back transaction was executed")
+	}
+	if nonce, err := client.PendingNonceAt(ctx, testAddr); err != nil || nonce != 0 {
+		t.Errorf("pending nonce mismatch: have %d, want 0 (err %v)", nonce, err)
+	}
+}
+
+func TestOlivetumBackendDividend(t *testing.T) {
+	adminKey, _ := crypto.GenerateKey()
+	sim := newOlivetumTestBackend(t, OlivetumConfig{
+		AdminKey:    adminKey,
+		GenesisTime: uint64(time.Now().Add(-48 * time.Hour).Unix()),
+	})
+	if err := sim.TriggerDividend(100); err != nil {
+		t.Fatalf("failed to trigger dividend: %v", err)
+	}
+	statedb, err := sim.eth.BlockChain().State()
+	if err != nil {
+		t.Fatalf("failed to get state: %v", err)
+	}
+	if status := core.GetDividendStatus(statedb, testAddr); status.Rate != 100 || status.Start == 0 {
+		t.Errorf("dividend round mismatch: have %+v", status)
+	}
+	// A second round within the dividend interval is rejected
+	if err := sim.TriggerDividend(100); err == nil {
+		t.Error("second dividend round started within the interval")
+	}
+	// Helpers need an Olivetum chain
+	dev := simTestBackend(testAddr)
+	defer dev.Close()
+	if err := dev.TriggerDividend(100); err != errNotOlivetum {
+		t.Errorf("error mismatch: have %v, want %v", err, errNotOlivetum)
+	}
+}
@@ -94,6 +94,8 @@ type Service struct {
 	backend backend
 	engine  consensus.Engine // Consensus engine to retrieve variadic block fields
 
+	olivetum olivetumBackend // Backend of the Olivetum stats, nil if not reported
+
 	node string // Name of the node to display on the monitoring page
 	pass string // Password to authorize access to the monitoring page
 	host string // Remote address of the monitoring service
@@ -179,8 +181,9 @@ func parseEthstatsURL(url string) (parts []string, err error) {
 	return []string{nodename, pass, host}, nil
 }
 
-// New returns a monitoring service ready for stats reporting.
-func New(node *node.Node, backend backend, engine consensus.Engine, url string) error {
+// New returns a monitoring service ready for stats reporting. If olivetum is
+// set, the Olivetum chain stats are reported with every block too.
+func New(node *node.Node, backend backend, engine consensus.Engine, url string, olivetum bool) error {
 	parts, err := parseEthstatsURL(url)
 	if err != nil {
 		return err
@@ -195,6 +198,9 @@ func New(node *node.Node, backend backend, engine consensus.Engine, url string)
 		pongCh:  make(chan struct{}),
 		histCh:  make(chan []uint64, 1),
 	}
+	if olivetum {
+		ethstats.olivetum = olivetumReporter(backend)
+	}
 
 	node.RegisterLifecycle(ethstats)
 	return nil
@@ -345,6 +351,9 @@ func (s *Service) loop(chainHeadCh chan core.ChainHeadEvent, txEventCh chan core
 					if err = s.reportPending(conn); err != nil {
 						log.Warn("Post-block transaction stats report failed", "err", err)
 					}
+					if err = s.reportOlivetum(conn); err != nil {
+						log.Warn("Olivetum stats report failed", "err", err)
+					}
 				case <-txCh:
 					if err = s.reportPending(conn); err != nil {
 						log.Warn("Transaction stats report failed", "err", err)
@@ -533,6 +542,9 @@ func (s *Service) report(conn *connWrapper) error {
 	if err := s.reportStats(conn); err != nil {
 		return err
 	}
+	if err := s.reportOlivetum(conn); err != nil {
+		return err
+	}
 	return nil
 }
 
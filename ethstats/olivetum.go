@@ -0,0 +1,91 @@
+package ethstats
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/olivetumhash"
+	"github.com/ethereum/go-ethereum/eth/olivetumtypes"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+)
+
+// olivetumBackend encompasses the functionality necessary for a full node
+// reporting Olivetum stats to ethstats
+type olivetumBackend interface {
+	ChainConfig() ctypes.ChainConfigurator
+	OlivetumSupply(ctx context.Context) (*olivetumtypes.Supply, error)
+	OlivetumRuntimeConfig(ctx context.Context) (*olivetumtypes.RuntimeConfig, error)
+	OlivetumFinalizedHeight() uint64
+}
+
+// olivetumStats is the Olivetum chain state reported with every block. It is
+// sent as a separate message so dashboards that don't know it can ignore it.
+type olivetumStats struct {
+	Number          *big.Int                     `json:"number"`
+	Hash            common.Hash                  `json:"hash"`
+	Supply          *olivetumtypes.Supply        `json:"supply"`
+	RuntimeConfig   *olivetumtypes.RuntimeConfig `json:"runtimeConfig"`
+	FinalizedHeight uint64                       `json:"finalizedHeight"`
+	Gateway         *olivetumhash.GatewayStats   `json:"gateway,omitempty"`
+}
+
+// olivetumReporter returns the backend to report Olivetum stats from, or nil if
+// the backend is not a full node of an Olivetum chain.
+func olivetumReporter(backend backend) olivetumBackend {
+	b, ok := backend.(olivetumBackend)
+	if !ok || !params.IsOlivetumConfig(b.ChainConfig()) {
+		log.Warn("Olivetum stats need a full node of an Olivetum chain, not reporting them")
+		return nil
+	}
+	return b
+}
+
+// assembleOlivetumStats gathers the Olivetum stats of the current head.
+func (s *Service) assembleOlivetumStats() (*olivetumStats, error) {
+	header := s.backend.CurrentHeader()
+	supply, err := s.olivetum.OlivetumSupply(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	config, err := s.olivetum.OlivetumRuntimeConfig(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	stats := &olivetumStats{
+		Number:          header.Number,
+		Hash:            header.Hash(),
+		Supply:          supply,
+		RuntimeConfig:   config,
+		FinalizedHeight: s.olivetum.OlivetumFinalizedHeight(),
+	}
+	if engine, ok := s.engine.(*olivetumhash.Olivetumhash); ok {
+		gateway := engine.GatewayStats()
+		stats.Gateway = &gateway
+	}
+	return stats, nil
+}
+
+// reportOlivetum reports the Olivetum stats of the current head to the stats
+// server, if enabled.
+func (s *Service) reportOlivetum(conn *connWrapper) error {
+	if s.olivetum == nil {
+		return nil
+	}
+	details, err := s.assembleOlivetumStats()
+	if err != nil {
+		return err
+	}
+	log.Trace("Sending Olivetum stats to ethstats", "number", details.Number, "hash", details.Hash)
+
+	stats := map[string]interface{}{
+		"id":       s.node,
+		"olivetum": details,
+	}
+	report := map[string][]interface{}{
+		"emit": {"olivetum", stats},
+	}
+	return conn.WriteJSON(report)
+}
@@ -0,0 +1,65 @@
+package ethstats
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/olivetumhash"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/olivetumtypes"
+	"github.com/ethereum/go-ethereum/params/types/ctypes"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+)
+
+type olivetumTestBackend struct {
+	backend
+	config ctypes.ChainConfigurator
+	head   *types.Header
+}
+
+func (b *olivetumTestBackend) ChainConfig() ctypes.ChainConfigurator { return b.config }
+func (b *olivetumTestBackend) CurrentHeader() *types.Header          { return b.head }
+func (b *olivetumTestBackend) OlivetumFinalizedHeight() uint64       { return 7 }
+
+func (b *olivetumTestBackend) OlivetumSupply(ctx context.Context) (*olivetumtypes.Supply, error) {
+	return &olivetumtypes.Supply{BurnRate: 150, TotalMinted: (*hexutil.Big)(big.NewInt(1000))}, nil
+}
+
+func (b *olivetumTestBackend) OlivetumRuntimeConfig(ctx context.Context) (*olivetumtypes.RuntimeConfig, error) {
+	return &olivetumtypes.RuntimeConfig{BlockPeriod: 15, BurnRate: 150}, nil
+}
+
+func TestOlivetumStats(t *testing.T) {
+	config := &goethereum.ChainConfig{ChainID: big.NewInt(1)}
+	if olivetumReporter(&olivetumTestBackend{config: config}) != nil {
+		t.Fatal("Olivetum stats enabled on a non-Olivetum chain")
+	}
+	config = &goethereum.ChainConfig{ChainID: big.NewInt(30216931)}
+	backend := &olivetumTestBackend{config: config, head: &types.Header{Number: big.NewInt(12)}}
+	s := &Service{backend: backend, olivetum: olivetumReporter(backend)}
+	if s.olivetum == nil {
+		t.Fatal("Olivetum stats disabled on an Olivetum chain")
+	}
+	stats, err := s.assembleOlivetumStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Number.Uint64() != 12 || stats.Hash != backend.head.Hash() || stats.FinalizedHeight != 7 {
+		t.Errorf("wrong head stats: %+v", stats)
+	}
+	if stats.Supply.BurnRate != 150 || stats.RuntimeConfig.BlockPeriod != 15 || stats.Gateway != nil {
+		t.Errorf("wrong chain stats: %+v", stats)
+	}
+	if _, err := json.Marshal(stats); err != nil {
+		t.Fatal(err)
+	}
+	engine := olivetumhash.NewFaker()
+	defer engine.Close()
+	s.engine = engine
+	if stats, err = s.assembleOlivetumStats(); err != nil || stats.Gateway == nil {
+		t.Errorf("missing gateway stats: %v", err)
+	}
+}
@@ -0,0 +1,282 @@
+package graphql
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/olivetumtypes"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// olivetumState returns the state and header of the account's block, or nil on
+// non-Olivetum chains.
+func (a *Account) olivetumState(ctx context.Context) (*state.StateDB, *types.Header, error) {
+	if !params.IsOlivetumConfig(a.r.backend.ChainConfig()) {
+		return nil, nil, nil
+	}
+	return a.r.backend.StateAndHeaderByNumberOrHash(ctx, a.blockNrOrHash)
+}
+
+func (a *Account) DividendStatus(ctx context.Context) (*OlivetumDividendStatus, error) {
+	state, _, err := a.olivetumState(ctx)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	return &OlivetumDividendStatus{core.GetDividendStatus(state, a.address)}, nil
+}
+
+func (a *Account) DividendView(ctx context.Context) (*OlivetumDividendView, error) {
+	state, header, err := a.olivetumState(ctx)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	return &OlivetumDividendView{core.GetDividendView(state, a.address, header.Time)}, nil
+}
+
+func (a *Account) TxAllowance(ctx context.Context) (*hexutil.Uint64, error) {
+	state, header, err := a.olivetumState(ctx)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	allowance := hexutil.Uint64(core.ReadTxAllowance(state, a.address, header.Time))
+	return &allowance, nil
+}
+
+func (a *Account) OffSessionBudget(ctx context.Context) (*OlivetumOffSessionBudget, error) {
+	state, header, err := a.olivetumState(ctx)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	var (
+		schedule = core.ReadSessionSchedule(state)
+		limit    = core.ReadOffSessionMaxPerTx(state)
+		spent    = core.ReadOffSessionBudgetSpent(state, a.address, header.Time)
+	)
+	remaining := new(big.Int).Sub(limit, spent)
+	if remaining.Sign() < 0 {
+		remaining = new(big.Int)
+	}
+	return &OlivetumOffSessionBudget{
+		session:   schedule.IsOpen(header.Time),
+		limit:     limit,
+		spent:     spent,
+		remaining: remaining,
+		window:    schedule.BudgetWindow(header.Time),
+	}, nil
+}
+
+// OlivetumDividendStatus is the current dividend round of an account.
+type OlivetumDividendStatus struct {
+	status core.DividendStatus
+}
+
+func (s *OlivetumDividendStatus) Rate() hexutil.Uint64    { return hexutil.Uint64(s.status.Rate) }
+func (s *OlivetumDividendStatus) Start() hexutil.Uint64   { return hexutil.Uint64(s.status.Start) }
+func (s *OlivetumDividendStatus) Qualify() hexutil.Uint64 { return hexutil.Uint64(s.status.Qualify) }
+func (s *OlivetumDividendStatus) Window() hexutil.Uint64  { return hexutil.Uint64(s.status.Window) }
+func (s *OlivetumDividendStatus) Claimed() bool           { return s.status.Claimed }
+func (s *OlivetumDividendStatus) AutoPayout() bool        { return s.status.AutoPayout }
+
+// OlivetumDividendView is the split of the dividend holdings of an account.
+type OlivetumDividendView struct {
+	view core.DividendView
+}
+
+func (v *OlivetumDividendView) EligibleNow() hexutil.Big { return hexutil.Big(*v.view.EligibleNow) }
+func (v *OlivetumDividendView) Pending() hexutil.Big     { return hexutil.Big(*v.view.Pending) }
+
+// OlivetumOffSessionBudget is the off-session spending budget of an account.
+type OlivetumOffSessionBudget struct {
+	session                 bool
+	limit, spent, remaining *big.Int
+	window                  uint64
+}
+
+func (b *OlivetumOffSessionBudget) Session() bool                { return b.session }
+func (b *OlivetumOffSessionBudget) Limit() hexutil.Big           { return hexutil.Big(*b.limit) }
+func (b *OlivetumOffSessionBudget) Spent() hexutil.Big           { return hexutil.Big(*b.spent) }
+func (b *OlivetumOffSessionBudget) Remaining() hexutil.Big       { return hexutil.Big(*b.remaining) }
+func (b *OlivetumOffSessionBudget) BudgetWindow() hexutil.Uint64 { return hexutil.Uint64(b.window) }
+
+// olivetumState returns the state and header of the block, or nil on
+// non-Olivetum chains.
+func (b *Block) olivetumState(ctx context.Context) (*state.StateDB, *types.Header, error) {
+	if !params.IsOlivetumConfig(b.r.backend.ChainConfig()) {
+		return nil, nil, nil
+	}
+	if _, err := b.resolveHeader(ctx); err != nil {
+		return nil, nil, err
+	}
+	return b.r.backend.StateAndHeaderByNumberOrHash(ctx, *b.numberOrHash)
+}
+
+func (b *Block) RuntimeConfig(ctx context.Context) (*OlivetumRuntimeConfig, error) {
+	state, header, err := b.olivetumState(ctx)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	return &OlivetumRuntimeConfig{state: state, header: header}, nil
+}
+
+func (b *Block) Supply(ctx context.Context) (*OlivetumSupply, error) {
+	state, _, err := b.olivetumState(ctx)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	return &OlivetumSupply{ethapi.OlivetumEconomyStats(state)}, nil
+}
+
+func (b *Block) BurnRate(ctx context.Context) (*hexutil.Uint64, error) {
+	state, _, err := b.olivetumState(ctx)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	rate := hexutil.Uint64(core.GetBurnRate(state))
+	return &rate, nil
+}
+
+// OlivetumRuntimeConfig is the Olivetum runtime configuration stored in the
+// state of a block.
+type OlivetumRuntimeConfig struct {
+	state  *state.StateDB
+	header *types.Header
+}
+
+func (c *OlivetumRuntimeConfig) BlockPeriod() hexutil.Uint64 {
+	return hexutil.Uint64(core.ReadBlockPeriod(c.state))
+}
+
+func (c *OlivetumRuntimeConfig) MinTxAmount() hexutil.Big {
+	return hexutil.Big(*core.ReadMinTxAmount(c.state))
+}
+
+func (c *OlivetumRuntimeConfig) TxRateLimit() hexutil.Uint64 {
+	return hexutil.Uint64(core.ReadTxRateLimit(c.state))
+}
+
+func (c *OlivetumRuntimeConfig) OffSessionTxRate() hexutil.Uint64 {
+	return hexutil.Uint64(core.ReadOffSessionTxRate(c.state))
+}
+
+func (c *OlivetumRuntimeConfig) OffSessionMaxPerTx() hexutil.Big {
+	return hexutil.Big(*core.ReadOffSessionMaxPerTx(c.state))
+}
+
+func (c *OlivetumRuntimeConfig) SessionTzOffset() int32 {
+	return core.ReadSessionTzOffset(c.state)
+}
+
+func (c *OlivetumRuntimeConfig) SessionHours() []*OlivetumSessionHours {
+	cal := core.ReadSessionCalendar(c.state)
+	hours := make([]*OlivetumSessionHours, len(cal.Hours))
+	for i, h := range cal.Hours {
+		hours[i] = &OlivetumSessionHours{h}
+	}
+	return hours
+}
+
+func (c *OlivetumRuntimeConfig) Holidays() []string {
+	cal := core.ReadSessionCalendar(c.state)
+	holidays := make([]string, 0, len(cal.Holidays))
+	for _, day := range cal.Holidays {
+		holidays = append(holidays, time.Unix(int64(day)*24*60*60, 0).UTC().Format("2006-01-02"))
+	}
+	return holidays
+}
+
+func (c *OlivetumRuntimeConfig) Session() bool {
+	return core.ReadSessionSchedule(c.state).IsOpen(c.header.Time)
+}
+
+func (c *OlivetumRuntimeConfig) BurnRate() hexutil.Uint64 {
+	return hexutil.Uint64(core.GetBurnRate(c.state))
+}
+
+func (c *OlivetumRuntimeConfig) DividendRate() hexutil.Uint64 {
+	return hexutil.Uint64(core.GetDividendRate(c.state))
+}
+
+// OlivetumSessionHours are the session hours of a weekday.
+type OlivetumSessionHours struct {
+	hours params.SessionHours
+}
+
+func (h *OlivetumSessionHours) Open() int32  { return int32(h.hours.Open) }
+func (h *OlivetumSessionHours) Close() int32 { return int32(h.hours.Close) }
+
+// OlivetumSupply holds the mint, burn and dividend counters of a block.
+type OlivetumSupply struct {
+	stats *olivetumtypes.EconomyStats
+}
+
+func (s *OlivetumSupply) TotalMinted() hexutil.Big      { return *s.stats.TotalMinted }
+func (s *OlivetumSupply) MaxSupply() hexutil.Big        { return *s.stats.MaxSupply }
+func (s *OlivetumSupply) Remaining() hexutil.Big        { return *s.stats.Remaining }
+func (s *OlivetumSupply) Burned() hexutil.Big           { return *s.stats.Burned }
+func (s *OlivetumSupply) BurnedTransfers() hexutil.Big  { return *s.stats.BurnedTransfers }
+func (s *OlivetumSupply) BurnedGas() hexutil.Big        { return *s.stats.BurnedGas }
+func (s *OlivetumSupply) BurnedRewards() hexutil.Big    { return *s.stats.BurnedRewards }
+func (s *OlivetumSupply) MinerBurnShare() hexutil.Big   { return *s.stats.MinerBurnShare }
+func (s *OlivetumSupply) GrossBurnCharged() hexutil.Big { return *s.stats.GrossBurnCharged }
+func (s *OlivetumSupply) DividendsMinted() hexutil.Big  { return *s.stats.DividendsMinted }
+func (s *OlivetumSupply) NetBurnedAfterDividends() hexutil.Big {
+	return *s.stats.NetBurnedAfterDividends
+}
+
+// olivetumBurn returns the burn of a mined transaction, or nil for pending
+// transactions and on non-Olivetum chains. The burn rate is read from the
+// parent state.
+func (t *Transaction) olivetumBurn(ctx context.Context) (*core.OlivetumTxBurn, error) {
+	config := t.r.backend.ChainConfig()
+	if !params.IsOlivetumConfig(config) {
+		return nil, nil
+	}
+	_, block := t.resolve(ctx)
+	if block == nil {
+		return nil, nil
+	}
+	b, err := block.resolve(ctx)
+	if err != nil || b == nil {
+		return nil, err
+	}
+	receipts, err := block.resolveReceipts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var rate uint64
+	if b.NumberU64() > 0 {
+		parent, _, err := t.r.backend.StateAndHeaderByNumberOrHash(ctx, rpc.BlockNumberOrHashWithHash(b.ParentHash(), false))
+		if err != nil {
+			return nil, err
+		}
+		rate = core.GetBurnRate(parent)
+	}
+	burns := core.OlivetumTxBurns(b, receipts, types.MakeSigner(config, b.Number(), b.Time()), rate)
+	if int(t.index) >= len(burns) {
+		return nil, nil
+	}
+	return &burns[t.index], nil
+}
+
+func (t *Transaction) Burned(ctx context.Context) (*hexutil.Big, error) {
+	burn, err := t.olivetumBurn(ctx)
+	if burn == nil || err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(burn.Burned), nil
+}
+
+func (t *Transaction) MinerBurnShare(ctx context.Context) (*hexutil.Big, error) {
+	burn, err := t.olivetumBurn(ctx)
+	if burn == nil || err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(burn.MinerBurnShare), nil
+}
@@ -0,0 +1,137 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/consensus/olivetumhash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+func TestGraphQLOlivetum(t *testing.T) {
+	var (
+		key, _    = crypto.GenerateKey()
+		addr      = crypto.PubkeyToAddress(key.PublicKey)
+		recipient = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		value     = new(big.Int).Mul(big.NewInt(20), big.NewInt(vars.Ether))
+		config    = &goethereum.ChainConfig{
+			ChainID:             big.NewInt(30216931),
+			HomesteadBlock:      big.NewInt(0),
+			EIP150Block:         big.NewInt(0),
+			EIP155Block:         big.NewInt(0),
+			EIP158Block:         big.NewInt(0),
+			ByzantiumBlock:      big.NewInt(0),
+			ConstantinopleBlock: big.NewInt(0),
+			PetersburgBlock:     big.NewInt(0),
+			IstanbulBlock:       big.NewInt(0),
+			BerlinBlock:         big.NewInt(0),
+			Olivetumhash:        &params.OlivetumhashConfig{EpochLength: 32, DatasetInitBytes: 4096, MixRounds: 8},
+		}
+	)
+	params.ApplyOlivetumDefaults(config)
+	genesis := &genesisT.Genesis{
+		Config:     config,
+		GasLimit:   15_000_000,
+		Difficulty: big.NewInt(1),
+		Alloc: genesisT.GenesisAlloc{
+			addr: {Balance: new(big.Int).Mul(big.NewInt(1000), big.NewInt(vars.Ether))},
+			core.BurnContract: {Balance: new(big.Int), Storage: map[common.Hash]common.Hash{
+				{}: common.BigToHash(big.NewInt(150)),
+			}},
+		},
+	}
+	stack := createNode(t)
+	defer stack.Close()
+
+	ethBackend, err := eth.New(stack, &ethconfig.Config{
+		Genesis:        genesis,
+		Ethash:         ethash.Config{PowMode: ethash.ModeFake},
+		TrieCleanCache: 5,
+		TrieDirtyCache: 5,
+		TrieTimeout:    60 * time.Minute,
+		SnapshotCache:  5,
+	})
+	if err != nil {
+		t.Fatalf("could not create eth backend: %v", err)
+	}
+	var (
+		signer = types.LatestSigner(config)
+		engine = olivetumhash.New(config.Olivetumhash)
+	)
+	defer engine.Close()
+	chain, _ := core.GenerateChain(config, ethBackend.BlockChain().Genesis(), engine, ethBackend.ChainDb(), 1, func(i int, gen *core.BlockGen) {
+		tx, _ := types.SignNewTx(key, signer, &types.LegacyTx{To: &recipient, Value: value, Gas: 21000, GasPrice: big.NewInt(1)})
+		gen.AddTx(tx)
+	})
+	// The node verifies the seals, so mine the generated blocks.
+	for i, block := range chain {
+		results := make(chan *types.Block, 1)
+		if err := engine.Seal(nil, block, results, nil); err != nil {
+			t.Fatalf("could not seal block %d: %v", block.NumberU64(), err)
+		}
+		chain[i] = <-results
+	}
+	if _, err := ethBackend.BlockChain().InsertChain(chain); err != nil {
+		t.Fatalf("could not import blocks: %v", err)
+	}
+	handler, err := newHandler(stack, ethBackend.APIBackend, filters.NewFilterSystem(ethBackend.APIBackend, filters.Config{}), []string{}, []string{})
+	if err != nil {
+		t.Fatalf("could not create graphql service: %v", err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatalf("could not start node: %v", err)
+	}
+
+	burn := new(big.Int).Div(new(big.Int).Mul(value, big.NewInt(150)), big.NewInt(10000))
+	share := new(big.Int)
+	if fork := params.GetBurnShareForkBlock(); fork.Sign() == 0 || fork.Cmp(common.Big1) <= 0 {
+		share.Div(new(big.Int).Mul(burn, big.NewInt(core.MinerBurnShareBps)), big.NewInt(10000))
+	}
+	burned := new(big.Int).Sub(burn, share)
+
+	for i, tt := range []struct {
+		body string
+		want string
+	}{
+		{
+			body: `{block(number: 1) { burnRate runtimeConfig { burnRate minTxAmount sessionHours { open close } } supply { maxSupply } } }`,
+			want: fmt.Sprintf(`{"block":{"burnRate":"0x96","runtimeConfig":{"burnRate":"0x96","minTxAmount":"%#x","sessionHours":[{"open":0,"close":0},{"open":12,"close":24},{"open":12,"close":24},{"open":12,"close":24},{"open":12,"close":24},{"open":12,"close":24},{"open":12,"close":24}]},"supply":{"maxSupply":"%#x"}}}`,
+				params.GetMinTxAmount(), params.MaxSupply()),
+		},
+		{
+			body: `{block(number: 1) { transactionAt(index: 0) { burned minerBurnShare } } }`,
+			want: fmt.Sprintf(`{"block":{"transactionAt":{"burned":"%#x","minerBurnShare":"%#x"}}}`, burned, share),
+		},
+		{
+			body: fmt.Sprintf(`{block(number: 1) { account(address: "%s") { dividendView { eligibleNow } offSessionBudget { spent } } } }`, recipient),
+			want: `{"block":{"account":{"dividendView":{"eligibleNow":"0x0"},"offSessionBudget":{"spent":"0x0"}}}}`,
+		},
+	} {
+		res := handler.Schema.Exec(context.Background(), tt.body, "", map[string]interface{}{})
+		if res.Errors != nil {
+			t.Fatalf("failed to execute query for testcase #%d: %v", i, res.Errors)
+		}
+		have, err := json.Marshal(res.Data)
+		if err != nil {
+			t.Fatalf("failed to encode graphql response for testcase #%d: %s", i, err)
+		}
+		if string(have) != tt.want {
+			t.Errorf("response unmatch for testcase #%d.\nhave:\n%s\nwant:\n%s", i, have, tt.want)
+		}
+	}
+}
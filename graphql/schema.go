@@ -54,6 +54,59 @@ const schema string = `
         # Storage provides access to the storage of a contract account, indexed
         # by its 32 byte slot identifier.
         storage(slot: Bytes32!): Bytes32!
+        # DividendStatus is the current dividend round and whether this account
+        # claimed in it. Null on non-Olivetum chains.
+        dividendStatus: OlivetumDividendStatus
+        # DividendView splits the dividend holdings of this account into the
+        # amount eligible at the block time and the amount still qualifying.
+        # Null on non-Olivetum chains.
+        dividendView: OlivetumDividendView
+        # TxAllowance is the number of transactions this account may still send
+        # in its rate limit epoch at the block time. Null on non-Olivetum chains.
+        txAllowance: Long
+        # OffSessionBudget is the off-session spending budget of this account at
+        # the block time. Null on non-Olivetum chains.
+        offSessionBudget: OlivetumOffSessionBudget
+    }
+
+    # OlivetumDividendStatus is the current dividend round of an account.
+    type OlivetumDividendStatus {
+        # Rate is the dividend rate of the round, in basis points.
+        rate: Long!
+        # Start is the unix timestamp at which the round started.
+        start: Long!
+        # Qualify is the holding time in seconds for a balance to be eligible.
+        qualify: Long!
+        # Window is the claim window of the round in seconds.
+        window: Long!
+        # Claimed is whether the account claimed in the round.
+        claimed: Boolean!
+        # AutoPayout is whether the dividends of the account are paid out
+        # automatically, without a claim.
+        autoPayout: Boolean!
+    }
+
+    # OlivetumDividendView is the split of the dividend holdings of an account.
+    type OlivetumDividendView {
+        # EligibleNow is the held amount eligible for dividends, in wei.
+        eligibleNow: BigInt!
+        # Pending is the received amount still qualifying, in wei.
+        pending: BigInt!
+    }
+
+    # OlivetumOffSessionBudget is the off-session spending budget of an account.
+    type OlivetumOffSessionBudget {
+        # Session is whether the session is open, in which case no budget applies.
+        session: Boolean!
+        # Limit is the off-session budget per window, in wei.
+        limit: BigInt!
+        # Spent is the amount spent in the current off-session window, in wei.
+        spent: BigInt!
+        # Remaining is the amount left in the current off-session window, in wei.
+        remaining: BigInt!
+        # BudgetWindow is the key of the off-session window containing the block
+        # time.
+        budgetWindow: Long!
     }
 
     # Log is an Ethereum event log.
@@ -170,6 +223,14 @@ const schema string = `
         rawReceipt: Bytes!
         # BlobVersionedHashes is a set of hash outputs from the blobs in the transaction.
         blobVersionedHashes: [Bytes32!]
+        # Burned is the amount removed from the supply by the transfer burn of
+        # this transaction and by the burn of its miner tip, in wei. Null for
+        # pending transactions and on non-Olivetum chains.
+        burned: BigInt
+        # MinerBurnShare is the share of the gross burn of this transaction paid
+        # to the miner, in wei. Null for pending transactions and on
+        # non-Olivetum chains.
+        minerBurnShare: BigInt
     }
 
     # BlockFilterCriteria encapsulates log filter criteria for a filter applied
@@ -279,6 +340,68 @@ const schema string = `
         blobGasUsed: Long
         # ExcessBlobGas is a running total of blob gas consumed in excess of the target, prior to the block.
         excessBlobGas: Long
+        # RuntimeConfig is the Olivetum runtime configuration in the state of
+        # this block. Null on non-Olivetum chains.
+        runtimeConfig: OlivetumRuntimeConfig
+        # Supply holds the Olivetum mint, burn and dividend counters in the state
+        # of this block. Null on non-Olivetum chains.
+        supply: OlivetumSupply
+        # BurnRate is the transfer burn rate in the state of this block, in basis
+        # points. Null on non-Olivetum chains.
+        burnRate: Long
+    }
+
+    # OlivetumRuntimeConfig is the Olivetum runtime configuration of a block.
+    type OlivetumRuntimeConfig {
+        # BlockPeriod is the target block period in seconds.
+        blockPeriod: Long!
+        # MinTxAmount is the minimum transfer amount, in wei.
+        minTxAmount: BigInt!
+        # TxRateLimit is the number of transactions per hour an account may send
+        # in session.
+        txRateLimit: Long!
+        # OffSessionTxRate is the number of transactions per hour an account may
+        # send off session.
+        offSessionTxRate: Long!
+        # OffSessionMaxPerTx is the maximum transfer amount off session, in wei.
+        offSessionMaxPerTx: BigInt!
+        # SessionTzOffset is the offset of the session calendar from UTC, in
+        # seconds.
+        sessionTzOffset: Int!
+        # SessionHours lists the session hours of the weekdays, starting on
+        # Sunday.
+        sessionHours: [OlivetumSessionHours!]!
+        # Holidays lists the closed dates of the session calendar as YYYY-MM-DD.
+        holidays: [String!]!
+        # Session is whether the block timestamp is in session.
+        session: Boolean!
+        # BurnRate is the transfer burn rate, in basis points.
+        burnRate: Long!
+        # DividendRate is the dividend rate, in basis points.
+        dividendRate: Long!
+    }
+
+    # OlivetumSessionHours are the session hours [open, close) of a weekday in
+    # local time.
+    type OlivetumSessionHours {
+        open: Int!
+        close: Int!
+    }
+
+    # OlivetumSupply holds the Olivetum mint, burn and dividend counters of a
+    # block. All amounts are in wei.
+    type OlivetumSupply {
+        totalMinted: BigInt!
+        maxSupply: BigInt!
+        remaining: BigInt!
+        burned: BigInt!
+        burnedTransfers: BigInt!
+        burnedGas: BigInt!
+        burnedRewards: BigInt!
+        minerBurnShare: BigInt!
+        grossBurnCharged: BigInt!
+        dividendsMinted: BigInt!
+        netBurnedAfterDividends: BigInt!
     }
 
     # CallData represents the data associated with a local contract call.
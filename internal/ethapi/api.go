@@ -42,6 +42,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/gasestimator"
+	"github.com/ethereum/go-ethereum/eth/olivetumtypes"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p"
@@ -66,54 +67,6 @@ type EthereumAPI struct {
 	b Backend
 }
 
-type TxLimits struct {
-	Session     bool           `json:"session"`
-	TxRemaining hexutil.Uint64 `json:"txRemaining"`
-	Min         *hexutil.Big   `json:"min"`
-	MaxPerTx    *hexutil.Big   `json:"maxPerTx,omitempty"`
-}
-
-type DividendStatus struct {
-	Rate    hexutil.Uint64 `json:"rate"`
-	Start   hexutil.Uint64 `json:"start"`
-	Qualify hexutil.Uint64 `json:"qualify"`
-	Window  hexutil.Uint64 `json:"window"`
-	Claimed bool           `json:"claimed"`
-}
-
-type DividendView struct {
-	EligibleNow *hexutil.Big `json:"eligibleNow"`
-	Pending     *hexutil.Big `json:"pending"`
-}
-
-type OffSessionBudget struct {
-	Session        bool           `json:"session"`
-	Enforced       bool           `json:"enforced"`
-	Limit          *hexutil.Big   `json:"limit"`
-	SpentConfirmed *hexutil.Big   `json:"spentConfirmed"`
-	SpentPending   *hexutil.Big   `json:"spentPending"`
-	SpentTotal     *hexutil.Big   `json:"spentTotal"`
-	Remaining      *hexutil.Big   `json:"remaining"`
-	WindowStart    hexutil.Uint64 `json:"windowStart"`
-	WindowEnd      hexutil.Uint64 `json:"windowEnd"`
-	ResetIn        hexutil.Uint64 `json:"resetIn"`
-}
-
-type EconomyStats struct {
-	TotalMinted             *hexutil.Big `json:"totalMinted"`
-	MaxSupply               *hexutil.Big `json:"maxSupply"`
-	Remaining               *hexutil.Big `json:"remaining"`
-	BurnRate                uint64       `json:"burnRate"`
-	DividendRate            uint64       `json:"dividendRate"`
-	Burned                  *hexutil.Big `json:"burned"`
-	BurnedTransfers         *hexutil.Big `json:"burnedTransfers"`
-	BurnedGas               *hexutil.Big `json:"burnedGas"`
-	MinerBurnShare          *hexutil.Big `json:"minerBurnShare"`
-	GrossBurnCharged        *hexutil.Big `json:"grossBurnCharged"`
-	DividendsMinted         *hexutil.Big `json:"dividendsMinted"`
-	NetBurnedAfterDividends *hexutil.Big `json:"netBurnedAfterDividends"`
-}
-
 // NewEthereumAPI creates a new Ethereum protocol API.
 func NewEthereumAPI(b Backend) *EthereumAPI {
 	return &EthereumAPI{b}
@@ -149,7 +102,7 @@ func (s *EthereumAPI) GetTxAllowance(ctx context.Context, addr common.Address) (
 	return hexutil.Uint64(allowance), nil
 }
 
-func (s *EthereumAPI) GetTxLimits(ctx context.Context, addr common.Address) (*TxLimits, error) {
+func (s *EthereumAPI) GetTxLimits(ctx context.Context, addr common.Address) (*olivetumtypes.TxLimits, error) {
 	state, header, err := s.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
 	if err != nil {
 		return nil, err
@@ -162,7 +115,7 @@ func (s *EthereumAPI) GetTxLimits(ctx context.Context, addr common.Address) (*Tx
 	if !session {
 		max = (*hexutil.Big)(params.GetOffSessionMaxPerTx())
 	}
-	return &TxLimits{
+	return &olivetumtypes.TxLimits{
 		Session:     session,
 		TxRemaining: hexutil.Uint64(allow),
 		Min:         (*hexutil.Big)(min),
@@ -190,71 +143,43 @@ func (s *EthereumAPI) GetTxUsage(ctx context.Context, addr common.Address) (map[
 	return resp, nil
 }
 
-func (s *EthereumAPI) GetDividendStatus(ctx context.Context, address common.Address) (*DividendStatus, error) {
+func (s *EthereumAPI) GetDividendStatus(ctx context.Context, address common.Address) (*olivetumtypes.DividendStatus, error) {
 	state, _, err := s.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
 	if err != nil {
 		return nil, err
 	}
 	ds := core.GetDividendStatus(state, address)
-	return &DividendStatus{
-		Rate:    hexutil.Uint64(ds.Rate),
-		Start:   hexutil.Uint64(ds.Start),
-		Qualify: hexutil.Uint64(ds.Qualify),
-		Window:  hexutil.Uint64(ds.Window),
-		Claimed: ds.Claimed,
+	return &olivetumtypes.DividendStatus{
+		Rate:       hexutil.Uint64(ds.Rate),
+		Start:      hexutil.Uint64(ds.Start),
+		Qualify:    hexutil.Uint64(ds.Qualify),
+		Window:     hexutil.Uint64(ds.Window),
+		Claimed:    ds.Claimed,
+		AutoPayout: ds.AutoPayout,
 	}, nil
 }
 
-func (s *EthereumAPI) GetDividendView(ctx context.Context, address common.Address) (*DividendView, error) {
+func (s *EthereumAPI) GetDividendView(ctx context.Context, address common.Address) (*olivetumtypes.DividendView, error) {
 	state, header, err := s.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
 	if err != nil {
 		return nil, err
 	}
 	view := core.GetDividendView(state, address, header.Time)
-	return &DividendView{
+	return &olivetumtypes.DividendView{
 		EligibleNow: (*hexutil.Big)(view.EligibleNow),
 		Pending:     (*hexutil.Big)(view.Pending),
 	}, nil
 }
 
 func offSessionBudgetWindowRange(ts uint64) (uint64, uint64) {
-	off := int64(params.GetSessionTzOffsetSeconds())
-	local := int64(ts) + off
-	if local < 0 {
+	period := core.GetSessionPeriod(ts)
+	if period.Open {
 		return 0, 0
 	}
-	t := time.Unix(local, 0).UTC()
-	if t.Weekday() != time.Sunday && t.Hour() >= 12 && t.Hour() < 24 {
-		return 0, 0
-	}
-
-	day := int64(24 * time.Hour / time.Second)
-	startLocal := (local / day) * day
-	if t.Weekday() == time.Monday && t.Hour() < 12 {
-		startLocal -= day
-		if startLocal < 0 {
-			startLocal = 0
-		}
-	}
-
-	windowLen := int64(12 * time.Hour / time.Second)
-	if time.Unix(startLocal, 0).UTC().Weekday() == time.Sunday {
-		windowLen = int64(36 * time.Hour / time.Second)
-	}
-	endLocal := startLocal + windowLen
-
-	startUTC := startLocal - off
-	endUTC := endLocal - off
-	if startUTC < 0 {
-		startUTC = 0
-	}
-	if endUTC < 0 {
-		endUTC = 0
-	}
-	return uint64(startUTC), uint64(endUTC)
+	return period.Start, period.End
 }
 
-func (s *EthereumAPI) GetOffSessionBudget(ctx context.Context, address common.Address) (*OffSessionBudget, error) {
+func (s *EthereumAPI) GetOffSessionBudget(ctx context.Context, address common.Address) (*olivetumtypes.OffSessionBudget, error) {
 	state, header, err := s.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
 	if err != nil {
 		return nil, err
@@ -302,7 +227,7 @@ func (s *EthereumAPI) GetOffSessionBudget(ctx context.Context, address common.Ad
 	if windowEnd > 0 && now < windowEnd {
 		resetIn = windowEnd - now
 	}
-	return &OffSessionBudget{
+	return &olivetumtypes.OffSessionBudget{
 		Session:        session,
 		Enforced:       enforced,
 		Limit:          (*hexutil.Big)(limit),
@@ -316,11 +241,16 @@ func (s *EthereumAPI) GetOffSessionBudget(ctx context.Context, address common.Ad
 	}, nil
 }
 
-func (s *EthereumAPI) GetEconomyStats(ctx context.Context) (*EconomyStats, error) {
+func (s *EthereumAPI) GetEconomyStats(ctx context.Context) (*olivetumtypes.EconomyStats, error) {
 	state, _, err := s.b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
 	if err != nil {
 		return nil, err
 	}
+	return OlivetumEconomyStats(state), nil
+}
+
+// OlivetumEconomyStats returns the mint, burn and dividend counters of a state.
+func OlivetumEconomyStats(state vm.StateDB) *olivetumtypes.EconomyStats {
 	totalMinted := core.GetTotalMinted(state)
 	maxSupply := params.MaxSupply()
 	remaining := new(big.Int).Sub(maxSupply, totalMinted)
@@ -331,6 +261,7 @@ func (s *EthereumAPI) GetEconomyStats(ctx context.Context) (*EconomyStats, error
 	burned := core.GetTotalBurned(state)
 	burnedTransfers := core.GetTotalBurnedTransfers(state)
 	burnedGas := core.GetTotalBurnedGas(state)
+	burnedRewards := core.GetTotalBurnedRewards(state)
 	minerShare := core.GetTotalMinerBurnShare(state)
 	grossBurnCharged := new(big.Int).Add(new(big.Int).Set(burned), minerShare)
 
@@ -340,7 +271,7 @@ func (s *EthereumAPI) GetEconomyStats(ctx context.Context) (*EconomyStats, error
 		netBurned = new(big.Int)
 	}
 
-	return &EconomyStats{
+	return &olivetumtypes.EconomyStats{
 		TotalMinted:             (*hexutil.Big)(totalMinted),
 		MaxSupply:               (*hexutil.Big)(maxSupply),
 		Remaining:               (*hexutil.Big)(remaining),
@@ -349,11 +280,12 @@ func (s *EthereumAPI) GetEconomyStats(ctx context.Context) (*EconomyStats, error
 		Burned:                  (*hexutil.Big)(burned),
 		BurnedTransfers:         (*hexutil.Big)(burnedTransfers),
 		BurnedGas:               (*hexutil.Big)(burnedGas),
+		BurnedRewards:           (*hexutil.Big)(burnedRewards),
 		MinerBurnShare:          (*hexutil.Big)(minerShare),
 		GrossBurnCharged:        (*hexutil.Big)(grossBurnCharged),
 		DividendsMinted:         (*hexutil.Big)(dividends),
 		NetBurnedAfterDividends: (*hexutil.Big)(netBurned),
-	}, nil
+	}
 }
 
 type feeHistoryResult struct {
@@ -1741,6 +1673,7 @@ type RPCTransaction struct {
 	R                   *hexutil.Big      `json:"r"`
 	S                   *hexutil.Big      `json:"s"`
 	YParity             *hexutil.Uint64   `json:"yParity,omitempty"`
+	Memo                *hexutil.Bytes    `json:"memo,omitempty"`
 }
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
@@ -1768,6 +1701,17 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 		result.BlockNumber = (*hexutil.Big)(new(big.Int).SetUint64(blockNumber))
 		result.TransactionIndex = (*hexutil.Uint64)(&index)
 	}
+	if params.IsOlivetumConfig(config) {
+		// Pending transactions are included at the earliest in the block
+		// after the head.
+		number := new(big.Int).SetUint64(blockNumber)
+		if blockHash == (common.Hash{}) {
+			number.Add(number, common.Big1)
+		}
+		if memo, ok := core.TransferMemo(tx); ok && params.TransferMemoFork.Active(number) {
+			result.Memo = (*hexutil.Bytes)(&memo)
+		}
+	}
 
 	switch tx.Type() {
 	case types.LegacyTxType:
@@ -12,8 +12,10 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/params/types/genesisT"
+	"github.com/ethereum/go-ethereum/params/types/goethereum"
 )
 
 func TestGetEconomyStatsReturnsStateCounters(t *testing.T) {
@@ -22,6 +24,7 @@ func TestGetEconomyStatsReturnsStateCounters(t *testing.T) {
 	burnedTransfers := big.NewInt(70)
 	burnedGas := big.NewInt(30)
 	minerShare := big.NewInt(5)
+	burnedRewards := big.NewInt(12)
 	dividends := big.NewInt(40)
 
 	rewardVaultStorage := map[common.Hash]common.Hash{
@@ -31,6 +34,7 @@ func TestGetEconomyStatsReturnsStateCounters(t *testing.T) {
 		{0: 0x0f}: common.BigToHash(burnedTransfers),
 		{0: 0x10}: common.BigToHash(burnedGas),
 		{0: 0x11}: common.BigToHash(minerShare),
+		{0: 0x12}: common.BigToHash(burnedRewards),
 	}
 	burnStorage := map[common.Hash]common.Hash{
 		{}: common.BigToHash(new(big.Int).SetUint64(150)),
@@ -66,6 +70,9 @@ func TestGetEconomyStatsReturnsStateCounters(t *testing.T) {
 	if (*big.Int)(stats.BurnedGas).Cmp(burnedGas) != 0 {
 		t.Fatalf("burnedGas mismatch: got %v want %v", (*big.Int)(stats.BurnedGas), burnedGas)
 	}
+	if (*big.Int)(stats.BurnedRewards).Cmp(burnedRewards) != 0 {
+		t.Fatalf("burnedRewards mismatch: got %v want %v", (*big.Int)(stats.BurnedRewards), burnedRewards)
+	}
 	if (*big.Int)(stats.MinerBurnShare).Cmp(minerShare) != 0 {
 		t.Fatalf("minerBurnShare mismatch: got %v want %v", (*big.Int)(stats.MinerBurnShare), minerShare)
 	}
@@ -199,3 +206,33 @@ func TestGetOffSessionBudgetIncludesTxPoolPending(t *testing.T) {
 		t.Fatalf("resetIn mismatch: got %d want %d", uint64(budget.ResetIn), end-ts)
 	}
 }
+
+func TestRPCTransactionMemoForkGated(t *testing.T) {
+	old := params.TransferMemoFork.Block()
+	t.Cleanup(func() { params.TransferMemoFork.SetBlock(old) })
+	params.TransferMemoFork.SetBlock(big.NewInt(10))
+
+	config := &goethereum.ChainConfig{ChainID: big.NewInt(30216931), HomesteadBlock: big.NewInt(0), EIP155Block: big.NewInt(0)}
+	params.ApplyOlivetumDefaults(config)
+
+	key, _ := crypto.GenerateKey()
+	to := common.HexToAddress("0xbbbb")
+	tx := types.MustSignNewTx(key, types.LatestSignerForChainID(config.ChainID), &types.LegacyTx{
+		To:    &to,
+		Value: big.NewInt(1),
+		Gas:   100000,
+		Data:  params.EncodeTransferMemo([]byte("invoice")),
+	})
+	blockHash := common.HexToHash("0x01")
+	if memo := newRPCTransaction(tx, blockHash, 9, 0, 0, nil, config).Memo; memo != nil {
+		t.Fatalf("memo reported before the fork: %x", *memo)
+	}
+	if memo := newRPCTransaction(tx, blockHash, 10, 0, 0, nil, config).Memo; memo == nil || string(*memo) != "invoice" {
+		t.Fatalf("memo mismatch at the fork: %v", memo)
+	}
+	// Pending transactions are evaluated for the block after the head.
+	head := &types.Header{Number: big.NewInt(9), Difficulty: big.NewInt(1)}
+	if memo := NewRPCPendingTransaction(tx, head, config).Memo; memo == nil {
+		t.Fatalf("pending memo not reported for the fork block")
+	}
+}
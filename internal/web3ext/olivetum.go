@@ -0,0 +1,414 @@
+package web3ext
+
+// OlivetumJs binds the olivetum namespace and the Olivetum methods of the eth
+// namespace. Amounts are shown in Olivo and quantities as decimals. The admin
+// helpers send the management transactions from eth.defaultAccount, or from
+// the account given as last argument, which must be unlocked.
+const OlivetumJs = `
+(function() {
+	var utils = web3._extend.utils;
+
+	var amountFields = {
+		balance: true, burn: true, burned: true, burnedGas: true, burnedOnSend: true, burnedRewards: true,
+		burnedTransfers: true, circulating: true, circulatingSupply: true, dividendsClaimed: true,
+		dividendsMinted: true, eligibleNow: true, gasFees: true, grossBurnCharged: true, held: true,
+		limit: true, locked: true, maxPerTx: true, maxSupply: true, min: true, minTxAmount: true,
+		minerBurnShare: true, mintedAtEnd: true, netBurnedAfterDividends: true, netReward: true,
+		offSessionMaxPerTx: true, outstanding: true, outstandingSupply: true, pending: true,
+		remaining: true, reward: true, spentConfirmed: true, spentPending: true, spentTotal: true,
+		totalEligible: true, totalMinted: true, totalRewards: true, value: true
+	};
+	var quantityFields = {
+		block: true, blockNumber: true, blocks: true, budgetWindow: true, endBlock: true,
+		exhaustionBlock: true, fromBlock: true, head: true, holdingSince: true, indexedHead: true,
+		lastChanged: true, logIndex: true, missingPreimages: true, nextSessionClose: true,
+		nextSessionOpen: true, offSessionBudgetWindow: true, periodEnd: true, periodStart: true,
+		qualify: true, rate: true, resetIn: true, start: true, startBlock: true, time: true,
+		timestamp: true, toBlock: true, transactionIndex: true, txRemaining: true, window: true,
+		windowEnd: true, windowStart: true
+	};
+
+	// format converts the hex amounts of a result to Olivo and its hex
+	// quantities to numbers.
+	var format = function(value, key) {
+		if (utils.isArray(value)) {
+			return value.map(function(v) { return format(v); });
+		}
+		if (value !== null && typeof value === 'object') {
+			var formatted = {};
+			for (var k in value) {
+				formatted[k] = format(value[k], k);
+			}
+			return formatted;
+		}
+		if (utils.isString(value) && value.indexOf('0x') === 0) {
+			if (amountFields[key]) {
+				return utils.fromWei(utils.toBigNumber(value), 'ether');
+			}
+			if (quantityFields[key]) {
+				return utils.toDecimal(value);
+			}
+		}
+		return value;
+	};
+	var formatResult = function(result) { return format(result); };
+	var decimals = function(result) {
+		var formatted = {};
+		for (var k in result) {
+			formatted[k] = utils.toDecimal(result[k]);
+		}
+		return formatted;
+	};
+	var optionalQuantity = function(value) {
+		return (value === undefined || value === null) ? null : utils.fromDecimal(value);
+	};
+	var optionalBlock = function(value) {
+		return (value === undefined || value === null) ? null : web3._extend.formatters.inputBlockNumberFormatter(value);
+	};
+	var memoInput = function(memo) {
+		return (utils.isString(memo) && memo.indexOf('0x') !== 0) ? utils.fromUtf8(memo) : memo;
+	};
+
+	web3._extend({
+		property: 'olivetum',
+		methods: [
+			new web3._extend.Method({
+				name: 'getRuntimeConfig',
+				call: 'olivetum_getRuntimeConfig',
+				params: 0,
+				outputFormatter: formatResult
+			}),
+			new web3._extend.Method({
+				name: 'getFinalizedHeight',
+				call: 'olivetum_getFinalizedHeight',
+				params: 0,
+				outputFormatter: utils.toDecimal
+			}),
+			new web3._extend.Method({
+				name: 'getSupply',
+				call: 'olivetum_getSupply',
+				params: 0,
+				outputFormatter: formatResult
+			}),
+			new web3._extend.Method({
+				name: 'getNetworkHashrate',
+				call: 'olivetum_getNetworkHashrate',
+				params: 1,
+				inputFormatter: [optionalQuantity],
+				outputFormatter: utils.toBigNumber
+			}),
+			new web3._extend.Method({
+				name: 'getSessionInfo',
+				call: 'olivetum_getSessionInfo',
+				params: 1,
+				inputFormatter: [optionalBlock],
+				outputFormatter: formatResult
+			}),
+			new web3._extend.Method({
+				name: 'getAddressClasses',
+				call: 'olivetum_getAddressClasses',
+				params: 2,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter, optionalBlock]
+			}),
+			new web3._extend.Method({
+				name: 'getAddressList',
+				call: 'olivetum_getAddressList',
+				params: 2,
+				inputFormatter: [null, optionalBlock]
+			}),
+			new web3._extend.Method({
+				name: 'getDividendPayouts',
+				call: 'olivetum_getDividendPayouts',
+				params: 1,
+				inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter],
+				outputFormatter: formatResult
+			}),
+			new web3._extend.Method({
+				name: 'getDividendHolders',
+				call: 'olivetum_getDividendHolders',
+				params: 3,
+				inputFormatter: [null, optionalQuantity, optionalBlock],
+				outputFormatter: formatResult
+			}),
+			new web3._extend.Method({
+				name: 'getEconomyHistory',
+				call: 'olivetum_getEconomyHistory',
+				params: 3,
+				inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter, optionalQuantity],
+				outputFormatter: formatResult
+			}),
+			new web3._extend.Method({
+				name: 'getAccountEconomy',
+				call: 'olivetum_getAccountEconomy',
+				params: 3,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter],
+				outputFormatter: formatResult
+			}),
+			new web3._extend.Method({
+				name: 'getTransfersByMemo',
+				call: 'olivetum_getTransfersByMemo',
+				params: 3,
+				inputFormatter: [memoInput, optionalQuantity, optionalQuantity],
+				outputFormatter: formatResult
+			}),
+			new web3._extend.Method({
+				name: 'getRewardSchedule',
+				call: 'olivetum_getRewardSchedule',
+				params: 2,
+				inputFormatter: [optionalQuantity, optionalQuantity],
+				outputFormatter: formatResult
+			}),
+			new web3._extend.Method({
+				name: 'getRichList',
+				call: 'olivetum_getRichList',
+				params: 2,
+				inputFormatter: [optionalQuantity, optionalBlock],
+				outputFormatter: formatResult
+			}),
+		],
+		properties: [
+			new web3._extend.Property({
+				name: 'runtimeConfig',
+				getter: 'olivetum_getRuntimeConfig',
+				outputFormatter: formatResult
+			}),
+			new web3._extend.Property({
+				name: 'supply',
+				getter: 'olivetum_getSupply',
+				outputFormatter: formatResult
+			}),
+			new web3._extend.Property({
+				name: 'finalizedHeight',
+				getter: 'olivetum_getFinalizedHeight',
+				outputFormatter: utils.toDecimal
+			}),
+		]
+	});
+
+	web3._extend({
+		property: 'eth',
+		methods: [
+			new web3._extend.Method({
+				name: 'getTxAllowance',
+				call: 'eth_getTxAllowance',
+				params: 1,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter],
+				outputFormatter: utils.toDecimal
+			}),
+			new web3._extend.Method({
+				name: 'getTxLimits',
+				call: 'eth_getTxLimits',
+				params: 1,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter],
+				outputFormatter: formatResult
+			}),
+			new web3._extend.Method({
+				name: 'getTxUsage',
+				call: 'eth_getTxUsage',
+				params: 1,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter],
+				outputFormatter: decimals
+			}),
+			new web3._extend.Method({
+				name: 'getDividendStatus',
+				call: 'eth_getDividendStatus',
+				params: 1,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter],
+				outputFormatter: formatResult
+			}),
+			new web3._extend.Method({
+				name: 'getDividendView',
+				call: 'eth_getDividendView',
+				params: 1,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter],
+				outputFormatter: formatResult
+			}),
+			new web3._extend.Method({
+				name: 'getOffSessionBudget',
+				call: 'eth_getOffSessionBudget',
+				params: 1,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter],
+				outputFormatter: formatResult
+			}),
+			new web3._extend.Method({
+				name: 'getEconomyStats',
+				call: 'eth_getEconomyStats',
+				params: 0,
+				outputFormatter: formatResult
+			}),
+		],
+		properties: [
+			new web3._extend.Property({
+				name: 'economyStats',
+				getter: 'eth_getEconomyStats',
+				outputFormatter: formatResult
+			}),
+		]
+	});
+
+	var olivetum = web3.olivetum;
+
+	// Addresses of the management contracts.
+	olivetum.contracts = {
+		burn: '0x0000000000000000000000000000000000000b00',
+		gasLimit: '0x0000000000000000000000000000000000000b01',
+		period: '0x0000000000000000000000000000000000000b02',
+		minTxAmount: '0x0000000000000000000000000000000000000b03',
+		txRateLimit: '0x0000000000000000000000000000000000000b04',
+		offSessionTxRate: '0x0000000000000000000000000000000000000b05',
+		offSessionMaxPerTx: '0x0000000000000000000000000000000000000b06',
+		sessionTz: '0x0000000000000000000000000000000000000b07',
+		sessionCalendar: '0x0000000000000000000000000000000000000b08',
+		addressList: '0x0000000000000000000000000000000000000b09',
+		batchTransfer: '0x0000000000000000000000000000000000000b0a',
+		dividend: '0x000000000000000000000000000000000000d1e1'
+	};
+	// Address classes of the address list.
+	olivetum.addressClasses = {
+		minAmountExempt: 0x01,
+		rateLimitExempt: 0x02,
+		offSessionExempt: 0x04,
+		frozen: 0x08
+	};
+
+	olivetum.toOlivo = function(wei) { return utils.fromWei(wei, 'ether'); };
+	olivetum.fromOlivo = function(olivo) { return utils.toWei(olivo, 'ether'); };
+
+	// uint encodes an unsigned integer as a big-endian value of the given size.
+	var uint = function(name, value, size) {
+		var n = utils.toBigNumber(value);
+		if (!n.isInteger() || n.isNegative() || n.greaterThanOrEqualTo(utils.toBigNumber(2).pow(8 * size))) {
+			throw new Error('invalid ' + name + ': ' + value);
+		}
+		return utils.padLeft(n.toString(16), 2 * size);
+	};
+	// units encodes an Olivo amount as the 8-byte number of units of unitWei.
+	var units = function(name, olivo, unitWei) {
+		var n = utils.toBigNumber(utils.toWei(olivo, 'ether'));
+		if (!n.modulo(unitWei).isZero()) {
+			throw new Error('invalid ' + name + ': ' + olivo + ' is not a multiple of ' + utils.fromWei(unitWei, 'ether') + ' Olivo');
+		}
+		return uint(name, n.dividedBy(unitWei), 8);
+	};
+	var send = function(to, data, from) {
+		return web3.eth.sendTransaction({
+			from: from || web3.eth.defaultAccount,
+			to: to,
+			value: 0,
+			data: '0x' + data
+		});
+	};
+
+	olivetum.setBurnRate = function(index, from) {
+		return send(olivetum.contracts.burn, uint('burn rate index', index, 1), from);
+	};
+	olivetum.setDividendRate = function(index, from) {
+		return send(olivetum.contracts.dividend, uint('dividend rate index', index, 1), from);
+	};
+	olivetum.claimDividend = function(from) {
+		return send(olivetum.contracts.dividend, '', from);
+	};
+	olivetum.setGasLimit = function(millions, from) {
+		return send(olivetum.contracts.gasLimit, uint('gas limit', millions, 1), from);
+	};
+	olivetum.setBlockPeriod = function(seconds, from) {
+		return send(olivetum.contracts.period, uint('block period', seconds, 1), from);
+	};
+	olivetum.setMinTxAmount = function(olivo, from) {
+		return send(olivetum.contracts.minTxAmount, units('min tx amount', olivo, '1000000000000000'), from);
+	};
+	olivetum.setTxRateLimit = function(perHour, from) {
+		return send(olivetum.contracts.txRateLimit, uint('tx rate limit', perHour, 1), from);
+	};
+	olivetum.setOffSessionTxRate = function(perHour, from) {
+		return send(olivetum.contracts.offSessionTxRate, uint('off-session tx rate', perHour, 1), from);
+	};
+	olivetum.setOffSessionMaxPerTx = function(olivo, from) {
+		return send(olivetum.contracts.offSessionMaxPerTx, units('off-session max per tx', olivo, '100000000000000'), from);
+	};
+	olivetum.setSessionTzOffset = function(seconds, from) {
+		var n = utils.toBigNumber(seconds);
+		if (n.isNegative()) {
+			n = n.plus('4294967296');
+		}
+		return send(olivetum.contracts.sessionTz, uint('session tz offset', n, 4), from);
+	};
+	olivetum.setSessionHours = function(weekday, open, close, from) {
+		var data = '01' + uint('weekday', weekday, 1) + uint('open hour', open, 1) + uint('close hour', close, 1);
+		return send(olivetum.contracts.sessionCalendar, data, from);
+	};
+	var holiday = function(op, year, month, day) {
+		return op + uint('year', year, 2) + uint('month', month, 1) + uint('day', day, 1);
+	};
+	olivetum.addSessionHoliday = function(year, month, day, from) {
+		return send(olivetum.contracts.sessionCalendar, holiday('02', year, month, day), from);
+	};
+	olivetum.removeSessionHoliday = function(year, month, day, from) {
+		return send(olivetum.contracts.sessionCalendar, holiday('03', year, month, day), from);
+	};
+	olivetum.resetSessionCalendar = function(from) {
+		return send(olivetum.contracts.sessionCalendar, '04', from);
+	};
+	var addressList = function(op, address, cls) {
+		return op + uint('address class', cls, 1) + web3._extend.formatters.inputAddressFormatter(address).slice(2).toLowerCase();
+	};
+	olivetum.addAddressClass = function(address, cls, from) {
+		return send(olivetum.contracts.addressList, addressList('01', address, cls), from);
+	};
+	olivetum.removeAddressClass = function(address, cls, from) {
+		return send(olivetum.contracts.addressList, addressList('02', address, cls), from);
+	};
+})();
+`
+
+const OlivetumhashJs = `
+web3._extend({
+	property: 'olivetumhash',
+	methods: [
+		new web3._extend.Method({
+			name: 'getWork',
+			call: 'olivetumhash_getWork',
+			params: 0
+		}),
+		new web3._extend.Method({
+			name: 'getWorkFor',
+			call: 'olivetumhash_getWorkFor',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getHashrate',
+			call: 'olivetumhash_getHashrate',
+			params: 0,
+			outputFormatter: web3._extend.utils.toDecimal
+		}),
+		new web3._extend.Method({
+			name: 'submitWork',
+			call: 'olivetumhash_submitWork',
+			params: 3,
+		}),
+		new web3._extend.Method({
+			name: 'submitWorkFor',
+			call: 'olivetumhash_submitWorkFor',
+			params: 4,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, null, null]
+		}),
+		new web3._extend.Method({
+			name: 'submitHashrate',
+			call: 'olivetumhash_submitHashrate',
+			params: 2,
+		}),
+		new web3._extend.Method({
+			name: 'submitHashrateFor',
+			call: 'olivetumhash_submitHashrateFor',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, null]
+		}),
+		new web3._extend.Method({
+			name: 'gatewayStats',
+			call: 'olivetumhash_gatewayStats',
+			params: 0
+		}),
+	]
+});
+`
@@ -0,0 +1,93 @@
+package web3ext
+
+import (
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/olivetumtx"
+	"github.com/ethereum/go-ethereum/internal/jsre"
+	"github.com/ethereum/go-ethereum/internal/jsre/deps"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+// TestOlivetumAdminHelpers checks that the console helpers send the payloads
+// the management contracts accept.
+func TestOlivetumAdminHelpers(t *testing.T) {
+	re := jsre.New("", os.Stdout)
+	defer re.Stop(false)
+	for name, src := range map[string]string{"bignumber.js": deps.BigNumberJS, "web3.js": deps.Web3JS} {
+		if err := re.Compile(name, src); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+	if _, err := re.Run("var Web3 = require('web3'); var web3 = new Web3();"); err != nil {
+		t.Fatal(err)
+	}
+	if err := re.Compile("olivetum.js", OlivetumJs); err != nil {
+		t.Fatalf("olivetum.js: %v", err)
+	}
+	if err := re.Compile("olivetumhash.js", OlivetumhashJs); err != nil {
+		t.Fatalf("olivetumhash.js: %v", err)
+	}
+	if _, err := re.Run("var olivetum = web3.olivetum; web3.eth.sendTransaction = function(tx) { return tx.to + ' ' + tx.data; };"); err != nil {
+		t.Fatal(err)
+	}
+	must := func(call *olivetumtx.Call, err error) *olivetumtx.Call {
+		if err != nil {
+			t.Fatal(err)
+		}
+		return call
+	}
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	tests := []struct {
+		js   string
+		want *olivetumtx.Call
+	}{
+		{"olivetum.setBurnRate(2)", &olivetumtx.Call{To: must(olivetumtx.SetBurnRate(150)).To, Data: []byte{2}}},
+		{"olivetum.claimDividend()", olivetumtx.ClaimDividend()},
+		{"olivetum.setGasLimit(30)", must(olivetumtx.SetGasLimit(30_000_000))},
+		{"olivetum.setBlockPeriod(15)", must(olivetumtx.SetBlockPeriod(15))},
+		{"olivetum.setMinTxAmount('0.025')", must(olivetumtx.SetMinTxAmount(new(big.Int).Div(big.NewInt(vars.Ether), big.NewInt(40))))},
+		{"olivetum.setOffSessionMaxPerTx(5)", must(olivetumtx.SetOffSessionMaxPerTx(new(big.Int).Mul(big.NewInt(5), big.NewInt(vars.Ether))))},
+		{"olivetum.setTxRateLimit(20)", must(olivetumtx.SetTxRateLimit(20))},
+		{"olivetum.setOffSessionTxRate(5)", must(olivetumtx.SetOffSessionTxRate(5))},
+		{"olivetum.setSessionTzOffset(-3600)", must(olivetumtx.SetSessionTzOffset(-3600))},
+		{"olivetum.setSessionHours(1, 9, 17)", must(olivetumtx.SetSessionHours(time.Monday, 9, 17))},
+		{"olivetum.addSessionHoliday(2024, 12, 25)", must(olivetumtx.AddSessionHoliday(2024, time.December, 25))},
+		{"olivetum.removeSessionHoliday(2024, 12, 25)", must(olivetumtx.RemoveSessionHoliday(2024, time.December, 25))},
+		{"olivetum.resetSessionCalendar()", olivetumtx.ResetSessionCalendar()},
+		{"olivetum.addAddressClass('" + addr.Hex() + "', olivetum.addressClasses.frozen)", must(olivetumtx.AddAddressClass(addr, params.AddressClassFrozen))},
+		{"olivetum.removeAddressClass('" + addr.Hex() + "', 1)", must(olivetumtx.RemoveAddressClass(addr, params.AddressClassMinAmountExempt))},
+	}
+	for _, tt := range tests {
+		v, err := re.Run(tt.js)
+		if err != nil {
+			t.Errorf("%s: %v", tt.js, err)
+			continue
+		}
+		want := strings.ToLower(tt.want.To.Hex()) + " " + hexutil.Encode(tt.want.Data)
+		if tt.want.Data == nil {
+			want = strings.ToLower(tt.want.To.Hex()) + " 0x"
+		}
+		if have := v.String(); have != want {
+			t.Errorf("%s: have %s want %s", tt.js, have, want)
+		}
+	}
+	for _, js := range []string{"olivetum.setMinTxAmount('0.0001')", "olivetum.setBurnRate(256)", "olivetum.setBlockPeriod(-1)"} {
+		if _, err := re.Run(js); err == nil {
+			t.Errorf("%s: expected error", js)
+		}
+	}
+	if v, err := re.Run("typeof web3.eth.getTxLimits + typeof web3.olivetumhash.submitWorkFor + olivetum.toOlivo('1500000000000000000')"); err != nil || v.String() != "functionfunction1.5" {
+		t.Errorf("bindings: have %v, %v", v, err)
+	}
+	if v, err := re.Run("olivetum.contracts.batchTransfer"); err != nil || v.String() != strings.ToLower(params.BatchTransferContract.Hex()) {
+		t.Errorf("batch transfer contract: have %v, %v", v, err)
+	}
+}
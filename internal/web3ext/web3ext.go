@@ -18,20 +18,22 @@
 package web3ext
 
 var Modules = map[string]string{
-	"admin":    AdminJs,
-	"clique":   CliqueJs,
-	"ethash":   EthashJs,
-	"debug":    DebugJs,
-	"eth":      EthJs,
-	"miner":    MinerJs,
-	"net":      NetJs,
-	"personal": PersonalJs,
-	"rpc":      RpcJs,
-	"trace":    TraceJs,
-	"txpool":   TxpoolJs,
-	"les":      LESJs,
-	"vflux":    VfluxJs,
-	"dev":      DevJs,
+	"admin":        AdminJs,
+	"clique":       CliqueJs,
+	"ethash":       EthashJs,
+	"debug":        DebugJs,
+	"eth":          EthJs,
+	"miner":        MinerJs,
+	"net":          NetJs,
+	"personal":     PersonalJs,
+	"rpc":          RpcJs,
+	"trace":        TraceJs,
+	"txpool":       TxpoolJs,
+	"les":          LESJs,
+	"vflux":        VfluxJs,
+	"dev":          DevJs,
+	"olivetum":     OlivetumJs,
+	"olivetumhash": OlivetumhashJs,
 }
 
 const CliqueJs = `
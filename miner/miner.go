@@ -270,3 +270,20 @@ func (miner *Miner) SubscribePendingLogs(ch chan<- []*types.Log) event.Subscript
 func (miner *Miner) BuildPayload(args *BuildPayloadArgs) (*Payload, error) {
 	return miner.worker.buildPayload(args)
 }
+
+// SealingBlock assembles an unsealed block on top of the given parent, filled
+// with the pending transactions of the pool. The timestamp is moved past the
+// parent if needed and the consensus engine may advance it further. It lets
+// proof-of-work chains be driven block by block outside the engine API.
+func (miner *Miner) SealingBlock(parent common.Hash, timestamp uint64, coinbase common.Address) (*types.Block, error) {
+	result := miner.worker.getSealingBlock(&generateParams{
+		timestamp:  timestamp,
+		parentHash: parent,
+		coinbase:   coinbase,
+		noUncle:    true,
+	})
+	if result.err != nil {
+		return nil, result.err
+	}
+	return result.block, nil
+}
@@ -0,0 +1,607 @@
+package miner
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/olivetumhash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/olivetumtx"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/params/vars"
+)
+
+var (
+	// olivetumGenesisTime is a Friday morning before the trading session
+	// opens. Genesis holdings qualify for dividends 31 days later, on Monday
+	// the 4th of March.
+	olivetumGenesisTime = uint64(time.Date(2024, time.February, 2, 11, 0, 0, 0, time.UTC).Unix())
+
+	olivetumCoinbase  = common.HexToAddress("0x000000000000000000000000000000000000c0fe")
+	olivetumOutsiders = []common.Address{
+		common.HexToAddress("0x00000000000000000000000000000000000000aa"),
+		common.HexToAddress("0x00000000000000000000000000000000000000bb"),
+	}
+
+	// Management calls are priced below transfers, so that the worker orders
+	// them last in a block like the harness does.
+	olivetumTransferPrice   = big.NewInt(2 * vars.GWei)
+	olivetumManagementPrice = big.NewInt(vars.GWei)
+
+	errOlivetumReverted = errors.New("transaction reverted")
+)
+
+// olivetumEther returns n Olivo in wei.
+func olivetumEther(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(vars.Ether))
+}
+
+// olivetumSubmission is a transaction fed to the pool with the pool verdict.
+type olivetumSubmission struct {
+	tx  *types.Transaction
+	err error
+}
+
+// olivetumHarness feeds transaction streams through the transaction pool and
+// the worker of an Olivetum chain. Every block it executes the submitted
+// transactions on top of the head and checks that exactly those the pool
+// accepted execute, that the worker builds the block from the same
+// transactions and that the pool is empty once the block is imported.
+type olivetumHarness struct {
+	t      *testing.T
+	chain  *core.BlockChain
+	pool   *txpool.TxPool
+	worker *worker
+	signer types.Signer
+
+	admin *ecdsa.PrivateKey
+	keys  []*ecdsa.PrivateKey // Funded accounts, the administrator first
+
+	pending []olivetumSubmission
+
+	// Accounts paid and claiming dividends in the random stream of the block
+	paid, claiming map[common.Address]bool
+}
+
+func newOlivetumHarness(t *testing.T) *olivetumHarness {
+	var keys []*ecdsa.PrivateKey
+	for i := 1; i <= 4; i++ {
+		key, _ := crypto.ToECDSA(common.LeftPadBytes([]byte{byte(i)}, 32))
+		keys = append(keys, key)
+	}
+	oldEconomy, oldMemo, oldBatch := params.GetEconomyForkBlock(), params.TransferMemoFork.Block(), params.BatchTransferFork.Block()
+	oldCalendar, oldList := params.SessionCalendarFork.Block(), params.AddressListFork.Block()
+	t.Cleanup(func() {
+		params.SetEconomyForkBlock(oldEconomy)
+		params.TransferMemoFork.SetBlock(oldMemo)
+		params.BatchTransferFork.SetBlock(oldBatch)
+		params.SessionCalendarFork.SetBlock(oldCalendar)
+		params.AddressListFork.SetBlock(oldList)
+
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+		core.ApplyOlivetumRuntime(statedb, nil)
+	})
+	params.SetEconomyForkBlock(big.NewInt(1))
+	params.TransferMemoFork.SetBlock(big.NewInt(1))
+	params.BatchTransferFork.SetBlock(big.NewInt(1))
+	params.SessionCalendarFork.SetBlock(big.NewInt(1))
+	params.AddressListFork.SetBlock(big.NewInt(1))
+
+	genesis := core.NewOlivetumTestGenesis(olivetumGenesisTime).WithAdmin(keys[0])
+	for _, key := range keys {
+		genesis.Fund(key, olivetumEther(1_000_000))
+	}
+	gspec := genesis.Genesis()
+
+	engine := olivetumhash.NewFaker()
+	t.Cleanup(func() { engine.Close() })
+
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), &core.CacheConfig{TrieDirtyDisabled: true}, gspec, nil, engine, vm.Config{}, nil, nil)
+	if err != nil {
+		t.Fatalf("core.NewBlockChain failed: %v", err)
+	}
+	t.Cleanup(chain.Stop)
+
+	h := &olivetumHarness{
+		t:      t,
+		chain:  chain,
+		signer: types.LatestSigner(gspec.Config),
+		admin:  keys[0],
+		keys:   keys,
+
+		paid:     make(map[common.Address]bool),
+		claiming: make(map[common.Address]bool),
+	}
+	h.resetRuntime()
+
+	pool, err := txpool.New(testTxPoolConfig.PriceLimit, chain, []txpool.SubPool{legacypool.New(testTxPoolConfig, chain)})
+	if err != nil {
+		t.Fatalf("txpool.New failed: %v", err)
+	}
+	t.Cleanup(func() { pool.Close() })
+	h.pool = pool
+
+	// Stop the background loops, the harness drives the worker directly
+	h.worker = newWorker(testConfig, gspec.Config, engine, &testWorkerBackend{chain: chain, txPool: pool}, new(event.TypeMux), nil, false)
+	h.worker.close()
+	return h
+}
+
+// resetRuntime loads the runtime parameters of the head state, undoing the
+// changes of management transactions executed on top of it.
+func (h *olivetumHarness) resetRuntime() {
+	statedb, err := h.chain.State()
+	if err != nil {
+		h.t.Fatalf("head state: %v", err)
+	}
+	core.ApplyOlivetumRuntime(statedb, nil)
+}
+
+// submit signs a transaction of key with the next pool nonce, adds it to the
+// pool and records the verdict for the next block.
+func (h *olivetumHarness) submit(key *ecdsa.PrivateKey, to *common.Address, value *big.Int, data []byte, price *big.Int) error {
+	tx := types.MustSignNewTx(key, h.signer, &types.LegacyTx{
+		Nonce:    h.pool.Nonce(crypto.PubkeyToAddress(key.PublicKey)),
+		To:       to,
+		Value:    value,
+		Gas:      200_000,
+		GasPrice: price,
+		Data:     data,
+	})
+	err := h.pool.Add([]*types.Transaction{tx}, true, true)[0]
+	h.pending = append(h.pending, olivetumSubmission{tx: tx, err: err})
+	return err
+}
+
+// manage submits a management call of the administrator.
+func (h *olivetumHarness) manage(call *olivetumtx.Call, err error) error {
+	if err != nil {
+		h.t.Fatalf("management call: %v", err)
+	}
+	return h.submit(h.admin, &call.To, new(big.Int), call.Data, olivetumManagementPrice)
+}
+
+// describe identifies a submitted transaction in failures.
+func (h *olivetumHarness) describe(i int, tx *types.Transaction) string {
+	from, _ := types.Sender(h.signer, tx)
+	to := "creation"
+	if tx.To() != nil {
+		to = tx.To().Hex()
+	}
+	return fmt.Sprintf("block %d tx %d from %s to %s value %v data %x", h.chain.CurrentBlock().Number.Uint64()+1, i, from.Hex(), to, tx.Value(), tx.Data())
+}
+
+// mine executes the submitted transactions in the block after the head and
+// imports the block the worker builds from the pool.
+func (h *olivetumHarness) mine() {
+	h.t.Helper()
+	h.mineAt(core.PendingOlivetumTime(h.chain.CurrentBlock()))
+}
+
+// mineAt is like mine, but seals the block at the given timestamp. A block
+// sealed later than the pool expected may leave out transactions the pool
+// admitted, along with the following transactions of their senders; these stay
+// in the pool. The left out transactions are returned.
+func (h *olivetumHarness) mineAt(timestamp uint64) []*types.Transaction {
+	h.t.Helper()
+
+	head := h.chain.CurrentBlock()
+	late := timestamp != core.PendingOlivetumTime(head)
+	genParams := &generateParams{
+		timestamp: timestamp,
+		forceTime: true,
+		coinbase:  olivetumCoinbase,
+		noUncle:   true,
+	}
+	env, err := h.worker.prepareWork(genParams)
+	if err != nil {
+		h.t.Fatalf("prepare work: %v", err)
+	}
+	defer env.discard()
+	if env.header.Time != genParams.timestamp {
+		h.t.Fatalf("block time %d, pool expected %d", env.header.Time, genParams.timestamp)
+	}
+	env.gasPool = new(core.GasPool).AddGas(env.header.GasLimit)
+
+	var (
+		executed = make(map[common.Hash]bool)
+		skipped  = make(map[common.Address]bool)
+		left     []*types.Transaction
+	)
+	for i, sub := range h.pending {
+		if late {
+			if sub.err != nil {
+				continue
+			}
+			from, _ := types.Sender(h.signer, sub.tx)
+			if skipped[from] || h.execute(env, sub.tx) != nil {
+				skipped[from] = true
+				left = append(left, sub.tx)
+				continue
+			}
+			executed[sub.tx.Hash()] = true
+			continue
+		}
+		err := h.execute(env, sub.tx)
+		switch {
+		case sub.err == nil && err != nil:
+			h.t.Fatalf("%s: accepted by the pool, failed execution: %v", h.describe(i, sub.tx), err)
+		case sub.err != nil && err == nil:
+			h.t.Fatalf("%s: rejected by the pool (%v), executed", h.describe(i, sub.tx), sub.err)
+		case err == nil:
+			executed[sub.tx.Hash()] = true
+		}
+	}
+	// Build the block from the pool on the head parameters again
+	h.resetRuntime()
+	result := h.worker.generateWork(genParams)
+	if result.err != nil {
+		h.t.Fatalf("generate work: %v", result.err)
+	}
+	block := result.block
+	if have, want := len(block.Transactions()), len(executed); have != want {
+		h.t.Fatalf("block %d: worker included %d transactions, %d executed", block.NumberU64(), have, want)
+	}
+	for _, tx := range block.Transactions() {
+		if !executed[tx.Hash()] {
+			h.t.Fatalf("block %d: worker included unexecuted transaction %x", block.NumberU64(), tx.Hash())
+		}
+	}
+	h.resetRuntime()
+	if _, err := h.chain.InsertChain(types.Blocks{block}); err != nil {
+		h.t.Fatalf("block %d import failed: %v", block.NumberU64(), err)
+	}
+	receipts := h.chain.GetReceiptsByHash(block.Hash())
+	for i, tx := range block.Transactions() {
+		if isDividendCall(tx) && receipts[i].Status != types.ReceiptStatusSuccessful {
+			h.t.Fatalf("block %d: dividend call %d reverted", block.NumberU64(), i)
+		}
+	}
+	h.resetRuntime()
+	if err := h.pool.Sync(); err != nil {
+		h.t.Fatalf("pool sync: %v", err)
+	}
+	if pending, queued := h.pool.Stats(); pending != len(left) || queued != 0 {
+		h.t.Fatalf("block %d: %d pending and %d queued transactions left in the pool, want %d pending", block.NumberU64(), pending, queued, len(left))
+	}
+	h.pending = h.pending[:0]
+	clear(h.paid)
+	clear(h.claiming)
+	return left
+}
+
+// execute applies tx to the block environment like the worker does. Dividend
+// calls the contract does not honour revert rather than fail, but the pool
+// rejects them, so a reverted dividend call is undone and the stream of the
+// sender goes on without it. Other reverted calls, such as the removal of an
+// address from a class it is not in, are included by both.
+func (h *olivetumHarness) execute(env *environment, tx *types.Transaction) error {
+	var (
+		prev = env.state.Copy() // Executed transactions are finalised, snapshots are gone
+		gp   = env.gasPool.Gas()
+		used = env.header.GasUsed
+	)
+	env.state.SetTxContext(tx.Hash(), len(env.txs))
+	receipt, err := h.worker.applyTransaction(env, tx)
+	if err != nil {
+		return err
+	}
+	if isDividendCall(tx) && receipt.Status != types.ReceiptStatusSuccessful {
+		env.state = prev
+		env.gasPool.SetGas(gp)
+		env.header.GasUsed = used
+		return errOlivetumReverted
+	}
+	env.txs = append(env.txs, tx)
+	env.receipts = append(env.receipts, receipt)
+	return nil
+}
+
+func isDividendCall(tx *types.Transaction) bool {
+	return tx.To() != nil && *tx.To() == core.DividendContract
+}
+
+// advance imports an empty block at the given timestamp.
+func (h *olivetumHarness) advance(timestamp uint64) {
+	h.t.Helper()
+
+	if len(h.pending) > 0 {
+		h.t.Fatal("advancing time with submitted transactions")
+	}
+	result := h.worker.generateWork(&generateParams{
+		timestamp: timestamp,
+		forceTime: true,
+		coinbase:  olivetumCoinbase,
+		noUncle:   true,
+		noTxs:     true,
+	})
+	if result.err != nil {
+		h.t.Fatalf("generate empty block: %v", result.err)
+	}
+	if _, err := h.chain.InsertChain(types.Blocks{result.block}); err != nil {
+		h.t.Fatalf("empty block import failed: %v", err)
+	}
+	h.resetRuntime()
+	if err := h.pool.Sync(); err != nil {
+		h.t.Fatalf("pool sync: %v", err)
+	}
+}
+
+// Tests that the pool and the worker agree on random transaction streams
+// crossing session boundaries, runtime parameter updates, address list changes
+// and dividend rounds.
+func TestOlivetumPoolWorkerConsistency(t *testing.T) {
+	for seed := int64(1); seed <= 4; seed++ {
+		t.Run(fmt.Sprintf("seed-%d", seed), func(t *testing.T) {
+			testOlivetumPoolWorkerConsistency(t, seed, 40)
+		})
+	}
+}
+
+func testOlivetumPoolWorkerConsistency(t *testing.T, seed int64, blocks int) {
+	var (
+		h   = newOlivetumHarness(t)
+		rng = rand.New(rand.NewSource(seed))
+
+		steps = []uint64{10 * 60, 60 * 60, 5 * 60 * 60, 13 * 60 * 60}
+	)
+	// Start when the genesis holdings qualify for dividends
+	h.advance(olivetumGenesisTime + 31*24*60*60)
+	for i := 0; i < blocks; i++ {
+		if rng.Intn(3) == 0 {
+			h.advance(h.chain.CurrentBlock().Time + steps[rng.Intn(len(steps))])
+		}
+		h.randomBlock(rng)
+		h.mine()
+	}
+}
+
+// randomBlock submits a random transaction stream for the next block. A
+// management call changes the rules of the transactions after it, so it ends
+// the block.
+func (h *olivetumHarness) randomBlock(rng *rand.Rand) {
+	for n := 1 + rng.Intn(8); n > 0; n-- {
+		if rng.Intn(8) == 0 {
+			h.randomManagement(rng)
+			return
+		}
+		h.randomTransaction(rng)
+	}
+}
+
+func (h *olivetumHarness) randomAmount(rng *rand.Rand) *big.Int {
+	switch rng.Intn(5) {
+	case 0:
+		return new(big.Int)
+	case 1:
+		return big.NewInt(vars.GWei)
+	case 2:
+		return olivetumEther(10)
+	case 3:
+		return olivetumEther(50)
+	default:
+		return olivetumEther(20_000)
+	}
+}
+
+func (h *olivetumHarness) randomAccount(rng *rand.Rand) common.Address {
+	i := rng.Intn(len(h.keys) + len(olivetumOutsiders))
+	if i < len(h.keys) {
+		return crypto.PubkeyToAddress(h.keys[i].PublicKey)
+	}
+	return olivetumOutsiders[i-len(h.keys)]
+}
+
+// randomRecipient returns a random account to pay in the block.
+//
+// The genesis balance of an account only becomes its dividend holding with its
+// first claim, and a payment received before that claim in the same block
+// leaves it out, reverting the claim. The pool judges each sender on its own
+// and cannot tell which of the two transactions the block orders first, so
+// the stream never pays an account claiming in the same block.
+func (h *olivetumHarness) randomRecipient(rng *rand.Rand) common.Address {
+	addr := h.randomAccount(rng)
+	if h.claiming[addr] {
+		addr = olivetumOutsiders[0]
+	}
+	h.paid[addr] = true
+	return addr
+}
+
+// randomTransaction submits a transaction of a random funded account.
+func (h *olivetumHarness) randomTransaction(rng *rand.Rand) {
+	var (
+		key  = h.keys[rng.Intn(len(h.keys))]
+		from = crypto.PubkeyToAddress(key.PublicKey)
+		to   = h.randomRecipient(rng)
+	)
+	switch rng.Intn(10) {
+	case 0, 1, 2, 3, 4:
+		h.submit(key, &to, h.randomAmount(rng), nil, olivetumTransferPrice)
+	case 5:
+		memo, err := olivetumtx.TransferMemo([]byte("invoice"))
+		if err != nil {
+			h.t.Fatalf("memo: %v", err)
+		}
+		h.submit(key, &to, h.randomAmount(rng), memo, olivetumTransferPrice)
+	case 6:
+		entries := make([]params.BatchTransferEntry, 1+rng.Intn(3))
+		for i := range entries {
+			entries[i] = params.BatchTransferEntry{To: h.randomRecipient(rng), Amount: h.randomAmount(rng)}
+		}
+		call, err := olivetumtx.BatchTransfer(entries)
+		if err != nil {
+			h.t.Fatalf("batch transfer: %v", err)
+		}
+		h.submit(key, &call.To, new(big.Int), call.Data, olivetumTransferPrice)
+	case 7:
+		if h.paid[from] {
+			return
+		}
+		h.claiming[from] = true
+
+		value := new(big.Int)
+		if rng.Intn(4) == 0 {
+			value.SetUint64(1)
+		}
+		dividend := core.DividendContract
+		h.submit(key, &dividend, value, nil, olivetumTransferPrice)
+	case 8:
+		// Management calls of anyone but the administrator
+		key = h.keys[1+rng.Intn(len(h.keys)-1)]
+		call, _ := olivetumtx.SetTxRateLimit(params.TxRateLimitMax)
+		h.submit(key, &call.To, new(big.Int), call.Data, olivetumTransferPrice)
+	default:
+		h.submit(key, nil, new(big.Int), []byte{0x60, 0x00}, olivetumTransferPrice)
+	}
+}
+
+// randomManagement submits a random management call of the administrator.
+func (h *olivetumHarness) randomManagement(rng *rand.Rand) {
+	var (
+		classes = []uint8{
+			params.AddressClassFrozen,
+			params.AddressClassRateLimitExempt,
+			params.AddressClassOffSessionExempt,
+			params.AddressClassMinAmountExempt,
+		}
+		// Never freeze the administrator, which would end the stream
+		addr  = h.randomAccount(rng)
+		class = classes[rng.Intn(len(classes))]
+	)
+	if addr == crypto.PubkeyToAddress(h.admin.PublicKey) {
+		addr = olivetumOutsiders[0]
+	}
+	switch rng.Intn(9) {
+	case 0:
+		h.manage(olivetumtx.SetMinTxAmount(olivetumEther([]int64{1, 10, 20}[rng.Intn(3)])))
+	case 1:
+		h.manage(olivetumtx.SetTxRateLimit([]uint64{1, 2, 5, 10}[rng.Intn(4)]))
+	case 2:
+		h.manage(olivetumtx.SetOffSessionTxRate([]uint64{1, 2, 5}[rng.Intn(3)]))
+	case 3:
+		h.manage(olivetumtx.SetOffSessionMaxPerTx(olivetumEther([]int64{5, 100, 10_000}[rng.Intn(3)])))
+	case 4:
+		h.manage(olivetumtx.SetBurnRate([]uint64{50, 150, 300}[rng.Intn(3)]))
+	case 5:
+		h.manage(olivetumtx.AddAddressClass(addr, class))
+	case 6:
+		h.manage(olivetumtx.RemoveAddressClass(addr, class))
+	case 7:
+		h.manage(olivetumtx.SetDividendRate(100))
+	default:
+		next := time.Unix(int64(core.PendingOlivetumTime(h.chain.CurrentBlock())), 0).UTC()
+		if rng.Intn(2) == 0 {
+			h.manage(olivetumtx.SetSessionHours(next.Weekday(), 0, 0))
+		} else {
+			h.manage(olivetumtx.SetSessionHours(next.Weekday(), 12, 24))
+		}
+	}
+}
+
+// Tests that the pool judges transactions by the session of the block they are
+// expected in rather than that of the head: a transfer above the off-session
+// maximum is admitted just before the session opens and rejected just before
+// it closes.
+func TestOlivetumPoolWorkerSessionBoundary(t *testing.T) {
+	var (
+		h      = newOlivetumHarness(t)
+		sender = h.keys[1]
+		to     = olivetumOutsiders[0]
+		day    = time.Unix(int64(olivetumGenesisTime), 0).UTC().Truncate(24 * time.Hour)
+		period = params.GetBlockPeriod()
+	)
+	if err := h.manage(olivetumtx.SetOffSessionMaxPerTx(olivetumEther(5))); err != nil {
+		t.Fatalf("off-session maximum update rejected: %v", err)
+	}
+	h.mine()
+
+	// The head is off-session, the next block in the session
+	h.advance(uint64(day.Add(12*time.Hour).Unix()) - period + 5)
+	if core.IsSession(h.chain.CurrentBlock().Time) {
+		t.Fatal("head in the session")
+	}
+	if err := h.submit(sender, &to, olivetumEther(100), nil, olivetumTransferPrice); err != nil {
+		t.Fatalf("transfer opening the session rejected: %v", err)
+	}
+	h.mine()
+
+	// The head is in the session, the next block off-session
+	h.advance(uint64(day.Add(24*time.Hour).Unix()) - period + 5)
+	if !core.IsSession(h.chain.CurrentBlock().Time) {
+		t.Fatal("head off-session")
+	}
+	if err := h.submit(sender, &to, olivetumEther(100), nil, olivetumTransferPrice); !errors.Is(err, core.ErrOverMaxOffSessionBudget) {
+		t.Fatalf("transfer closing the session: have %v, want %v", err, core.ErrOverMaxOffSessionBudget)
+	}
+	h.mine()
+}
+
+// Tests blocks sealed later than the pool expects, as the worker stamps them
+// with the wall clock: transactions admitted for the expected time but not
+// allowed at the sealed time are left out of the block rather than failing or
+// reverting in it.
+func TestOlivetumPoolWorkerLateSeal(t *testing.T) {
+	var (
+		day    = time.Unix(int64(olivetumGenesisTime), 0).UTC().Truncate(24 * time.Hour)
+		period = params.GetBlockPeriod()
+		to     = olivetumOutsiders[0]
+	)
+	t.Run("session-close", func(t *testing.T) {
+		h := newOlivetumHarness(t)
+		if err := h.manage(olivetumtx.SetOffSessionMaxPerTx(olivetumEther(50))); err != nil {
+			t.Fatalf("off-session maximum update rejected: %v", err)
+		}
+		h.mine()
+
+		// The next block is expected just before the session closes, but it
+		// is sealed after.
+		closing := uint64(day.Add(24 * time.Hour).Unix())
+		h.advance(closing - period - 30)
+		if err := h.submit(h.keys[1], &to, olivetumEther(100), nil, olivetumTransferPrice); err != nil {
+			t.Fatalf("transfer above the off-session maximum rejected: %v", err)
+		}
+		large := h.pending[len(h.pending)-1].tx
+		if err := h.submit(h.keys[2], &to, olivetumEther(10), nil, olivetumTransferPrice); err != nil {
+			t.Fatalf("transfer below the off-session maximum rejected: %v", err)
+		}
+		left := h.mineAt(closing + 60)
+		if len(left) != 1 || left[0].Hash() != large.Hash() {
+			t.Fatalf("left out %d transactions, want the transfer above the off-session maximum", len(left))
+		}
+	})
+	t.Run("claim-window", func(t *testing.T) {
+		h := newOlivetumHarness(t)
+		h.advance(olivetumGenesisTime + 31*24*60*60)
+		if err := h.manage(olivetumtx.SetDividendRate(100)); err != nil {
+			t.Fatalf("dividend round rejected: %v", err)
+		}
+		h.mine()
+
+		// The claim is expected in the last seconds of the claim window, but
+		// sealed after it closed.
+		start := h.chain.CurrentBlock().Time
+		h.advance(start + 24*60*60 - period - 10)
+		dividend := core.DividendContract
+		if err := h.submit(h.keys[1], &dividend, new(big.Int), nil, olivetumTransferPrice); err != nil {
+			t.Fatalf("claim rejected: %v", err)
+		}
+		if left := h.mineAt(start + 24*60*60 + 60); len(left) != 1 || *left[0].To() != dividend {
+			t.Fatalf("left out %d transactions, want the claim", len(left))
+		}
+	})
+}
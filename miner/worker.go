@@ -38,6 +38,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/params/mutations"
 	"github.com/ethereum/go-ethereum/params/types/ctypes"
 	"github.com/ethereum/go-ethereum/params/vars"
@@ -973,6 +974,13 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 	}
 	var coalescedLogs []*types.Log
 
+	// The pool admits Olivetum transactions for the expected time of the block,
+	// which may precede its actual time. Most rules are enforced again by the
+	// state transition, but dividend calls would revert rather than fail.
+	var olivetumRules *core.OlivetumRules
+	if params.IsOlivetumConfig(w.chainConfig) {
+		olivetumRules = core.NewOlivetumRules(env.header.Number, env.header.Time, params.GetAddressClasses)
+	}
 	for {
 		// Check interruption signal and abort building if it's fired.
 		if interrupt != nil {
@@ -1044,6 +1052,13 @@ func (w *worker) commitTransactions(env *environment, plainTxs, blobTxs *transac
 			txs.Pop()
 			continue
 		}
+		if olivetumRules != nil && tx.To() != nil && *tx.To() == core.DividendContract {
+			if err := olivetumRules.CheckDividendCall(env.state, from, tx.Value(), tx.Data()); err != nil {
+				log.Trace("Skipping dividend call reverting at the block time", "hash", ltx.Hash, "err", err)
+				txs.Pop()
+				continue
+			}
+		}
 		// Start executing the transaction
 		env.state.SetTxContext(tx.Hash(), env.tcount)
 
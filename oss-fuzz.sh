@@ -106,6 +106,23 @@ compile_fuzzer github.com/ethereum/go-ethereum/core/types \
   FuzzRLP fuzzRlp \
   $repo/core/types/rlp_fuzzer_test.go
 
+compile_fuzzer github.com/ethereum/go-ethereum/params \
+  FuzzOlivetumDecoders fuzzOlivetumDecoders \
+  $repo/params/olivetum_fuzz_test.go
+
+pkg=$repo/core/
+compile_fuzzer github.com/ethereum/go-ethereum/core \
+  FuzzValidateOlivetumTxPayload fuzzOlivetumTxPayload \
+  $pkg/olivetum_fuzz_test.go,$pkg/olivetum_dividend_test.go,$pkg/olivetum_dividend_buckets_test.go
+
+compile_fuzzer github.com/ethereum/go-ethereum/core \
+  FuzzOlivetumHoldings fuzzOlivetumHoldings \
+  $pkg/olivetum_fuzz_test.go,$pkg/olivetum_dividend_test.go,$pkg/olivetum_dividend_buckets_test.go
+
+compile_fuzzer github.com/ethereum/go-ethereum/core \
+  FuzzOlivetumOffSessionBudget fuzzOlivetumOffSessionBudget \
+  $pkg/olivetum_fuzz_test.go,$pkg/olivetum_dividend_test.go,$pkg/olivetum_dividend_buckets_test.go
+
 compile_fuzzer github.com/ethereum/go-ethereum/crypto/blake2b \
   Fuzz fuzzBlake2b \
   $repo/crypto/blake2b/blake2b_f_fuzz_test.go
@@ -0,0 +1,100 @@
+package params
+
+import "github.com/ethereum/go-ethereum/common"
+
+// Address classes managed by the administrator through AddressListContract.
+// Classes are bit flags so an address may belong to several of them.
+//
+// The payload is 22 bytes: op (0x01 add, 0x02 remove), class, address.
+const (
+	AddressClassMinAmountExempt  uint8 = 0x01
+	AddressClassRateLimitExempt  uint8 = 0x02
+	AddressClassOffSessionExempt uint8 = 0x04
+	AddressClassFrozen           uint8 = 0x08
+
+	AddressListOpAdd    byte = 0x01
+	AddressListOpRemove byte = 0x02
+
+	// AddressListPayloadLength is the size of an address list update payload.
+	AddressListPayloadLength = 2 + common.AddressLength
+
+	// AddressListMax bounds the number of listed addresses so the list can be
+	// mirrored from state on every block.
+	AddressListMax = 256
+)
+
+var (
+	AddressListAdmin    = TxRateLimitAdmin
+	AddressListContract = common.HexToAddress("0x0000000000000000000000000000000000000b09")
+
+	currentAddressClasses = map[common.Address]uint8{}
+)
+
+// AddressListUpdate is a decoded address list management payload.
+type AddressListUpdate struct {
+	Op      byte
+	Class   uint8
+	Address common.Address
+}
+
+// IsAddressClass reports whether class is exactly one known address class.
+func IsAddressClass(class uint8) bool {
+	switch class {
+	case AddressClassMinAmountExempt, AddressClassRateLimitExempt, AddressClassOffSessionExempt, AddressClassFrozen:
+		return true
+	default:
+		return false
+	}
+}
+
+// DecodeAddressListUpdate expects op (1 byte), class (1 byte) and the 20-byte
+// address.
+func DecodeAddressListUpdate(data []byte) (AddressListUpdate, bool) {
+	if len(data) != AddressListPayloadLength {
+		return AddressListUpdate{}, false
+	}
+	if data[0] != AddressListOpAdd && data[0] != AddressListOpRemove {
+		return AddressListUpdate{}, false
+	}
+	if !IsAddressClass(data[1]) {
+		return AddressListUpdate{}, false
+	}
+	return AddressListUpdate{
+		Op:      data[0],
+		Class:   data[1],
+		Address: common.BytesToAddress(data[2:]),
+	}, true
+}
+
+// GetAddressClasses returns the runtime class flags of an address.
+func GetAddressClasses(addr common.Address) uint8 { return currentAddressClasses[addr] }
+
+// HasAddressClass reports whether the address is in the given runtime class.
+func HasAddressClass(addr common.Address, class uint8) bool {
+	return currentAddressClasses[addr]&class != 0
+}
+
+// SetAddressClasses replaces the runtime address classes.
+func SetAddressClasses(classes map[common.Address]uint8) {
+	next := make(map[common.Address]uint8, len(classes))
+	for addr, flags := range classes {
+		if flags != 0 {
+			next[addr] = flags
+		}
+	}
+	currentAddressClasses = next
+}
+
+// SetAddressClass updates the runtime class flags of a single address.
+func SetAddressClass(addr common.Address, flags uint8) {
+	next := make(map[common.Address]uint8, len(currentAddressClasses)+1)
+	for a, f := range currentAddressClasses {
+		next[a] = f
+	}
+	if flags == 0 {
+		delete(next, addr)
+	} else {
+		next[addr] = flags
+	}
+	currentAddressClasses = next
+}
@@ -0,0 +1,49 @@
+package params
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// A batch transfer is a zero-value transaction to BatchTransferContract whose
+// payload is a list of entries, each the 20-byte recipient followed by the
+// 32-byte big-endian amount. Every entry is settled like a plain transfer from
+// the sender.
+const (
+	BatchTransferEntryLength   = common.AddressLength + 32
+	BatchTransferMaxRecipients = 256
+
+	// BatchTransferGasPerRecipient is charged per entry on top of the
+	// intrinsic gas of the transaction.
+	BatchTransferGasPerRecipient uint64 = 9000
+)
+
+var BatchTransferContract = common.HexToAddress("0x0000000000000000000000000000000000000b0a")
+
+// BatchTransferEntry is a single payout of a batch transfer.
+type BatchTransferEntry struct {
+	To     common.Address
+	Amount *big.Int
+}
+
+// DecodeBatchTransfer splits a batch transfer payload into its entries. The
+// payload must hold between 1 and BatchTransferMaxRecipients whole entries.
+func DecodeBatchTransfer(data []byte) ([]BatchTransferEntry, bool) {
+	if len(data) == 0 || len(data)%BatchTransferEntryLength != 0 {
+		return nil, false
+	}
+	n := len(data) / BatchTransferEntryLength
+	if n > BatchTransferMaxRecipients {
+		return nil, false
+	}
+	entries := make([]BatchTransferEntry, n)
+	for i := range entries {
+		entry := data[i*BatchTransferEntryLength : (i+1)*BatchTransferEntryLength]
+		entries[i] = BatchTransferEntry{
+			To:     common.BytesToAddress(entry[:common.AddressLength]),
+			Amount: new(big.Int).SetBytes(entry[common.AddressLength:]),
+		}
+	}
+	return entries, true
+}
@@ -0,0 +1,5 @@
+package params
+
+// DividendAutoBatchSize bounds the number of holders paid out per block by the
+// automatic dividend distribution.
+const DividendAutoBatchSize = 100
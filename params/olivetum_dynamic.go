@@ -15,9 +15,10 @@ var (
 	PeriodContract   = common.HexToAddress("0x0000000000000000000000000000000000000b02")
 
 	currentGasLimit = vars.GenesisGasLimit
-	// default to the clique genesis period so blocks continue sealing even
-	// without runtime configuration transactions
-	currentPeriod uint64 = 15
+	// BlockPeriodDefault is the clique genesis period, so blocks continue
+	// sealing even without runtime configuration transactions.
+	BlockPeriodDefault uint64 = 15
+	currentPeriod             = BlockPeriodDefault
 
 	// MaxReorgDepth defines the maximum number of blocks a canonical reorg is
 	// allowed to roll back for Olivetum. Reorgs deeper than this are rejected.
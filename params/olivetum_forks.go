@@ -0,0 +1,84 @@
+package params
+
+import "math/big"
+
+// Olivetum feature fork schedule.
+//
+// The features below activate together at olivetumFeatureForkBlock, after the
+// economy fork. Like the difficulty forks, the heights are compiled in: move
+// them only together with a release that reaches the network before the chain
+// does.
+const olivetumFeatureForkBlock = 300000
+
+var (
+	// SessionCalendarFork activates SessionCalendarContract: it only accepts
+	// its administrator and executes calendar updates. Before it the address
+	// is a plain account.
+	SessionCalendarFork = newOlivetumFork(olivetumFeatureForkBlock)
+
+	// AddressListFork activates AddressListContract, which only accepts its
+	// administrator and executes list updates, and stops frozen accounts from
+	// sending and receiving. Before it the address is a plain account.
+	AddressListFork = newOlivetumFork(olivetumFeatureForkBlock)
+
+	// BatchTransferFork executes transactions to BatchTransferContract as
+	// batch transfers.
+	BatchTransferFork = newOlivetumFork(olivetumFeatureForkBlock)
+
+	// TransferMemoFork lets plain transfers carry a memo payload.
+	TransferMemoFork = newOlivetumFork(olivetumFeatureForkBlock)
+
+	// RewardBurnFork records the burn taken from block rewards in the supply
+	// counters.
+	RewardBurnFork = newOlivetumFork(olivetumFeatureForkBlock)
+
+	// DividendAutoFork pays the rewards of a triggered dividend round out to
+	// the indexed holders across the following blocks, without claim
+	// transactions. Holders are indexed when their holdings change or they
+	// claim; holders untouched since the fork still have to claim.
+	DividendAutoFork = newOlivetumFork(olivetumFeatureForkBlock)
+
+	// DividendBucketFork merges the credits to the recent holding queue of an
+	// account per day and compacts the queues built under the old rules when
+	// they are next touched.
+	DividendBucketFork = newOlivetumFork(olivetumFeatureForkBlock)
+)
+
+// OlivetumFork is the activation height of an Olivetum hard fork. A fork
+// without a height is not scheduled; a fork at height zero is active from
+// genesis.
+type OlivetumFork struct {
+	block *big.Int
+}
+
+func newOlivetumFork(block int64) *OlivetumFork {
+	return &OlivetumFork{block: big.NewInt(block)}
+}
+
+// Block returns the activation height of the fork, nil if it is not scheduled.
+func (f *OlivetumFork) Block() *big.Int {
+	if f.block == nil {
+		return nil
+	}
+	return new(big.Int).Set(f.block)
+}
+
+// SetBlock schedules the fork at the given height, or unschedules it if block
+// is nil.
+func (f *OlivetumFork) SetBlock(block *big.Int) {
+	if block == nil {
+		f.block = nil
+		return
+	}
+	f.block = new(big.Int).Set(block)
+}
+
+// Active reports whether the fork is active at the given block.
+func (f *OlivetumFork) Active(num *big.Int) bool {
+	return f.block != nil && num != nil && num.Cmp(f.block) >= 0
+}
+
+// ActivatesAt reports whether the given block is the first one of the fork.
+func (f *OlivetumFork) ActivatesAt(num *big.Int) bool {
+	return f.block != nil && num != nil && num.Cmp(f.block) == 0
+}
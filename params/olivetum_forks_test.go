@@ -0,0 +1,41 @@
+package params
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestOlivetumFeatureForkSchedule(t *testing.T) {
+	forks := map[string]*OlivetumFork{
+		"session calendar": SessionCalendarFork,
+		"address list":     AddressListFork,
+		"batch transfer":   BatchTransferFork,
+		"transfer memo":    TransferMemoFork,
+		"reward burn":      RewardBurnFork,
+		"dividend auto":    DividendAutoFork,
+		"dividend buckets": DividendBucketFork,
+	}
+	before, at := big.NewInt(olivetumFeatureForkBlock-1), big.NewInt(olivetumFeatureForkBlock)
+	for name, fork := range forks {
+		if fork.Active(before) {
+			t.Errorf("%s fork active before its height", name)
+		}
+		if !fork.Active(at) || !fork.ActivatesAt(at) {
+			t.Errorf("%s fork not activated at its height", name)
+		}
+		if fork.ActivatesAt(new(big.Int).Add(at, big.NewInt(1))) {
+			t.Errorf("%s fork activated after its height", name)
+		}
+	}
+}
+
+func TestOlivetumForkUnscheduled(t *testing.T) {
+	fork := newOlivetumFork(0)
+	if !fork.Active(big.NewInt(0)) {
+		t.Fatalf("fork at genesis not active")
+	}
+	fork.SetBlock(nil)
+	if fork.Block() != nil || fork.Active(big.NewInt(1<<40)) || fork.ActivatesAt(big.NewInt(0)) {
+		t.Fatalf("unscheduled fork active")
+	}
+}
@@ -0,0 +1,116 @@
+package params
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// FuzzOlivetumDecoders feeds management and transfer payloads to the Olivetum
+// decoders and checks that accepted payloads decode to values within the
+// bounds the runtime parameters rely on.
+func FuzzOlivetumDecoders(f *testing.F) {
+	f.Add([]byte{15})
+	f.Add([]byte{0, 0, 0, 0, 0, 0, 0x03, 0xe8})
+	f.Add([]byte{0xff, 0xff, 0xb9, 0xb0})
+	f.Add(append([]byte{AddressListOpAdd, AddressClassFrozen}, common.Address{0x01}.Bytes()...))
+	f.Add([]byte{SessionCalendarOpSetHours, 1, 9, 17})
+	f.Add([]byte{SessionCalendarOpAddHoliday, 0x07, 0xe8, 12, 25})
+	f.Add(EncodeTransferMemo([]byte("invoice 42")))
+	f.Add(make([]byte, BatchTransferEntryLength))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if limit, ok := DecodeGasLimit(data); ok && limit != uint64(data[0])*1000000 {
+			t.Fatalf("gas limit %d decoded from %x", limit, data)
+		}
+		if period, ok := DecodeBlockPeriod(data); ok && (period == 0 || period > 60) {
+			t.Fatalf("block period %d out of range", period)
+		}
+		if amount, ok := DecodeMinTxAmount(data); ok {
+			if amount.Cmp(MinTxAmountMin) < 0 || amount.Cmp(MinTxAmountMax) > 0 {
+				t.Fatalf("min tx amount %v out of range", amount)
+			}
+			if new(big.Int).Mod(amount, MinTxAmountMin).Sign() != 0 {
+				t.Fatalf("min tx amount %v not a multiple of the unit", amount)
+			}
+		}
+		if limit, ok := DecodeTxRateLimit(data); ok && (limit < TxRateLimitMin || limit > TxRateLimitMax) {
+			t.Fatalf("tx rate limit %d out of range", limit)
+		}
+		if rate, ok := DecodeOffSessionTxRate(data); ok && (rate < OffSessionTxRateMin || rate > OffSessionTxRateMax) {
+			t.Fatalf("off-session tx rate %d out of range", rate)
+		}
+		if amount, ok := DecodeOffSessionMaxPerTx(data); ok {
+			if amount.Cmp(OffSessionMaxPerTxMin) < 0 || amount.Cmp(OffSessionMaxPerTxMax) > 0 {
+				t.Fatalf("off-session max per tx %v out of range", amount)
+			}
+		}
+		if offset, ok := DecodeSessionTzOffset(data); ok {
+			if offset < -86400 || offset > 86400 {
+				t.Fatalf("session offset %d out of range", offset)
+			}
+			if int32(binary.BigEndian.Uint32(data)) != offset {
+				t.Fatalf("session offset %d decoded from %x", offset, data)
+			}
+		}
+		if u, ok := DecodeAddressListUpdate(data); ok {
+			if !IsAddressClass(u.Class) || (u.Op != AddressListOpAdd && u.Op != AddressListOpRemove) {
+				t.Fatalf("invalid address list update %+v", u)
+			}
+			if enc := append([]byte{u.Op, u.Class}, u.Address.Bytes()...); !bytes.Equal(enc, data) {
+				t.Fatalf("address list update %+v re-encodes to %x, want %x", u, enc, data)
+			}
+		}
+		if u, ok := DecodeSessionCalendarUpdate(data); ok {
+			checkSessionCalendarUpdate(t, u, data)
+		}
+		if memo, ok := DecodeTransferMemo(data); ok {
+			if len(memo) == 0 || len(memo) > TransferMemoMaxLength {
+				t.Fatalf("memo length %d out of range", len(memo))
+			}
+			if enc := EncodeTransferMemo(memo); !bytes.Equal(enc, data) {
+				t.Fatalf("memo re-encodes to %x, want %x", enc, data)
+			}
+		}
+		if entries, ok := DecodeBatchTransfer(data); ok {
+			if len(entries) == 0 || len(entries) > BatchTransferMaxRecipients {
+				t.Fatalf("batch of %d entries", len(entries))
+			}
+			var enc []byte
+			for _, e := range entries {
+				enc = append(enc, e.To.Bytes()...)
+				enc = append(enc, common.BigToHash(e.Amount).Bytes()...)
+			}
+			if !bytes.Equal(enc, data) {
+				t.Fatalf("batch re-encodes to %x, want %x", enc, data)
+			}
+		}
+	})
+}
+
+func checkSessionCalendarUpdate(t *testing.T, u SessionCalendarUpdate, data []byte) {
+	t.Helper()
+
+	switch u.Op {
+	case SessionCalendarOpSetHours:
+		if u.Weekday < time.Sunday || u.Weekday > time.Saturday {
+			t.Fatalf("weekday %d out of range", u.Weekday)
+		}
+		if closed := u.Hours.Open == 0 && u.Hours.Close == 0; !closed && (u.Hours.Open >= u.Hours.Close || u.Hours.Close > 24) {
+			t.Fatalf("invalid session hours %+v", u.Hours)
+		}
+	case SessionCalendarOpAddHoliday, SessionCalendarOpRemoveHoliday:
+		date := time.Unix(int64(uint64(u.Day)*secondsPerDay), 0).UTC()
+		enc := binary.BigEndian.AppendUint16([]byte{u.Op}, uint16(date.Year()))
+		if enc = append(enc, byte(date.Month()), byte(date.Day())); !bytes.Equal(enc, data) {
+			t.Fatalf("holiday %v re-encodes to %x, want %x", date, enc, data)
+		}
+	case SessionCalendarOpReset:
+	default:
+		t.Fatalf("unknown calendar op %d", u.Op)
+	}
+}
@@ -20,12 +20,9 @@ var (
 
 	currentMinTxAmount = new(big.Int).Set(MinTxAmountDefault)
 
+	// MinTxAmountAdmin is exempt as well, both as an account and as a sender.
 	minTxAmountExempt = map[common.Address]struct{}{
-		MinTxAmountAdmin: {},
-		DividendAddress:  {},
-	}
-	minTxAmountExemptSender = map[common.Address]struct{}{
-		MinTxAmountAdmin: {},
+		DividendAddress: {},
 	}
 	minTxAmountExemptRecipient = map[common.Address]struct{}{
 		DividendAddress: {},
@@ -54,12 +51,11 @@ func DecodeMinTxAmount(data []byte) (*big.Int, bool) {
 
 func IsMinTxAmountExempt(addr common.Address) bool {
 	_, ok := minTxAmountExempt[addr]
-	return ok
+	return ok || addr == MinTxAmountAdmin
 }
 
 func IsMinTxAmountExemptSender(addr common.Address) bool {
-	_, ok := minTxAmountExemptSender[addr]
-	return ok
+	return addr == MinTxAmountAdmin
 }
 
 func IsMinTxAmountExemptRecipient(addr common.Address) bool {
@@ -23,15 +23,17 @@ var (
 	OffSessionMaxPerTxContract = common.HexToAddress("0x0000000000000000000000000000000000000b06")
 
 	// Off-session tx/h bounds and default.
-	OffSessionTxRateMin uint64 = 1
-	OffSessionTxRateMax uint64 = 100
-	offSessionTxRate           = uint64(2) // default 2 tx/h off-session
+	OffSessionTxRateMin     uint64 = 1
+	OffSessionTxRateMax     uint64 = 100
+	OffSessionTxRateDefault uint64 = 2 // default 2 tx/h off-session
+	offSessionTxRate               = OffSessionTxRateDefault
 
 	// Off-session per-transaction maximum amount bounds and default.
 	// Units in wei. Bounds are 0.0001 .. 10000 Olivo.
-	OffSessionMaxPerTxMin = new(big.Int).Div(big.NewInt(vars.Ether), big.NewInt(10000)) // 0.0001 Olivo
-	OffSessionMaxPerTxMax = new(big.Int).Mul(big.NewInt(10000), big.NewInt(vars.Ether)) // 10000 Olivo
-	offSessionMaxPerTx    = new(big.Int).Set(OffSessionMaxPerTxMax)                     // default 10000 Olivo
+	OffSessionMaxPerTxMin     = new(big.Int).Div(big.NewInt(vars.Ether), big.NewInt(10000)) // 0.0001 Olivo
+	OffSessionMaxPerTxMax     = new(big.Int).Mul(big.NewInt(10000), big.NewInt(vars.Ether)) // 10000 Olivo
+	OffSessionMaxPerTxDefault = OffSessionMaxPerTxMax                                       // default 10000 Olivo
+	offSessionMaxPerTx        = new(big.Int).Set(OffSessionMaxPerTxDefault)
 )
 
 func GetOffSessionTxRate() uint64      { return offSessionTxRate }
@@ -0,0 +1,279 @@
+package params
+
+import (
+	"encoding/binary"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Session calendar management.
+//
+// The calendar describes, per local weekday, the hours during which the
+// market session is open, plus a bounded list of closed dates (holidays).
+// Local time is the block timestamp shifted by the session time offset. The
+// administrator updates the calendar by sending zero-value transactions with
+// one of the payloads below to SessionCalendarContract:
+//
+//	0x01 weekday open close   set the hours of a weekday (0 = Sunday); open ==
+//	                          close == 0 closes the weekday entirely, unless
+//	                          it is the last open weekday
+//	0x02 year(2) month day    add a closed date
+//	0x03 year(2) month day    remove a closed date
+//	0x04                      restore the default calendar
+
+const (
+	SessionCalendarOpSetHours      byte = 0x01
+	SessionCalendarOpAddHoliday    byte = 0x02
+	SessionCalendarOpRemoveHoliday byte = 0x03
+	SessionCalendarOpReset         byte = 0x04
+
+	// SessionHolidaysMax bounds the number of closed dates kept in state so
+	// loading the calendar stays cheap on every block.
+	SessionHolidaysMax = 64
+
+	secondsPerDay  = uint64(24 * time.Hour / time.Second)
+	secondsPerHour = uint64(time.Hour / time.Second)
+
+	// sessionSearchDays bounds how far boundary lookups walk the calendar.
+	sessionSearchDays = 400
+)
+
+var (
+	// Admin for the session calendar is the same network admin.
+	SessionCalendarAdmin = SessionTzAdmin
+
+	// Session calendar management address.
+	SessionCalendarContract = common.HexToAddress("0x0000000000000000000000000000000000000b08")
+
+	currentSessionCalendar = DefaultSessionCalendar()
+)
+
+// SessionHours is the open interval [Open, Close) of a weekday, in local
+// hours. A day with Open == Close is closed.
+type SessionHours struct {
+	Open  uint8
+	Close uint8
+}
+
+// IsClosed reports whether the weekday has no session at all.
+func (h SessionHours) IsClosed() bool { return h.Open >= h.Close }
+
+// SessionCalendar holds the weekly trading hours and the closed dates,
+// expressed as local days since the Unix epoch.
+type SessionCalendar struct {
+	Hours    [7]SessionHours
+	Holidays []uint32
+}
+
+// SessionCalendarUpdate is a decoded calendar management payload.
+type SessionCalendarUpdate struct {
+	Op      byte
+	Weekday time.Weekday
+	Hours   SessionHours
+	Day     uint32
+}
+
+// DefaultSessionCalendar returns the legacy schedule: Monday–Saturday from
+// 12:00 to 24:00, Sundays closed, no holidays.
+func DefaultSessionCalendar() SessionCalendar {
+	var cal SessionCalendar
+	for wd := time.Monday; wd <= time.Saturday; wd++ {
+		cal.Hours[wd] = SessionHours{Open: 12, Close: 24}
+	}
+	return cal
+}
+
+// Copy returns a deep copy of the calendar.
+func (c SessionCalendar) Copy() SessionCalendar {
+	cpy := c
+	cpy.Holidays = append([]uint32(nil), c.Holidays...)
+	return cpy
+}
+
+// IsAllClosed reports whether every weekday is closed, so that the session
+// never opens.
+func (c SessionCalendar) IsAllClosed() bool {
+	for _, h := range c.Hours {
+		if !h.IsClosed() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsHoliday reports whether the given local day is a closed date.
+func (c SessionCalendar) IsHoliday(day uint32) bool {
+	i := sort.Search(len(c.Holidays), func(i int) bool { return c.Holidays[i] >= day })
+	return i < len(c.Holidays) && c.Holidays[i] == day
+}
+
+// IsOpenLocal reports whether the session is open at the given local time.
+func (c SessionCalendar) IsOpenLocal(local uint64) bool {
+	day := local / secondsPerDay
+	if c.IsHoliday(uint32(day)) {
+		return false
+	}
+	h := c.Hours[weekdayOfDay(day)]
+	if h.IsClosed() {
+		return false
+	}
+	sec := local % secondsPerDay
+	return sec >= uint64(h.Open)*secondsPerHour && sec < uint64(h.Close)*secondsPerHour
+}
+
+// PeriodLocal returns whether the session is open at the given local time
+// together with the local start of the current period and the local start of
+// the next one. Boundaries further than the search horizon are clamped, except
+// that a period without a start within it, such as the single closed period of
+// a calendar without open weekdays, starts at the local epoch so that it keeps
+// the same off-session budget window.
+func (c SessionCalendar) PeriodLocal(local uint64) (open bool, start, end uint64) {
+	open = c.IsOpenLocal(local)
+	start, end = local-local%secondsPerDay, local-local%secondsPerDay+secondsPerDay
+
+	// Walk backwards over the candidate boundaries of each day until the
+	// state flips.
+	day := local / secondsPerDay
+	found := false
+	for i := uint64(0); i <= sessionSearchDays && !found; i++ {
+		if day < i {
+			start = 0
+			break
+		}
+		cands := c.dayBoundaries(day - i)
+		for j := len(cands) - 1; j >= 0; j-- {
+			b := cands[j]
+			if b > local || b == 0 {
+				continue
+			}
+			if c.IsOpenLocal(b-1) != open {
+				start, found = b, true
+				break
+			}
+		}
+		if !found {
+			start = (day - i) * secondsPerDay
+		}
+	}
+	if !found {
+		start = 0
+	}
+	// Walk forwards for the next flip.
+	found = false
+	for i := uint64(0); i <= sessionSearchDays && !found; i++ {
+		for _, b := range c.dayBoundaries(day + i) {
+			if b <= local {
+				continue
+			}
+			if c.IsOpenLocal(b) != open {
+				end, found = b, true
+				break
+			}
+		}
+		if !found {
+			end = (day + i + 1) * secondsPerDay
+		}
+	}
+	return open, start, end
+}
+
+// dayBoundaries lists the local timestamps at which the session state may
+// change during the given day, in ascending order.
+func (c SessionCalendar) dayBoundaries(day uint64) []uint64 {
+	base := day * secondsPerDay
+	h := c.Hours[weekdayOfDay(day)]
+	if h.IsClosed() || c.IsHoliday(uint32(day)) {
+		return []uint64{base}
+	}
+	return []uint64{base, base + uint64(h.Open)*secondsPerHour, base + uint64(h.Close)*secondsPerHour}
+}
+
+// Apply returns a copy of the calendar with the update applied. It reports
+// false if the update cannot be applied (last open weekday closed, holiday list
+// full, unknown date).
+func (c SessionCalendar) Apply(u SessionCalendarUpdate) (SessionCalendar, bool) {
+	next := c.Copy()
+	switch u.Op {
+	case SessionCalendarOpSetHours:
+		next.Hours[u.Weekday] = u.Hours
+		if next.IsAllClosed() {
+			return c, false
+		}
+	case SessionCalendarOpAddHoliday:
+		if next.IsHoliday(u.Day) {
+			return c, false
+		}
+		if len(next.Holidays) >= SessionHolidaysMax {
+			return c, false
+		}
+		next.Holidays = append(next.Holidays, u.Day)
+		sort.Slice(next.Holidays, func(i, j int) bool { return next.Holidays[i] < next.Holidays[j] })
+	case SessionCalendarOpRemoveHoliday:
+		i := sort.Search(len(next.Holidays), func(i int) bool { return next.Holidays[i] >= u.Day })
+		if i >= len(next.Holidays) || next.Holidays[i] != u.Day {
+			return c, false
+		}
+		next.Holidays = append(next.Holidays[:i], next.Holidays[i+1:]...)
+	case SessionCalendarOpReset:
+		next = DefaultSessionCalendar()
+	default:
+		return c, false
+	}
+	return next, true
+}
+
+func weekdayOfDay(day uint64) time.Weekday {
+	// 1970-01-01 was a Thursday.
+	return time.Weekday((day + 4) % 7)
+}
+
+func GetSessionCalendar() SessionCalendar    { return currentSessionCalendar.Copy() }
+func SetSessionCalendar(cal SessionCalendar) { currentSessionCalendar = cal.Copy() }
+
+// SessionCalendarPayloadLength returns the expected payload length for the
+// given calendar operation, or 0 if the operation is unknown.
+func SessionCalendarPayloadLength(op byte) int {
+	switch op {
+	case SessionCalendarOpSetHours:
+		return 4
+	case SessionCalendarOpAddHoliday, SessionCalendarOpRemoveHoliday:
+		return 5
+	case SessionCalendarOpReset:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// DecodeSessionCalendarUpdate parses a calendar management payload. Hours must
+// satisfy open < close <= 24, or open == close == 0 for a closed weekday.
+// Dates must be valid calendar dates from 1970 onwards.
+func DecodeSessionCalendarUpdate(data []byte) (SessionCalendarUpdate, bool) {
+	if len(data) == 0 || len(data) != SessionCalendarPayloadLength(data[0]) {
+		return SessionCalendarUpdate{}, false
+	}
+	u := SessionCalendarUpdate{Op: data[0]}
+	switch u.Op {
+	case SessionCalendarOpSetHours:
+		if data[1] > byte(time.Saturday) {
+			return SessionCalendarUpdate{}, false
+		}
+		open, close := data[2], data[3]
+		if !(open == 0 && close == 0) && (open >= close || close > 24) {
+			return SessionCalendarUpdate{}, false
+		}
+		u.Weekday = time.Weekday(data[1])
+		u.Hours = SessionHours{Open: open, Close: close}
+	case SessionCalendarOpAddHoliday, SessionCalendarOpRemoveHoliday:
+		year := int(binary.BigEndian.Uint16(data[1:3]))
+		month, day := time.Month(data[3]), int(data[4])
+		date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+		if year < 1970 || date.Year() != year || date.Month() != month || date.Day() != day {
+			return SessionCalendarUpdate{}, false
+		}
+		u.Day = uint32(uint64(date.Unix()) / secondsPerDay)
+	}
+	return u, true
+}
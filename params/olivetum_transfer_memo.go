@@ -0,0 +1,28 @@
+package params
+
+import "bytes"
+
+// A transfer memo is a short reference tag carried in the data of a plain
+// value transfer: TransferMemoPrefix followed by 1 to TransferMemoMaxLength
+// bytes of memo. It is paid for through the regular calldata gas.
+const TransferMemoMaxLength = 64
+
+// TransferMemoPrefix is the magic prefix ("OLVM") marking a memo payload.
+var TransferMemoPrefix = []byte{0x4f, 0x4c, 0x56, 0x4d}
+
+// DecodeTransferMemo returns the memo carried by a transfer payload.
+func DecodeTransferMemo(data []byte) ([]byte, bool) {
+	if !bytes.HasPrefix(data, TransferMemoPrefix) {
+		return nil, false
+	}
+	memo := data[len(TransferMemoPrefix):]
+	if len(memo) == 0 || len(memo) > TransferMemoMaxLength {
+		return nil, false
+	}
+	return memo, true
+}
+
+// EncodeTransferMemo builds the transfer payload carrying the given memo.
+func EncodeTransferMemo(memo []byte) []byte {
+	return append(append([]byte{}, TransferMemoPrefix...), memo...)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core"
 )
 
 var _ = (*stEnvMarshaling)(nil)
@@ -24,6 +25,7 @@ func (s stEnv) MarshalJSON() ([]byte, error) {
 		Timestamp     math.HexOrDecimal64   `json:"currentTimestamp"     gencodec:"required"`
 		BaseFee       *math.HexOrDecimal256 `json:"currentBaseFee"       gencodec:"optional"`
 		ExcessBlobGas *math.HexOrDecimal64  `json:"currentExcessBlobGas" gencodec:"optional"`
+		Olivetum      *core.OlivetumRuntime `json:"olivetum,omitempty" gencodec:"optional"`
 	}
 	var enc stEnv
 	enc.Coinbase = s.Coinbase
@@ -34,6 +36,7 @@ func (s stEnv) MarshalJSON() ([]byte, error) {
 	enc.Timestamp = math.HexOrDecimal64(s.Timestamp)
 	enc.BaseFee = (*math.HexOrDecimal256)(s.BaseFee)
 	enc.ExcessBlobGas = (*math.HexOrDecimal64)(s.ExcessBlobGas)
+	enc.Olivetum = s.Olivetum
 	return json.Marshal(&enc)
 }
 
@@ -48,6 +51,7 @@ func (s *stEnv) UnmarshalJSON(input []byte) error {
 		Timestamp     *math.HexOrDecimal64  `json:"currentTimestamp"     gencodec:"required"`
 		BaseFee       *math.HexOrDecimal256 `json:"currentBaseFee"       gencodec:"optional"`
 		ExcessBlobGas *math.HexOrDecimal64  `json:"currentExcessBlobGas" gencodec:"optional"`
+		Olivetum      *core.OlivetumRuntime `json:"olivetum,omitempty" gencodec:"optional"`
 	}
 	var dec stEnv
 	if err := json.Unmarshal(input, &dec); err != nil {
@@ -81,5 +85,8 @@ func (s *stEnv) UnmarshalJSON(input []byte) error {
 	if dec.ExcessBlobGas != nil {
 		s.ExcessBlobGas = (*uint64)(dec.ExcessBlobGas)
 	}
+	if dec.Olivetum != nil {
+		s.Olivetum = dec.Olivetum
+	}
 	return nil
 }
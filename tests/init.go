@@ -629,6 +629,20 @@ var Forks = map[string]ctypes.ChainConfigurator{
 		ShanghaiTime:            u64(0),
 		CancunTime:              u64(15_000),
 	},
+	// Olivetum is Berlin with the Olivetum chain ID, which enables the Olivetum
+	// transaction rules.
+	"Olivetum": &goethereum.ChainConfig{
+		ChainID:             big.NewInt(30216931),
+		HomesteadBlock:      big.NewInt(0),
+		EIP150Block:         big.NewInt(0),
+		EIP155Block:         big.NewInt(0),
+		EIP158Block:         big.NewInt(0),
+		ByzantiumBlock:      big.NewInt(0),
+		ConstantinopleBlock: big.NewInt(0),
+		PetersburgBlock:     big.NewInt(0),
+		IstanbulBlock:       big.NewInt(0),
+		BerlinBlock:         big.NewInt(0),
+	},
 }
 
 // AvailableForks returns the set of defined fork names
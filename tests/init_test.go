@@ -69,6 +69,7 @@ var (
 	executionSpecBlockchainTestDir = filepath.Join(".", "spec-tests", "fixtures", "blockchain_tests")
 	executionSpecStateTestDir      = filepath.Join(".", "spec-tests", "fixtures", "state_tests")
 	benchmarksDir                  = filepath.Join(".", "evm-benchmarks", "benchmarks")
+	olivetumStateTestDir           = filepath.Join(".", "olivetum")
 
 	baseDirETC           = filepath.Join(".", "testdata-etc")
 	stateTestDirETC      = filepath.Join(baseDirETC, "GeneralStateTests")
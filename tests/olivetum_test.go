@@ -0,0 +1,18 @@
+package tests
+
+import (
+	"testing"
+)
+
+// TestOlivetumState runs the Olivetum state tests, which pin the Olivetum
+// transaction rules: burns, minimum amounts, rate and off-session limits,
+// payload restrictions, dividends and management access.
+func TestOlivetumState(t *testing.T) {
+	st := new(testMatcher)
+	// The Olivetum runtime parameters are process globals, so the tests can't
+	// run in parallel.
+	st.noParallel = true
+	st.walk(t, olivetumStateTestDir, func(t *testing.T, name string, test *StateTest) {
+		execStateTest(t, st, test)
+	})
+}